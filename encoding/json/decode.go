@@ -1224,6 +1224,10 @@ func (d *Decoder) decodeType(valueJSON any, results typeDecodingResults) cadence
 		return cadence.NewMeteredPublicAccountKeysType(d.gauge)
 	case "AuthAccount.Contracts":
 		return cadence.NewMeteredAuthAccountContractsType(d.gauge)
+	case "AuthAccount.Capabilities":
+		return cadence.NewMeteredAuthAccountCapabilitiesType(d.gauge)
+	case "AuthAccount.Inbox":
+		return cadence.NewMeteredAuthAccountInboxType(d.gauge)
 	case "PublicAccount.Contracts":
 		return cadence.NewMeteredPublicAccountContractsType(d.gauge)
 	case "DeployedContract":
@@ -726,6 +726,8 @@ func prepareType(typ cadence.Type, results typePreparationResults) jsonValue {
 		cadence.PrivatePathType,
 		cadence.AccountKeyType,
 		cadence.AuthAccountContractsType,
+		cadence.AuthAccountCapabilitiesType,
+		cadence.AuthAccountInboxType,
 		cadence.AuthAccountKeysType,
 		cadence.AuthAccountType,
 		cadence.PublicAccountContractsType,
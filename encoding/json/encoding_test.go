@@ -1104,6 +1104,8 @@ func TestEncodeSimpleTypes(t *testing.T) {
 		cadence.PrivatePathType{},
 		cadence.AccountKeyType{},
 		cadence.AuthAccountContractsType{},
+		cadence.AuthAccountCapabilitiesType{},
+		cadence.AuthAccountInboxType{},
 		cadence.AuthAccountKeysType{},
 		cadence.AuthAccountType{},
 		cadence.PublicAccountContractsType{},
@@ -19,6 +19,10 @@
 package runtime
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
 	"fmt"
 	"testing"
 
@@ -26,6 +30,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/json"
@@ -311,7 +316,7 @@ func TestRuntimeAuthAccountKeys(t *testing.T) {
 		assert.Equal(
 			t,
 			[]string{
-				"AccountKey(keyIndex: 0, publicKey: PublicKey(publicKey: [1, 2, 3], signatureAlgorithm: SignatureAlgorithm(rawValue: 1)), hashAlgorithm: HashAlgorithm(rawValue: 3), weight: 100.00000000, isRevoked: false)",
+				"AccountKey(keyIndex: 0, publicKey: PublicKey(publicKey: [1, 2, 3], signatureAlgorithm: SignatureAlgorithm(rawValue: 1)), hashAlgorithm: HashAlgorithm(rawValue: 3), weight: 100.00000000, isRevoked: false, isActive: true)",
 			},
 			storage.logs,
 		)
@@ -373,7 +378,7 @@ func TestRuntimeAuthAccountKeys(t *testing.T) {
 		assert.Equal(t, revokedAccountKeyA, storage.returnedKey)
 	})
 
-	t.Run("revoke non-existing key", func(t *testing.T) {
+	t.Run("find revoked key", func(t *testing.T) {
 
 		t.Parallel()
 
@@ -388,8 +393,23 @@ func TestRuntimeAuthAccountKeys(t *testing.T) {
 			code: `
                 transaction {
                     prepare(signer: AuthAccount) {
-                        let key: AccountKey? = signer.keys.revoke(keyIndex: 5)
-                        assert(key == nil)
+                        let key = signer.keys.find(keyIndex: 0, includeRevoked: false) ?? panic("unexpectedly nil")
+                        assert(!key.isRevoked)
+
+                        signer.keys.revoke(keyIndex: 0)
+
+                        let revokedKey = signer.keys.find(keyIndex: 0, includeRevoked: true) ?? panic("unexpectedly nil")
+                        assert(revokedKey.isRevoked)
+                        assert(signer.keys.find(keyIndex: 0, includeRevoked: false) == nil)
+
+                        let publicKeys = getAccount(signer.address).keys
+                        assert(publicKeys.find(keyIndex: 0, includeRevoked: true)!.isRevoked)
+                        assert(publicKeys.find(keyIndex: 0, includeRevoked: false) == nil)
+
+                        // get returns revoked keys
+                        assert(signer.keys.get(keyIndex: 0)!.isRevoked)
+
+                        assert(signer.keys.find(keyIndex: 5, includeRevoked: true) == nil)
                     }
                 }`,
 			args: []cadence.Value{},
@@ -397,986 +417,3138 @@ func TestRuntimeAuthAccountKeys(t *testing.T) {
 
 		err := test.executeTransaction(rt, runtimeInterface)
 		require.NoError(t, err)
-		assert.Nil(t, storage.returnedKey)
+
+		assert.Equal(t, []*stdlib.AccountKey{revokedAccountKeyA}, storage.keys)
 	})
-}
 
-func TestRuntimeAuthAccountKeysAdd(t *testing.T) {
+	t.Run("add and revoke key with events disabled", func(t *testing.T) {
 
-	t.Parallel()
+		t.Parallel()
 
-	rt := newTestInterpreterRuntime()
+		storage := newTestAccountKeyStorage()
+		rt := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled:   true,
+			AccountKeyEventsDisabled: true,
+		})
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	pubKey := newBytesValue([]byte{1, 2, 3})
+		addAuthAccountKey(t, rt, runtimeInterface)
 
-	const code = `
-       transaction(publicKey: [UInt8]) {
-           prepare(signer: AuthAccount) {
-               let acct = AuthAccount(payer: signer)
-               acct.keys.add(
-                   publicKey: PublicKey(
-                       publicKey: publicKey,
-                       signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                   ),
-                   hashAlgorithm: HashAlgorithm.SHA3_256,
-                   weight: 100.0
-               )
-           }
-       }
-   `
+		assert.Equal(t, []*stdlib.AccountKey{accountKeyA}, storage.keys)
 
-	storage := newTestAccountKeyStorage()
-	runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
-	addPublicKeyValidation(runtimeInterface, nil)
+		test := accountKeyTestCase{
+			code: `
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = signer.keys.revoke(keyIndex: 0) ?? panic("unexpectedly nil")
+                        assert(key.isRevoked)
+                    }
+                }`,
+			args: []cadence.Value{},
+		}
 
-	nextTransactionLocation := newTransactionLocationGenerator()
+		err := test.executeTransaction(rt, runtimeInterface)
+		require.NoError(t, err)
 
-	err := rt.ExecuteTransaction(
-		Script{
-			Source:    []byte(code),
-			Arguments: encodeArgs([]cadence.Value{pubKey}),
-		},
-		Context{
-			Location:  nextTransactionLocation(),
-			Interface: runtimeInterface,
-		},
-	)
+		assert.Equal(t, []*stdlib.AccountKey{revokedAccountKeyA}, storage.keys)
+		assert.Empty(t, storage.events)
+	})
 
-	require.NoError(t, err)
-	assert.Len(t, storage.keys, 1)
+	t.Run("add key with failing event emission", func(t *testing.T) {
 
-	require.Len(t, storage.events, 2)
+		t.Parallel()
 
-	assert.EqualValues(t,
-		stdlib.AccountCreatedEventType.ID(),
-		storage.events[0].Type().ID(),
-	)
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	assert.EqualValues(t,
-		stdlib.AccountKeyAddedEventType.ID(),
-		storage.events[1].Type().ID(),
-	)
-}
+		emitErr := goerrors.New("event emission failed")
+		runtimeInterface.emitEvent = func(_ cadence.Event) error {
+			return emitErr
+		}
 
-func TestRuntimePublicAccountKeys(t *testing.T) {
+		test := accountKeyTestCase{
+			code: `
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = PublicKey(
+                            publicKey: "010203".decodeHex(),
+                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                        )
 
-	t.Parallel()
+                        signer.keys.add(
+                            publicKey: key,
+                            hashAlgorithm: HashAlgorithm.SHA3_256,
+                            weight: 100.0
+                        )
+                    }
+                }`,
+			args: []cadence.Value{},
+		}
 
-	t.Run("get key", func(t *testing.T) {
+		err := test.executeTransaction(rt, runtimeInterface)
+		require.ErrorIs(t, err, emitErr)
+
+		// The addition of the key was rolled back
+
+		assert.Equal(t, []*stdlib.AccountKey{revokedAccountKeyA}, storage.keys)
+		assert.Empty(t, storage.events)
+	})
+
+	t.Run("revoke non-existing key", func(t *testing.T) {
 
 		t.Parallel()
 
 		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
-
-		runtime := newTestInterpreterRuntime()
+		rt := newTestInterpreterRuntime()
 		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		addAuthAccountKey(t, rt, runtimeInterface)
 
 		test := accountKeyTestCase{
 			code: `
-              pub fun main(): AccountKey? {
-                  let acc = getAccount(0x02)
-                  return acc.keys.get(keyIndex: 0)
-              }
-            `,
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key: AccountKey? = signer.keys.revoke(keyIndex: 5)
+                        assert(key == nil)
+                    }
+                }`,
 			args: []cadence.Value{},
 		}
 
-		value, err := test.executeScript(runtime, runtimeInterface)
+		err := test.executeTransaction(rt, runtimeInterface)
 		require.NoError(t, err)
-		require.NotNil(t, value)
+		assert.Nil(t, storage.returnedKey)
+	})
 
-		require.IsType(t, cadence.Optional{}, value)
-		optionalValue := value.(cadence.Optional)
+	const totalWeightCode = `
+      transaction {
+          prepare(signer: AuthAccount) {
+              let key = PublicKey(
+                  publicKey: "010203".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+
+              for weight in [500.0, 300.0, 400.0] {
+                  signer.keys.add(
+                      publicKey: key,
+                      hashAlgorithm: HashAlgorithm.SHA3_256,
+                      weight: weight
+                  )
+              }
 
-		expectedValue := accountKeyExportedValue(
-			0,
-			[]byte{1, 2, 3},
-			sema.SignatureAlgorithmECDSA_P256,
-			sema.HashAlgorithmSHA3_256,
-			"100.0",
-			false,
-		)
+              assert(signer.keys.totalWeight() == 1200.0)
 
-		assert.Equal(t, expectedValue, optionalValue.Value)
-		assert.Equal(t, accountKeyA, storage.returnedKey)
+              signer.keys.revoke(keyIndex: 2)
 
-	})
+              assert(signer.keys.totalWeight() == 800.0)
+              assert(getAccount(signer.address).keys.totalWeight() == 800.0)
+          }
+      }
+    `
 
-	t.Run("get another key", func(t *testing.T) {
+	t.Run("total weight", func(t *testing.T) {
 
 		t.Parallel()
 
 		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
-
-		runtime := newTestInterpreterRuntime()
+		rt := newTestInterpreterRuntime()
 		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		// The host environment does not implement AccountKeysTotalWeightProvider,
+		// so the weights of the keys are summed
 
 		test := accountKeyTestCase{
-			code: `
-              pub fun main(): AccountKey? {
-                  let acc = getAccount(0x02)
-                  return acc.keys.get(keyIndex: 1)
-              }
-            `,
+			code: totalWeightCode,
 			args: []cadence.Value{},
 		}
 
-		value, err := test.executeScript(runtime, runtimeInterface)
+		err := test.executeTransaction(rt, runtimeInterface)
 		require.NoError(t, err)
-		require.NotNil(t, value)
+	})
 
-		require.IsType(t, cadence.Optional{}, value)
-		optionalValue := value.(cadence.Optional)
+	t.Run("total weight, provided by host", func(t *testing.T) {
 
-		expectedValue := accountKeyExportedValue(
-			1,
-			[]byte{4, 5, 6},
-			sema.SignatureAlgorithmECDSA_secp256k1,
-			sema.HashAlgorithmSHA3_256,
-			"100.0",
-			false,
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		var calls int
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(totalWeightCode),
+			},
+			Context{
+				Interface: testAccountKeysTotalWeightRuntimeInterface{
+					testRuntimeInterface: runtimeInterface,
+					getAccountKeysTotalWeight: func(_ Address) (uint64, error) {
+						calls++
+
+						var totalWeight uint64
+						for _, accountKey := range storage.keys {
+							if accountKey.IsRevoked {
+								continue
+							}
+							totalWeight += uint64(accountKey.Weight)
+						}
+						return totalWeight, nil
+					},
+				},
+				Location: common.TransactionLocation{},
+			},
 		)
+		require.NoError(t, err)
 
-		assert.Equal(t, expectedValue, optionalValue.Value)
-		assert.Equal(t, accountKeyB, storage.returnedKey)
+		assert.Equal(t, 3, calls)
 	})
 
-	t.Run("get non-existing key", func(t *testing.T) {
+	t.Run("meets threshold", func(t *testing.T) {
 
 		t.Parallel()
 
 		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
-
-		runtime := newTestInterpreterRuntime()
+		rt := newTestInterpreterRuntime()
 		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
 		test := accountKeyTestCase{
 			code: `
-                pub fun main(): AccountKey? {
-                    let acc = getAccount(0x02)
-                    return acc.keys.get(keyIndex: 4)
-                }
-            `,
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = PublicKey(
+                            publicKey: "010203".decodeHex(),
+                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                        )
+
+                        for weight in [500.0, 300.0, 400.0] {
+                            signer.keys.add(
+                                publicKey: key,
+                                hashAlgorithm: HashAlgorithm.SHA3_256,
+                                weight: weight
+                            )
+                        }
+
+                        assert(signer.keys.meetsThreshold(signedKeyIndices: [0, 2], threshold: 900.0))
+                        assert(signer.keys.meetsThreshold(signedKeyIndices: [0, 1, 2], threshold: 1000.0))
+                        assert(getAccount(signer.address).keys.meetsThreshold(signedKeyIndices: [0, 2], threshold: 900.0))
+
+                        // Not enough weight
+                        assert(!signer.keys.meetsThreshold(signedKeyIndices: [0, 1], threshold: 1000.0))
+                        assert(!signer.keys.meetsThreshold(signedKeyIndices: [], threshold: 1000.0))
+
+                        // Keys are only counted once
+                        assert(!signer.keys.meetsThreshold(signedKeyIndices: [0, 0, 0], threshold: 1000.0))
+                    }
+                }`,
 			args: []cadence.Value{},
 		}
 
-		value, err := test.executeScript(runtime, runtimeInterface)
+		err := test.executeTransaction(rt, runtimeInterface)
 		require.NoError(t, err)
-		require.NotNil(t, value)
-
-		require.IsType(t, cadence.Optional{}, value)
-		optionalValue := value.(cadence.Optional)
-
-		assert.Nil(t, optionalValue.Value)
 	})
 
-	t.Run("get revoked key", func(t *testing.T) {
+	t.Run("meets threshold, revoked and missing keys", func(t *testing.T) {
 
 		t.Parallel()
 
 		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, revokedAccountKeyA, accountKeyB)
-
-		runtime := newTestInterpreterRuntime()
+		rt := newTestInterpreterRuntime()
 		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
 		test := accountKeyTestCase{
 			code: `
-              pub fun main(): AccountKey? {
-                  let acc = getAccount(0x02)
-                  var keys: PublicAccount.Keys = acc.keys
-                  return keys.get(keyIndex: 0)
-              }
-            `,
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = PublicKey(
+                            publicKey: "010203".decodeHex(),
+                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                        )
+
+                        for weight in [1000.0, 1000.0] {
+                            signer.keys.add(
+                                publicKey: key,
+                                hashAlgorithm: HashAlgorithm.SHA3_256,
+                                weight: weight
+                            )
+                        }
+
+                        signer.keys.revoke(keyIndex: 1)
+
+                        assert(signer.keys.meetsThreshold(signedKeyIndices: [0], threshold: 1000.0))
+
+                        // Revoked keys are rejected, even if the threshold is met without them
+                        assert(!signer.keys.meetsThreshold(signedKeyIndices: [0, 1], threshold: 1000.0))
+
+                        // Missing keys are rejected
+                        assert(!signer.keys.meetsThreshold(signedKeyIndices: [0, 5], threshold: 1000.0))
+                    }
+                }`,
 			args: []cadence.Value{},
 		}
 
-		value, err := test.executeScript(runtime, runtimeInterface)
+		err := test.executeTransaction(rt, runtimeInterface)
 		require.NoError(t, err)
-		require.NotNil(t, value)
+	})
 
-		require.IsType(t, cadence.Optional{}, value)
-		optionalValue := value.(cadence.Optional)
+	t.Run("active key indices", func(t *testing.T) {
 
-		expectedValue := accountKeyExportedValue(
-			0,
-			[]byte{1, 2, 3},
-			sema.SignatureAlgorithmECDSA_P256,
-			sema.HashAlgorithmSHA3_256,
-			"100.0",
-			true,
-		)
+		t.Parallel()
 
-		assert.Equal(t, expectedValue, optionalValue.Value)
-		assert.Equal(t, revokedAccountKeyA, storage.returnedKey)
-	})
-}
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-func TestRuntimeHashAlgorithm(t *testing.T) {
+		test := accountKeyTestCase{
+			code: `
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = PublicKey(
+                            publicKey: "010203".decodeHex(),
+                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                        )
 
-	t.Parallel()
+                        assert(signer.keys.activeKeyIndices().length == 0)
+
+                        var i = 0
+                        while i < 5 {
+                            signer.keys.add(
+                                publicKey: key,
+                                hashAlgorithm: HashAlgorithm.SHA3_256,
+                                weight: 100.0
+                            )
+                            i = i + 1
+                        }
+
+                        signer.keys.revoke(keyIndex: 1)
+                        signer.keys.revoke(keyIndex: 4)
+
+                        let indices = signer.keys.activeKeyIndices()
+                        assert(indices.length == 3)
+                        assert(indices[0] == 0)
+                        assert(indices[1] == 2)
+                        assert(indices[2] == 3)
+
+                        let publicIndices = getAccount(signer.address).keys.activeKeyIndices()
+                        assert(publicIndices.length == 3)
+                        assert(publicIndices[0] == 0)
+                        assert(publicIndices[1] == 2)
+                        assert(publicIndices[2] == 3)
+                    }
+                }`,
+			args: []cadence.Value{},
+		}
 
-	rt := newTestInterpreterRuntime()
+		err := test.executeTransaction(rt, runtimeInterface)
+		require.NoError(t, err)
+	})
+}
 
-	script := []byte(`
-        pub fun main(): [HashAlgorithm?] {
-            var key1: HashAlgorithm? = HashAlgorithm.SHA3_256
+type testAccountKeysTotalWeightRuntimeInterface struct {
+	*testRuntimeInterface
+	getAccountKeysTotalWeight func(address Address) (uint64, error)
+}
 
-            var key2: HashAlgorithm? = HashAlgorithm(rawValue: 3)
+var _ AccountKeysTotalWeightProvider = testAccountKeysTotalWeightRuntimeInterface{}
 
-            var key3: HashAlgorithm? = HashAlgorithm(rawValue: 100)
-            return [key1, key2, key3]
-          }
-    `)
+func (i testAccountKeysTotalWeightRuntimeInterface) GetAccountKeysTotalWeight(address Address) (uint64, error) {
+	return i.getAccountKeysTotalWeight(address)
+}
 
-	storage := newTestLedger(nil, nil)
+type testAccountKeyActivatorRuntimeInterface struct {
+	*testRuntimeInterface
+	storage *testAccountKeyStorage
+}
 
-	runtimeInterface := &testRuntimeInterface{
-		storage: storage,
+var _ AccountKeyActivator = testAccountKeyActivatorRuntimeInterface{}
+
+func (i testAccountKeyActivatorRuntimeInterface) SetAccountKeyActive(_ Address, index int, active bool) error {
+	accountKey := i.storage.keys[index]
+	if active {
+		accountKey.Status = stdlib.AccountKeyStatusActive
+	} else {
+		accountKey.Status = stdlib.AccountKeyStatusInactive
 	}
+	return nil
+}
 
-	nextTransactionLocation := newTransactionLocationGenerator()
+func TestRuntimeAuthAccountKeysSetActive(t *testing.T) {
 
-	result, err := rt.ExecuteScript(
-		Script{
-			Source: script,
-		},
-		Context{
-			Interface: runtimeInterface,
-			Location:  nextTransactionLocation(),
-		},
-	)
-	require.NoError(t, err)
+	t.Parallel()
 
-	require.IsType(t, cadence.Array{}, result)
-	array := result.(cadence.Array)
+	executeTransaction := func(rt Runtime, runtimeInterface Interface, code string) error {
+		return rt.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
 
-	require.Len(t, array.Values, 3)
+	t.Run("toggle", func(t *testing.T) {
 
-	// Check key1
-	require.IsType(t, cadence.Optional{}, array.Values[0])
-	optionalValue := array.Values[0].(cadence.Optional)
+		t.Parallel()
 
-	require.IsType(t, cadence.Enum{}, optionalValue.Value)
-	builtinStruct := optionalValue.Value.(cadence.Enum)
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	require.Len(t, builtinStruct.Fields, 1)
-	assert.Equal(t,
-		cadence.NewUInt8(HashAlgorithmSHA3_256.RawValue()),
-		builtinStruct.Fields[0],
-	)
+		addAuthAccountKey(t, rt, runtimeInterface)
 
-	// Check key2
-	require.IsType(t, cadence.Optional{}, array.Values[1])
-	optionalValue = array.Values[1].(cadence.Optional)
+		err := executeTransaction(
+			rt,
+			testAccountKeyActivatorRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				storage:              storage,
+			},
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      assert(signer.keys.get(keyIndex: 0)!.isActive)
+
+                      signer.keys.setActive(keyIndex: 0, active: false)
+                      let key = signer.keys.get(keyIndex: 0)!
+                      assert(!key.isActive)
+                      assert(!key.isRevoked)
+                      assert(!getAccount(signer.address).keys.get(keyIndex: 0)!.isActive)
+
+                      signer.keys.setActive(keyIndex: 0, active: true)
+                      assert(signer.keys.get(keyIndex: 0)!.isActive)
+                  }
+              }
+            `,
+		)
+		require.NoError(t, err)
 
-	require.IsType(t, cadence.Enum{}, optionalValue.Value)
-	builtinStruct = optionalValue.Value.(cadence.Enum)
+		require.Len(t, storage.keys, 1)
+		assert.Equal(t, stdlib.AccountKeyStatusActive, storage.keys[0].Status)
+	})
 
-	require.Len(t, builtinStruct.Fields, 1)
-	assert.Equal(t,
-		cadence.NewUInt8(HashAlgorithmSHA3_256.RawValue()),
-		builtinStruct.Fields[0],
-	)
+	t.Run("active key indices", func(t *testing.T) {
 
-	// Check key3
-	require.IsType(t, cadence.Optional{}, array.Values[2])
-	optionalValue = array.Values[2].(cadence.Optional)
+		t.Parallel()
 
-	require.Nil(t, optionalValue.Value)
-}
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-func TestRuntimeSignatureAlgorithm(t *testing.T) {
+		addAuthAccountKey(t, rt, runtimeInterface)
+		addAuthAccountKey(t, rt, runtimeInterface)
 
-	t.Parallel()
+		err := executeTransaction(
+			rt,
+			testAccountKeyActivatorRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				storage:              storage,
+			},
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      assert(signer.keys.activeKeyIndices().length == 2)
 
-	rt := newTestInterpreterRuntime()
+                      // Inactive keys are not included
+                      signer.keys.setActive(keyIndex: 0, active: false)
 
-	script := []byte(`
-        pub fun main(): [SignatureAlgorithm?] {
-            var key1: SignatureAlgorithm? = SignatureAlgorithm.ECDSA_secp256k1
+                      let indices = signer.keys.activeKeyIndices()
+                      assert(indices.length == 1)
+                      assert(indices[0] == 1)
 
-            var key2: SignatureAlgorithm? = SignatureAlgorithm(rawValue: 2)
+                      let publicIndices = getAccount(signer.address).keys.activeKeyIndices()
+                      assert(publicIndices.length == 1)
+                      assert(publicIndices[0] == 1)
 
-            var key3: SignatureAlgorithm? = SignatureAlgorithm(rawValue: 100)
-            return [key1, key2, key3]
-        }
-    `)
+                      signer.keys.setActive(keyIndex: 0, active: true)
+                      assert(signer.keys.activeKeyIndices().length == 2)
+                  }
+              }
+            `,
+		)
+		require.NoError(t, err)
+	})
 
-	storage := newTestLedger(nil, nil)
+	t.Run("revoked key", func(t *testing.T) {
 
-	runtimeInterface := &testRuntimeInterface{
-		storage: storage,
-	}
+		t.Parallel()
 
-	nextTransactionLocation := newTransactionLocationGenerator()
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	result, err := rt.ExecuteScript(
-		Script{
-			Source: script,
-		},
-		Context{
-			Interface: runtimeInterface,
-			Location:  nextTransactionLocation(),
-		},
-	)
-	require.NoError(t, err)
+		addAuthAccountKey(t, rt, runtimeInterface)
 
-	require.IsType(t, cadence.Array{}, result)
-	array := result.(cadence.Array)
+		err := executeTransaction(
+			rt,
+			testAccountKeyActivatorRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				storage:              storage,
+			},
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.keys.revoke(keyIndex: 0)
+                      signer.keys.setActive(keyIndex: 0, active: true)
+                  }
+              }
+            `,
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key with index 0 is revoked")
+	})
 
-	require.Len(t, array.Values, 3)
+	t.Run("non-existing key", func(t *testing.T) {
 
-	// Check key1
-	require.IsType(t, cadence.Optional{}, array.Values[0])
-	optionalValue := array.Values[0].(cadence.Optional)
+		t.Parallel()
 
-	require.IsType(t, cadence.Enum{}, optionalValue.Value)
-	builtinStruct := optionalValue.Value.(cadence.Enum)
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
 
-	require.Len(t, builtinStruct.Fields, 1)
-	assert.Equal(t,
-		cadence.NewUInt8(SignatureAlgorithmECDSA_secp256k1.RawValue()),
-		builtinStruct.Fields[0],
-	)
+		err := executeTransaction(
+			rt,
+			testAccountKeyActivatorRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				storage:              storage,
+			},
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.keys.setActive(keyIndex: 5, active: false)
+                  }
+              }
+            `,
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key with index 5 does not exist")
+	})
 
-	// Check key2
-	require.IsType(t, cadence.Optional{}, array.Values[1])
-	optionalValue = array.Values[1].(cadence.Optional)
+	t.Run("not supported", func(t *testing.T) {
 
-	require.IsType(t, cadence.Enum{}, optionalValue.Value)
-	builtinStruct = optionalValue.Value.(cadence.Enum)
+		t.Parallel()
 
-	require.Len(t, builtinStruct.Fields, 1)
-	assert.Equal(t,
-		cadence.NewUInt8(SignatureAlgorithmECDSA_secp256k1.RawValue()),
-		builtinStruct.Fields[0],
-	)
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	// Check key3
-	require.IsType(t, cadence.Optional{}, array.Values[2])
-	optionalValue = array.Values[2].(cadence.Optional)
+		addAuthAccountKey(t, rt, runtimeInterface)
 
-	require.Nil(t, optionalValue.Value)
+		err := executeTransaction(
+			rt,
+			runtimeInterface,
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.keys.setActive(keyIndex: 0, active: false)
+                  }
+              }
+            `,
+		)
+		require.Error(t, err)
+
+		var notImplementedErr NotImplementedError
+		require.ErrorAs(t, err, &notImplementedErr)
+	})
 }
 
-// Utility methods and types
+func TestRuntimeAuthAccountKeysRotate(t *testing.T) {
 
-var AccountKeyType = ExportedBuiltinType(sema.AccountKeyType).(*cadence.StructType)
-var PublicKeyType = ExportedBuiltinType(sema.PublicKeyType).(*cadence.StructType)
-var SignAlgoType = ExportedBuiltinType(sema.SignatureAlgorithmType).(*cadence.EnumType)
-var HashAlgoType = ExportedBuiltinType(sema.HashAlgorithmType).(*cadence.EnumType)
+	t.Parallel()
 
-func ExportedBuiltinType(internalType sema.Type) cadence.Type {
-	return ExportType(internalType, map[sema.TypeID]cadence.Type{})
-}
+	const code = `
+      transaction {
+          prepare(signer: AuthAccount) {
+              let newKey = PublicKey(
+                  publicKey: "040506".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+
+              let key = signer.keys.rotate(
+                  oldIndex: 0,
+                  newKey: newKey,
+                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                  weight: 1000.0
+              )
+              assert(key.keyIndex == 1)
+              assert(!key.isRevoked)
+          }
+      }
+    `
 
-func newBytesValue(bytes []byte) cadence.Array {
-	result := make([]cadence.Value, len(bytes))
-	for index, value := range bytes {
-		result[index] = cadence.NewUInt8(value)
+	executeTransaction := func(rt Runtime, runtimeInterface Interface) error {
+		return rt.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
 	}
-	return cadence.NewArray(result).
-		WithType(cadence.VariableSizedArrayType{
-			ElementType: cadence.UInt8Type{},
-		})
-}
 
-func newSignAlgoValue(signAlgo sema.SignatureAlgorithm) cadence.Enum {
-	return cadence.NewEnum([]cadence.Value{
-		cadence.NewUInt8(signAlgo.RawValue()),
-	}).WithType(SignAlgoType)
-}
+	t.Run("rotate", func(t *testing.T) {
 
-func accountKeyExportedValue(
-	index int,
-	publicKeyBytes []byte,
-	signAlgo sema.SignatureAlgorithm,
-	hashAlgo sema.HashAlgorithm,
-	weight string,
-	isRevoked bool,
-) cadence.Struct {
+		t.Parallel()
 
-	weightUFix64, err := cadence.NewUFix64(weight)
-	if err != nil {
-		panic(err)
-	}
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-	return cadence.Struct{
-		StructType: AccountKeyType,
-		Fields: []cadence.Value{
-			// Key index
-			cadence.NewInt(index),
+		addAuthAccountKey(t, rt, runtimeInterface)
+		storage.events = nil
 
-			// Public Key (struct)
-			cadence.Struct{
-				StructType: PublicKeyType,
-				Fields: []cadence.Value{
-					// Public key (bytes)
-					newBytesValue(publicKeyBytes),
+		err := executeTransaction(rt, runtimeInterface)
+		require.NoError(t, err)
 
-					// Signature Algo
-					newSignAlgoValue(signAlgo),
-				},
+		require.Len(t, storage.keys, 2)
+		assert.True(t, storage.keys[0].IsRevoked)
+		assert.False(t, storage.keys[1].IsRevoked)
+		assert.Equal(t, []byte{4, 5, 6}, storage.keys[1].PublicKey.PublicKey)
+
+		require.Len(t, storage.events, 1)
+		event := storage.events[0]
+		assert.EqualValues(t, stdlib.AccountKeyRotatedEventType.ID(), event.Type().ID())
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.NewAddress([8]byte{42}),
+				cadence.NewInt(0),
+				cadence.NewInt(1),
 			},
+			event.Fields,
+		)
+	})
 
-			// Hash algo
-			cadence.NewEnum([]cadence.Value{
-				cadence.NewUInt8(hashAlgo.RawValue()),
-			}).WithType(HashAlgoType),
+	t.Run("failing add", func(t *testing.T) {
 
-			// Weight
-			weightUFix64,
+		t.Parallel()
 
-			// IsRevoked
-			cadence.NewBool(isRevoked),
-		},
-	}
-}
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-func getAccountKeyTestRuntimeInterface(storage *testAccountKeyStorage) *testRuntimeInterface {
-	runtimeInterface := &testRuntimeInterface{
-		storage: newTestLedger(nil, nil),
-		getSigningAccounts: func() ([]Address, error) {
-			return []Address{{42}}, nil
-		},
-		createAccount: func(payer Address) (address Address, err error) {
-			return Address{42}, nil
-		},
-		addAccountKey: func(address Address, publicKey *stdlib.PublicKey, hashAlgo HashAlgorithm, weight int) (*stdlib.AccountKey, error) {
-			index := len(storage.keys)
-			accountKey := &stdlib.AccountKey{
-				KeyIndex:  index,
-				PublicKey: publicKey,
-				HashAlgo:  hashAlgo,
-				Weight:    weight,
-				IsRevoked: false,
-			}
+		addAuthAccountKey(t, rt, runtimeInterface)
+		storage.events = nil
 
-			storage.keys = append(storage.keys, accountKey)
-			storage.returnedKey = accountKey
-			return accountKey, nil
-		},
-		getAccountKey: func(address Address, index int) (*stdlib.AccountKey, error) {
-			if index >= len(storage.keys) {
-				storage.returnedKey = nil
-				return nil, nil
-			}
+		addErr := goerrors.New("key addition failed")
+		runtimeInterface.addAccountKey = func(_ Address, _ *stdlib.PublicKey, _ HashAlgorithm, _ int) (*stdlib.AccountKey, error) {
+			return nil, addErr
+		}
 
-			accountKey := storage.keys[index]
-			storage.returnedKey = accountKey
-			return accountKey, nil
-		},
-		removeAccountKey: func(address Address, index int) (*stdlib.AccountKey, error) {
-			if index >= len(storage.keys) {
-				storage.returnedKey = nil
-				return nil, nil
+		err := executeTransaction(rt, runtimeInterface)
+		require.ErrorContains(t, err, addErr.Error())
+
+		// The old key is still active
+
+		assert.Equal(t, []*stdlib.AccountKey{accountKeyA}, storage.keys)
+		assert.Empty(t, storage.events)
+	})
+
+	t.Run("failing revoke", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		addAuthAccountKey(t, rt, runtimeInterface)
+		storage.events = nil
+
+		revokeErr := goerrors.New("key revocation failed")
+		revokeAccountKey := runtimeInterface.removeAccountKey
+		runtimeInterface.removeAccountKey = func(address Address, index int) (*stdlib.AccountKey, error) {
+			if index == 0 {
+				return nil, revokeErr
 			}
+			return revokeAccountKey(address, index)
+		}
 
-			accountKey := storage.keys[index]
-			accountKey.IsRevoked = true
+		err := executeTransaction(rt, runtimeInterface)
+		require.ErrorContains(t, err, revokeErr.Error())
 
-			storage.keys[index] = accountKey
-			storage.returnedKey = accountKey
+		// The addition of the new key was rolled back, the old key is still active
 
-			return accountKey, nil
-		},
-		log: func(message string) {
-			storage.logs = append(storage.logs, message)
-		},
-		emitEvent: func(event cadence.Event) error {
-			storage.events = append(storage.events, event)
-			return nil
-		},
-		meterMemory: func(_ common.MemoryUsage) error {
-			return nil
-		},
-	}
-	runtimeInterface.decodeArgument = func(b []byte, t cadence.Type) (value cadence.Value, err error) {
-		return json.Decode(runtimeInterface, b)
-	}
-	return runtimeInterface
-}
+		require.Len(t, storage.keys, 2)
+		assert.False(t, storage.keys[0].IsRevoked)
+		assert.True(t, storage.keys[1].IsRevoked)
+		assert.Empty(t, storage.events)
+	})
 
-func addAuthAccountKey(t *testing.T, runtime Runtime, runtimeInterface *testRuntimeInterface) {
-	test := accountKeyTestCase{
-		name: "Add key",
-		code: `
-                transaction {
-                    prepare(signer: AuthAccount) {
-                        let key = PublicKey(
-                            publicKey: "010203".decodeHex(),
-                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                        )
+	t.Run("non-existing key", func(t *testing.T) {
 
-                        var addedKey: AccountKey = signer.keys.add(
-                            publicKey: key,
-                            hashAlgorithm: HashAlgorithm.SHA3_256,
-                            weight: 100.0
-                        )
-                    }
-                }`,
-		args: []cadence.Value{},
-	}
+		t.Parallel()
 
-	err := test.executeTransaction(runtime, runtimeInterface)
-	require.NoError(t, err)
-}
+		storage := newTestAccountKeyStorage()
+		rt := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
 
-func addPublicKeyValidation(runtimeInterface *testRuntimeInterface, returnError error) {
-	runtimeInterface.validatePublicKey = func(_ *stdlib.PublicKey) error {
-		return returnError
-	}
-}
+		err := executeTransaction(rt, runtimeInterface)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key with index 0 does not exist")
 
-func encodeArgs(argValues []cadence.Value) [][]byte {
-	args := make([][]byte, len(argValues))
-	for i, arg := range argValues {
-		var err error
-		args[i], err = json.Encode(arg)
-		if err != nil {
-			panic(fmt.Errorf("broken test: invalid argument: %w", err))
-		}
-	}
-	return args
+		assert.Empty(t, storage.keys)
+	})
 }
 
-type accountKeyTestCase struct {
-	name string
-	code string
-	args []cadence.Value
-}
+func TestRuntimeAuthAccountKeysAdd(t *testing.T) {
 
-func (test accountKeyTestCase) executeTransaction(
-	runtime Runtime,
-	runtimeInterface *testRuntimeInterface,
-) error {
-	args := encodeArgs(test.args)
+	t.Parallel()
 
-	err := runtime.ExecuteTransaction(
+	rt := newTestInterpreterRuntime()
+
+	pubKey := newBytesValue([]byte{1, 2, 3})
+
+	const code = `
+       transaction(publicKey: [UInt8]) {
+           prepare(signer: AuthAccount) {
+               let acct = AuthAccount(payer: signer)
+               acct.keys.add(
+                   publicKey: PublicKey(
+                       publicKey: publicKey,
+                       signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                   ),
+                   hashAlgorithm: HashAlgorithm.SHA3_256,
+                   weight: 100.0
+               )
+           }
+       }
+   `
+
+	storage := newTestAccountKeyStorage()
+	runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+	addPublicKeyValidation(runtimeInterface, nil)
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := rt.ExecuteTransaction(
 		Script{
-			Source:    []byte(test.code),
-			Arguments: args,
+			Source:    []byte(code),
+			Arguments: encodeArgs([]cadence.Value{pubKey}),
 		},
 		Context{
+			Location:  nextTransactionLocation(),
 			Interface: runtimeInterface,
-			Location:  common.TransactionLocation{},
 		},
 	)
-	return err
+
+	require.NoError(t, err)
+	assert.Len(t, storage.keys, 1)
+
+	require.Len(t, storage.events, 2)
+
+	assert.EqualValues(t,
+		stdlib.AccountCreatedEventType.ID(),
+		storage.events[0].Type().ID(),
+	)
+
+	assert.EqualValues(t,
+		stdlib.AccountKeyAddedEventType.ID(),
+		storage.events[1].Type().ID(),
+	)
 }
 
-func (test accountKeyTestCase) executeScript(
-	runtime Runtime,
-	runtimeInterface *testRuntimeInterface,
-) (cadence.Value, error) {
+func TestRuntimeAuthAccountAddEncodedKeyWithMetadata(t *testing.T) {
 
-	args := encodeArgs(test.args)
+	t.Parallel()
+
+	rt := newTestInterpreterRuntime()
+
+	// RLP-encoded account key, as accepted by addPublicKey:
+	// public key [1, 2, 3], signature algorithm ECDSA_P256 (2),
+	// hash algorithm SHA2_256 (1), and weight 1000
+	encodedKey := newBytesValue([]byte{
+		0xc9,
+		0x83, 0x1, 0x2, 0x3,
+		0x2,
+		0x1,
+		0x82, 0x3, 0xe8,
+	})
+
+	const code = `
+       transaction(encodedKey: [UInt8]) {
+           prepare(signer: AuthAccount) {
+               let key = signer.addEncodedKeyWithMetadata(
+                   encodedKey: encodedKey,
+                   hashAlgorithm: HashAlgorithm.SHA3_256,
+                   weight: 100.0
+               )
+               assert(key.keyIndex == 0)
+               assert(key.publicKey.publicKey.length == 3)
+               assert(key.publicKey.signatureAlgorithm.rawValue == SignatureAlgorithm.ECDSA_P256.rawValue)
+               assert(key.hashAlgorithm.rawValue == HashAlgorithm.SHA3_256.rawValue)
+               assert(key.weight == 100.0)
+               assert(!key.isRevoked)
+           }
+       }
+   `
+
+	storage := newTestAccountKeyStorage()
+	runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+	addPublicKeyValidation(runtimeInterface, nil)
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := rt.ExecuteTransaction(
+		Script{
+			Source:    []byte(code),
+			Arguments: encodeArgs([]cadence.Value{encodedKey}),
+		},
+		Context{
+			Location:  nextTransactionLocation(),
+			Interface: runtimeInterface,
+		},
+	)
+	require.NoError(t, err)
+
+	// The hash algorithm and weight of the encoded key are replaced by the given ones
+
+	assert.Equal(t, []*stdlib.AccountKey{accountKeyA}, storage.keys)
+
+	require.Len(t, storage.events, 1)
+	assert.EqualValues(t,
+		stdlib.AccountKeyAddedEventType.ID(),
+		storage.events[0].Type().ID(),
+	)
+}
+
+type testAccountKeyByPublicKeyRuntimeInterface struct {
+	*testRuntimeInterface
+	storage *testAccountKeyStorage
+}
+
+var _ AccountKeyByPublicKeyProvider = &testAccountKeyByPublicKeyRuntimeInterface{}
+
+func (i *testAccountKeyByPublicKeyRuntimeInterface) GetAccountKeyByPublicKey(
+	_ Address,
+	publicKey *stdlib.PublicKey,
+) (*stdlib.AccountKey, error) {
+	for _, key := range i.storage.keys {
+		if key.IsRevoked ||
+			key.PublicKey.SignAlgo != publicKey.SignAlgo ||
+			!bytes.Equal(key.PublicKey.PublicKey, publicKey.PublicKey) {
+
+			continue
+		}
+		return key, nil
+	}
+	return nil, nil
+}
+
+func TestRuntimeAuthAccountKeysAddDuplicate(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      transaction {
+          prepare(signer: AuthAccount) {
+              let key = PublicKey(
+                  publicKey: "010203".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+
+              signer.keys.add(
+                  publicKey: key,
+                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                  weight: 100.0
+              )
+          }
+      }
+    `
+
+	test := func(t *testing.T, rejectDuplicates bool) (*testAccountKeyStorage, error) {
+
+		rt := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled:     true,
+			RejectDuplicateAccountKeys: rejectDuplicates,
+		})
+
+		storage := newTestAccountKeyStorage()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		executeTransaction := func() error {
+			return rt.ExecuteTransaction(
+				Script{
+					Source: []byte(code),
+				},
+				Context{
+					Location: nextTransactionLocation(),
+					Interface: &testAccountKeyByPublicKeyRuntimeInterface{
+						testRuntimeInterface: runtimeInterface,
+						storage:              storage,
+					},
+				},
+			)
+		}
+
+		err := executeTransaction()
+		require.NoError(t, err)
+
+		return storage, executeTransaction()
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage, err := test(t, true)
+		require.Error(t, err)
+
+		var duplicateErr *stdlib.DuplicateAccountKeyError
+		require.ErrorAs(t, err, &duplicateErr)
+		assert.Equal(t, 0, duplicateErr.KeyIndex)
+
+		assert.Len(t, storage.keys, 1)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage, err := test(t, false)
+		require.NoError(t, err)
+
+		assert.Len(t, storage.keys, 2)
+	})
+}
+
+func TestRuntimePublicAccountKeys(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("get key", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+
+		test := accountKeyTestCase{
+			code: `
+              pub fun main(): AccountKey? {
+                  let acc = getAccount(0x02)
+                  return acc.keys.get(keyIndex: 0)
+              }
+            `,
+			args: []cadence.Value{},
+		}
+
+		value, err := test.executeScript(runtime, runtimeInterface)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		require.IsType(t, cadence.Optional{}, value)
+		optionalValue := value.(cadence.Optional)
+
+		expectedValue := accountKeyExportedValue(
+			0,
+			[]byte{1, 2, 3},
+			sema.SignatureAlgorithmECDSA_P256,
+			sema.HashAlgorithmSHA3_256,
+			"100.0",
+			false,
+			true,
+		)
+
+		assert.Equal(t, expectedValue, optionalValue.Value)
+		assert.Equal(t, accountKeyA, storage.returnedKey)
+
+	})
+
+	t.Run("get another key", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+
+		test := accountKeyTestCase{
+			code: `
+              pub fun main(): AccountKey? {
+                  let acc = getAccount(0x02)
+                  return acc.keys.get(keyIndex: 1)
+              }
+            `,
+			args: []cadence.Value{},
+		}
+
+		value, err := test.executeScript(runtime, runtimeInterface)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		require.IsType(t, cadence.Optional{}, value)
+		optionalValue := value.(cadence.Optional)
+
+		expectedValue := accountKeyExportedValue(
+			1,
+			[]byte{4, 5, 6},
+			sema.SignatureAlgorithmECDSA_secp256k1,
+			sema.HashAlgorithmSHA3_256,
+			"100.0",
+			false,
+			true,
+		)
+
+		assert.Equal(t, expectedValue, optionalValue.Value)
+		assert.Equal(t, accountKeyB, storage.returnedKey)
+	})
+
+	t.Run("get non-existing key", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+
+		test := accountKeyTestCase{
+			code: `
+                pub fun main(): AccountKey? {
+                    let acc = getAccount(0x02)
+                    return acc.keys.get(keyIndex: 4)
+                }
+            `,
+			args: []cadence.Value{},
+		}
+
+		value, err := test.executeScript(runtime, runtimeInterface)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		require.IsType(t, cadence.Optional{}, value)
+		optionalValue := value.(cadence.Optional)
+
+		assert.Nil(t, optionalValue.Value)
+	})
+
+	t.Run("get revoked key", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, revokedAccountKeyA, accountKeyB)
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+
+		test := accountKeyTestCase{
+			code: `
+              pub fun main(): AccountKey? {
+                  let acc = getAccount(0x02)
+                  var keys: PublicAccount.Keys = acc.keys
+                  return keys.get(keyIndex: 0)
+              }
+            `,
+			args: []cadence.Value{},
+		}
+
+		value, err := test.executeScript(runtime, runtimeInterface)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		require.IsType(t, cadence.Optional{}, value)
+		optionalValue := value.(cadence.Optional)
+
+		expectedValue := accountKeyExportedValue(
+			0,
+			[]byte{1, 2, 3},
+			sema.SignatureAlgorithmECDSA_P256,
+			sema.HashAlgorithmSHA3_256,
+			"100.0",
+			true,
+			true,
+		)
+
+		assert.Equal(t, expectedValue, optionalValue.Value)
+		assert.Equal(t, revokedAccountKeyA, storage.returnedKey)
+	})
+}
+
+func TestRuntimeVerifyAccountKeyState(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+      pub fun main(): [Bool] {
+          let keys = getAccount(0x02).keys
+          let keyA = keys.get(keyIndex: 0)!
+          let keyB = keys.get(keyIndex: 1)!
+
+          let matching = verifyAccountKeyState(address: 0x02, expectedKeys: [keyA, keyB])
+          let reordered = verifyAccountKeyState(address: 0x02, expectedKeys: [keyB, keyA])
+          let missing = verifyAccountKeyState(address: 0x02, expectedKeys: [keyA])
+          let duplicated = verifyAccountKeyState(address: 0x02, expectedKeys: [keyA, keyA])
+          let none = verifyAccountKeyState(address: 0x02, expectedKeys: [])
+
+          getAuthAccount(0x02).keys.revoke(keyIndex: 1)
+
+          let revoked = verifyAccountKeyState(address: 0x02, expectedKeys: [keyA, keyB])
+
+          return [matching, reordered, missing, duplicated, none, revoked]
+      }
+    `
+
+	// Copy the keys, as revoking a key mutates it in the storage
+	keyA := *accountKeyA
+	keyB := *accountKeyB
+
+	storage := newTestAccountKeyStorage()
+	storage.keys = append(storage.keys, &keyA, &keyB)
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+
+	value, err := accountKeyTestCase{code: script}.executeScript(runtime, runtimeInterface)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewBool(true),
+			cadence.NewBool(true),
+			cadence.NewBool(false),
+			cadence.NewBool(false),
+			cadence.NewBool(false),
+			cadence.NewBool(false),
+		}).WithType(cadence.NewVariableSizedArrayType(cadence.NewBoolType())),
+		value,
+	)
+}
+
+type testEventMetricsObserverRuntimeInterface struct {
+	*testRuntimeInterface
+	onEventEmitted func(eventTypeID common.TypeID, payloadSize int)
+}
+
+var _ EventMetricsObserver = &testEventMetricsObserverRuntimeInterface{}
+
+func (i *testEventMetricsObserverRuntimeInterface) OnEventEmitted(eventTypeID common.TypeID, payloadSize int) {
+	i.onEventEmitted(eventTypeID, payloadSize)
+}
+
+func TestRuntimeEventMetricsObserver(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      transaction {
+          prepare(signer: AuthAccount) {
+              let key = PublicKey(
+                  publicKey: "010203".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+
+              signer.keys.add(
+                  publicKey: key,
+                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                  weight: 100.0
+              )
+
+              signer.keys.revoke(keyIndex: 0)
+          }
+      }
+    `
+
+	type eventMetrics struct {
+		eventTypeID common.TypeID
+		payloadSize int
+	}
+
+	var observed []eventMetrics
+
+	storage := newTestAccountKeyStorage()
+	rt := newTestInterpreterRuntime()
+
+	runtimeInterface := &testEventMetricsObserverRuntimeInterface{
+		testRuntimeInterface: getAccountKeyTestRuntimeInterface(storage),
+		onEventEmitted: func(eventTypeID common.TypeID, payloadSize int) {
+			observed = append(observed, eventMetrics{
+				eventTypeID: eventTypeID,
+				payloadSize: payloadSize,
+			})
+		},
+	}
+	addPublicKeyValidation(runtimeInterface.testRuntimeInterface, nil)
+
+	err := rt.ExecuteTransaction(
+		Script{
+			Source: []byte(code),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	// The observer is called once per emitted event,
+	// with the type and the payload size of the event
+
+	require.Len(t, storage.events, 2)
+
+	expected := make([]eventMetrics, 0, len(storage.events))
+	for _, event := range storage.events {
+		expected = append(expected, eventMetrics{
+			eventTypeID: common.TypeID(event.EventType.ID()),
+			payloadSize: valuesPayloadSize(event.Fields),
+		})
+	}
+
+	assert.Equal(t, stdlib.AccountKeyAddedEventType.ID(), expected[0].eventTypeID)
+	assert.Equal(t, stdlib.AccountKeyRemovedEventType.ID(), expected[1].eventTypeID)
+
+	assert.Equal(t, expected, observed)
+}
+
+func TestRuntimeHashAlgorithm(t *testing.T) {
+
+	t.Parallel()
+
+	rt := newTestInterpreterRuntime()
+
+	script := []byte(`
+        pub fun main(): [HashAlgorithm?] {
+            var key1: HashAlgorithm? = HashAlgorithm.SHA3_256
+
+            var key2: HashAlgorithm? = HashAlgorithm(rawValue: 3)
+
+            var key3: HashAlgorithm? = HashAlgorithm(rawValue: 100)
+            return [key1, key2, key3]
+          }
+    `)
+
+	storage := newTestLedger(nil, nil)
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: storage,
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	result, err := rt.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.IsType(t, cadence.Array{}, result)
+	array := result.(cadence.Array)
+
+	require.Len(t, array.Values, 3)
+
+	// Check key1
+	require.IsType(t, cadence.Optional{}, array.Values[0])
+	optionalValue := array.Values[0].(cadence.Optional)
+
+	require.IsType(t, cadence.Enum{}, optionalValue.Value)
+	builtinStruct := optionalValue.Value.(cadence.Enum)
+
+	require.Len(t, builtinStruct.Fields, 1)
+	assert.Equal(t,
+		cadence.NewUInt8(HashAlgorithmSHA3_256.RawValue()),
+		builtinStruct.Fields[0],
+	)
+
+	// Check key2
+	require.IsType(t, cadence.Optional{}, array.Values[1])
+	optionalValue = array.Values[1].(cadence.Optional)
+
+	require.IsType(t, cadence.Enum{}, optionalValue.Value)
+	builtinStruct = optionalValue.Value.(cadence.Enum)
+
+	require.Len(t, builtinStruct.Fields, 1)
+	assert.Equal(t,
+		cadence.NewUInt8(HashAlgorithmSHA3_256.RawValue()),
+		builtinStruct.Fields[0],
+	)
+
+	// Check key3
+	require.IsType(t, cadence.Optional{}, array.Values[2])
+	optionalValue = array.Values[2].(cadence.Optional)
+
+	require.Nil(t, optionalValue.Value)
+}
+
+func TestRuntimeSignatureAlgorithm(t *testing.T) {
+
+	t.Parallel()
+
+	rt := newTestInterpreterRuntime()
+
+	script := []byte(`
+        pub fun main(): [SignatureAlgorithm?] {
+            var key1: SignatureAlgorithm? = SignatureAlgorithm.ECDSA_secp256k1
+
+            var key2: SignatureAlgorithm? = SignatureAlgorithm(rawValue: 2)
+
+            var key3: SignatureAlgorithm? = SignatureAlgorithm(rawValue: 100)
+            return [key1, key2, key3]
+        }
+    `)
+
+	storage := newTestLedger(nil, nil)
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: storage,
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	result, err := rt.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.IsType(t, cadence.Array{}, result)
+	array := result.(cadence.Array)
+
+	require.Len(t, array.Values, 3)
+
+	// Check key1
+	require.IsType(t, cadence.Optional{}, array.Values[0])
+	optionalValue := array.Values[0].(cadence.Optional)
+
+	require.IsType(t, cadence.Enum{}, optionalValue.Value)
+	builtinStruct := optionalValue.Value.(cadence.Enum)
+
+	require.Len(t, builtinStruct.Fields, 1)
+	assert.Equal(t,
+		cadence.NewUInt8(SignatureAlgorithmECDSA_secp256k1.RawValue()),
+		builtinStruct.Fields[0],
+	)
+
+	// Check key2
+	require.IsType(t, cadence.Optional{}, array.Values[1])
+	optionalValue = array.Values[1].(cadence.Optional)
+
+	require.IsType(t, cadence.Enum{}, optionalValue.Value)
+	builtinStruct = optionalValue.Value.(cadence.Enum)
+
+	require.Len(t, builtinStruct.Fields, 1)
+	assert.Equal(t,
+		cadence.NewUInt8(SignatureAlgorithmECDSA_secp256k1.RawValue()),
+		builtinStruct.Fields[0],
+	)
+
+	// Check key3
+	require.IsType(t, cadence.Optional{}, array.Values[2])
+	optionalValue = array.Values[2].(cadence.Optional)
+
+	require.Nil(t, optionalValue.Value)
+}
+
+// Utility methods and types
+
+var AccountKeyType = ExportedBuiltinType(sema.AccountKeyType).(*cadence.StructType)
+var PublicKeyType = ExportedBuiltinType(sema.PublicKeyType).(*cadence.StructType)
+var SignAlgoType = ExportedBuiltinType(sema.SignatureAlgorithmType).(*cadence.EnumType)
+var HashAlgoType = ExportedBuiltinType(sema.HashAlgorithmType).(*cadence.EnumType)
+
+func ExportedBuiltinType(internalType sema.Type) cadence.Type {
+	return ExportType(internalType, map[sema.TypeID]cadence.Type{})
+}
+
+func newBytesValue(bytes []byte) cadence.Array {
+	result := make([]cadence.Value, len(bytes))
+	for index, value := range bytes {
+		result[index] = cadence.NewUInt8(value)
+	}
+	return cadence.NewArray(result).
+		WithType(cadence.VariableSizedArrayType{
+			ElementType: cadence.UInt8Type{},
+		})
+}
+
+func newSignAlgoValue(signAlgo sema.SignatureAlgorithm) cadence.Enum {
+	return cadence.NewEnum([]cadence.Value{
+		cadence.NewUInt8(signAlgo.RawValue()),
+	}).WithType(SignAlgoType)
+}
+
+func accountKeyExportedValue(
+	index int,
+	publicKeyBytes []byte,
+	signAlgo sema.SignatureAlgorithm,
+	hashAlgo sema.HashAlgorithm,
+	weight string,
+	isRevoked bool,
+	isActive bool,
+) cadence.Struct {
+
+	weightUFix64, err := cadence.NewUFix64(weight)
+	if err != nil {
+		panic(err)
+	}
+
+	return cadence.Struct{
+		StructType: AccountKeyType,
+		Fields: []cadence.Value{
+			// Key index
+			cadence.NewInt(index),
+
+			// Public Key (struct)
+			cadence.Struct{
+				StructType: PublicKeyType,
+				Fields: []cadence.Value{
+					// Public key (bytes)
+					newBytesValue(publicKeyBytes),
+
+					// Signature Algo
+					newSignAlgoValue(signAlgo),
+				},
+			},
+
+			// Hash algo
+			cadence.NewEnum([]cadence.Value{
+				cadence.NewUInt8(hashAlgo.RawValue()),
+			}).WithType(HashAlgoType),
+
+			// Weight
+			weightUFix64,
+
+			// IsRevoked
+			cadence.NewBool(isRevoked),
+
+			// IsActive
+			cadence.NewBool(isActive),
+		},
+	}
+}
+
+func getAccountKeyTestRuntimeInterface(storage *testAccountKeyStorage) *testRuntimeInterface {
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{42}}, nil
+		},
+		createAccount: func(payer Address) (address Address, err error) {
+			return Address{42}, nil
+		},
+		addAccountKey: func(address Address, publicKey *stdlib.PublicKey, hashAlgo HashAlgorithm, weight int) (*stdlib.AccountKey, error) {
+			index := len(storage.keys)
+			accountKey := &stdlib.AccountKey{
+				KeyIndex:  index,
+				PublicKey: publicKey,
+				HashAlgo:  hashAlgo,
+				Weight:    weight,
+				IsRevoked: false,
+			}
+
+			storage.keys = append(storage.keys, accountKey)
+			storage.returnedKey = accountKey
+			return accountKey, nil
+		},
+		getAccountKey: func(address Address, index int) (*stdlib.AccountKey, error) {
+			if index >= len(storage.keys) {
+				storage.returnedKey = nil
+				return nil, nil
+			}
+
+			accountKey := storage.keys[index]
+			storage.returnedKey = accountKey
+			return accountKey, nil
+		},
+		removeAccountKey: func(address Address, index int) (*stdlib.AccountKey, error) {
+			if index >= len(storage.keys) {
+				storage.returnedKey = nil
+				return nil, nil
+			}
+
+			accountKey := storage.keys[index]
+			accountKey.IsRevoked = true
+
+			storage.keys[index] = accountKey
+			storage.returnedKey = accountKey
+
+			return accountKey, nil
+		},
+		log: func(message string) {
+			storage.logs = append(storage.logs, message)
+		},
+		emitEvent: func(event cadence.Event) error {
+			storage.events = append(storage.events, event)
+			return nil
+		},
+		meterMemory: func(_ common.MemoryUsage) error {
+			return nil
+		},
+	}
+	runtimeInterface.decodeArgument = func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+		return json.Decode(runtimeInterface, b)
+	}
+	return runtimeInterface
+}
+
+func addAuthAccountKey(t *testing.T, runtime Runtime, runtimeInterface *testRuntimeInterface) {
+	test := accountKeyTestCase{
+		name: "Add key",
+		code: `
+                transaction {
+                    prepare(signer: AuthAccount) {
+                        let key = PublicKey(
+                            publicKey: "010203".decodeHex(),
+                            signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                        )
+
+                        var addedKey: AccountKey = signer.keys.add(
+                            publicKey: key,
+                            hashAlgorithm: HashAlgorithm.SHA3_256,
+                            weight: 100.0
+                        )
+                    }
+                }`,
+		args: []cadence.Value{},
+	}
+
+	err := test.executeTransaction(runtime, runtimeInterface)
+	require.NoError(t, err)
+}
+
+func addPublicKeyValidation(runtimeInterface *testRuntimeInterface, returnError error) {
+	runtimeInterface.validatePublicKey = func(_ *stdlib.PublicKey) error {
+		return returnError
+	}
+}
+
+func encodeArgs(argValues []cadence.Value) [][]byte {
+	args := make([][]byte, len(argValues))
+	for i, arg := range argValues {
+		var err error
+		args[i], err = json.Encode(arg)
+		if err != nil {
+			panic(fmt.Errorf("broken test: invalid argument: %w", err))
+		}
+	}
+	return args
+}
+
+type accountKeyTestCase struct {
+	name string
+	code string
+	args []cadence.Value
+}
+
+func (test accountKeyTestCase) executeTransaction(
+	runtime Runtime,
+	runtimeInterface *testRuntimeInterface,
+) error {
+	args := encodeArgs(test.args)
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source:    []byte(test.code),
+			Arguments: args,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	return err
+}
+
+func (test accountKeyTestCase) executeScript(
+	runtime Runtime,
+	runtimeInterface *testRuntimeInterface,
+) (cadence.Value, error) {
+
+	args := encodeArgs(test.args)
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source:    []byte(test.code),
+			Arguments: args,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	return value, err
+}
+
+func newTestAccountKeyStorage() *testAccountKeyStorage {
+	return &testAccountKeyStorage{
+		events: make([]cadence.Event, 0),
+		keys:   make([]*stdlib.AccountKey, 0),
+	}
+}
+
+type testAccountKeyStorage struct {
+	events      []cadence.Event
+	keys        []*stdlib.AccountKey
+	returnedKey *stdlib.AccountKey
+	logs        []string
+}
+
+func TestRuntimePublicKey(t *testing.T) {
+
+	t.Parallel()
+
+	rt := newTestInterpreterRuntime()
+
+	executeScript := func(code string, runtimeInterface Interface) (cadence.Value, error) {
+		return rt.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	t.Run("Constructor", func(t *testing.T) {
+		script := `
+            pub fun main(): PublicKey {
+                let publicKey = PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                return publicKey
+            }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		value, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		expected := cadence.Struct{
+			StructType: PublicKeyType,
+			Fields: []cadence.Value{
+				// Public key (bytes)
+				newBytesValue([]byte{1, 2}),
+
+				// Signature Algo
+				newSignAlgoValue(sema.SignatureAlgorithmECDSA_P256),
+			},
+		}
+
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("Validate func", func(t *testing.T) {
+		script := `
+            pub fun main(): Bool {
+                let publicKey =  PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                return publicKey.validate()
+            }
+        `
+
+		runtimeInterface := &testRuntimeInterface{}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "value of type `PublicKey` has no member `validate`")
+	})
+
+	t.Run("Construct PublicKey in Cadence code", func(t *testing.T) {
+		script := `
+              pub fun main(): PublicKey {
+                  let publicKey = PublicKey(
+                      publicKey: "0102".decodeHex(),
+                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                  )
+
+                  return publicKey
+              }
+            `
+
+		fakeError := &fakeError{}
+		for _, errorToReturn := range []error{fakeError, nil} {
+			invoked := false
+
+			storage := newTestLedger(nil, nil)
+
+			runtimeInterface := &testRuntimeInterface{
+				storage: storage,
+				validatePublicKey: func(publicKey *stdlib.PublicKey) error {
+					invoked = true
+					return errorToReturn
+				},
+			}
+
+			value, err := executeScript(script, runtimeInterface)
+
+			assert.True(t, invoked, "validatePublicKey was not invoked")
+
+			if errorToReturn == nil {
+				assert.NotNil(t, value)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, value)
+				assert.ErrorAs(t, err, &errorToReturn)
+				assert.ErrorAs(t, err, &interpreter.InvalidPublicKeyError{})
+			}
+		}
+	})
+
+	t.Run("PublicKey validation error", func(t *testing.T) {
+		script := `
+              pub fun main(): PublicKey {
+                  return PublicKey(
+                      publicKey: "0102".decodeHex(),
+                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                  )
+              }
+            `
+
+		invalidPointError := goerrors.New("point is not on curve")
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			validatePublicKey: func(publicKey *stdlib.PublicKey) error {
+				return invalidPointError
+			},
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.Error(t, err)
+
+		var validationErr *stdlib.PublicKeyValidationError
+		require.ErrorAs(t, err, &validationErr)
+
+		assert.Equal(t, sema.SignatureAlgorithmECDSA_P256, validationErr.SignatureAlgorithm)
+		assert.Equal(t, interpreter.PublicKeyFingerprint([]byte{0x1, 0x2}), validationErr.Fingerprint)
+		assert.ErrorIs(t, err, invalidPointError)
+		assert.NotContains(t, validationErr.Error(), "[1, 2]")
+
+		var invalidPublicKeyErr interpreter.InvalidPublicKeyError
+		require.ErrorAs(t, err, &invalidPublicKeyErr)
+		assert.NotContains(t, invalidPublicKeyErr.Error(), "[1, 2]")
+
+		// The full error must not include the key either
+		assert.NotContains(t, err.Error(), "[1, 2]")
+		assert.Contains(t, err.Error(), validationErr.Fingerprint)
+	})
+
+	t.Run("PublicKey validation panic", func(t *testing.T) {
+		script := `
+              pub fun main(): PublicKey {
+                  return PublicKey(
+                      publicKey: "0102".decodeHex(),
+                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                  )
+              }
+            `
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			validatePublicKey: func(publicKey *stdlib.PublicKey) error {
+				panic("internal failure")
+			},
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.Error(t, err)
+
+		var validationErr *stdlib.PublicKeyValidationError
+		assert.False(t, goerrors.As(err, &validationErr))
+	})
+
+	t.Run("PublicKey from host env", func(t *testing.T) {
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+
+		for index := range storage.keys {
+			script := fmt.Sprintf(`
+                          pub fun main(): PublicKey {
+                              // Get a public key from host env
+                              let acc = getAccount(0x02)
+                              let publicKey = acc.keys.get(keyIndex: %d)!.publicKey
+                              return publicKey
+                          }`,
+				index,
+			)
+
+			invoked := false
+
+			runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+			runtimeInterface.validatePublicKey = func(publicKey *stdlib.PublicKey) error {
+				invoked = true
+				return nil
+			}
+
+			value, err := executeScript(script, runtimeInterface)
+
+			// skip validation when key comes from host env aka FVM
+			assert.False(t, invoked, "validatePublicKey was not invoked")
+
+			assert.IsType(t, cadence.Struct{}, value)
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		script := `
+            pub fun main(): Bool {
+                let publicKey =  PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                return publicKey.verify(
+                    signature: [],
+                    signedData: [],
+                    domainSeparationTag: "something",
+                    hashAlgorithm: HashAlgorithm.SHA2_256
+                )
+            }
+        `
+		invoked := false
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+			verifySignature: func(
+				_ []byte,
+				_ string,
+				_ []byte,
+				_ []byte,
+				_ SignatureAlgorithm,
+				_ HashAlgorithm,
+			) (bool, error) {
+				invoked = true
+				return true, nil
+			},
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		value, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.True(t, invoked)
+		assert.Equal(t, cadence.Bool(true), value)
+	})
+
+	t.Run("Verify - publicKey from host env", func(t *testing.T) {
+
+		storage := newTestAccountKeyStorage()
+		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+
+		script := `
+            pub fun main(): Bool {
+                // Get a public key from host env
+                let acc = getAccount(0x02)
+                let publicKey = acc.keys.get(keyIndex: 0)!.publicKey
+
+                return publicKey.verify(
+                    signature: [],
+                    signedData: [],
+                    domainSeparationTag: "something",
+                    hashAlgorithm: HashAlgorithm.SHA2_256
+                )
+            }
+        `
+		invoked := false
+
+		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
+		runtimeInterface.verifySignature = func(
+			_ []byte,
+			_ string,
+			_ []byte,
+			_ []byte,
+			_ SignatureAlgorithm,
+			_ HashAlgorithm,
+		) (bool, error) {
+			invoked = true
+			return true, nil
+		}
+
+		value, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.True(t, invoked)
+		assert.Equal(t, cadence.Bool(true), value)
+	})
+
+	t.Run("field mutability", func(t *testing.T) {
+		script := `
+            pub fun main(): PublicKey {
+                let publicKey =  PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                publicKey.publicKey = []
+                publicKey.signatureAlgorithm = SignatureAlgorithm.ECDSA_secp256k1
+
+                return publicKey
+            }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checkerErr.Errors
+		require.Len(t, errs, 4)
+
+		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
+		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[1])
+		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[2])
+		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[3])
+	})
+
+	t.Run("raw-key mutability", func(t *testing.T) {
+		script := `
+            pub fun main(): PublicKey {
+                let publicKey =  PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                publicKey.publicKey[0] = 5
+
+                return publicKey
+            }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		_, err := executeScript(script, runtimeInterface)
+
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+		errs := checkerErr.Errors
+		require.Len(t, errs, 1)
+
+		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
+	})
+
+	t.Run("raw-key reference mutability", func(t *testing.T) {
+		script := `
+        pub fun main(): PublicKey {
+            let publicKey =  PublicKey(
+                publicKey: "0102".decodeHex(),
+                signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+            )
+          
+            var publickeyRef = &publicKey.publicKey as &[UInt8]
+            publickeyRef[0] = 3
+
+            return publicKey
+          }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		value, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		expected := cadence.Struct{
+			StructType: PublicKeyType,
+			Fields: []cadence.Value{
+				// Public key (bytes)
+				newBytesValue([]byte{1, 2}),
+				// Signature Algo
+				newSignAlgoValue(sema.SignatureAlgorithmECDSA_P256),
+			},
+		}
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("fingerprint", func(t *testing.T) {
+		script := `
+            pub fun main(): [String] {
+                let publicKey1 = PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+                let publicKey2 = PublicKey(
+                    publicKey: "0102".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+                let publicKey3 = PublicKey(
+                    publicKey: "0103".decodeHex(),
+                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                )
+
+                return [
+                    publicKey1.fingerprint,
+                    publicKey1.fingerprint,
+                    publicKey2.fingerprint,
+                    publicKey3.fingerprint
+                ]
+            }
+        `
+
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		value, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		fingerprint := func(key []byte) cadence.String {
+			hash := sha256.Sum256(key)
+			return cadence.String(hex.EncodeToString(hash[:8]))
+		}
+
+		require.IsType(t, cadence.Array{}, value)
+		fingerprints := value.(cadence.Array).Values
+
+		assert.Equal(t,
+			[]cadence.Value{
+				fingerprint([]byte{1, 2}),
+				fingerprint([]byte{1, 2}),
+				fingerprint([]byte{1, 2}),
+				fingerprint([]byte{1, 3}),
+			},
+			fingerprints,
+		)
+		assert.NotEqual(t, fingerprints[0], fingerprints[3])
+	})
+}
+
+func TestAuthAccountContracts(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("get names", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            transaction {
+                prepare(signer: AuthAccount) {
+                    let names = signer.contracts.names
+
+                    assert(names.isInstance(Type<[String]>()))
+                    assert(names.length == 2)
+                }
+            }
+        `)
+
+		invoked := false
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				invoked = true
+				return []string{"foo", "bar"}, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, invoked)
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            pub fun main(): [ContractInfo] {
+                let acc = getAccount(0x02)
+                return acc.contracts.manifest()
+            }
+        `)
+
+		codes := map[string][]byte{
+			"Foo": []byte("pub contract Foo {}"),
+			"Bar": []byte("pub contract Bar { pub let x: Int; init() { self.x = 1 } }"),
+		}
+
+		runtimeInterface := &testRuntimeInterface{
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				return []string{"Foo", "Bar"}, nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				return codes[name], nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Array{}, result)
+		array := result.(cadence.Array)
+
+		require.Len(t, array.Values, 2)
+
+		// The contracts are sorted by name
+
+		for i, name := range []string{"Bar", "Foo"} {
+			require.IsType(t, cadence.Struct{}, array.Values[i])
+			fields := array.Values[i].(cadence.Struct).Fields
+
+			code := codes[name]
+			hash := sha3.Sum256(code)
+
+			expectedHash := make([]cadence.Value, len(hash))
+			for i, b := range hash {
+				expectedHash[i] = cadence.UInt8(b)
+			}
+
+			require.Len(t, fields, 3)
+			assert.Equal(t, cadence.String(name), fields[0])
+			assert.Equal(t, expectedHash, fields[1].(cadence.Array).Values)
+			assert.Equal(t, cadence.NewInt(len(code)), fields[2])
+		}
+	})
+
+	t.Run("update names", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            transaction {
+                prepare(signer: AuthAccount) {
+                    signer.contracts.names[0] = "baz"
+                }
+            }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				return []string{"foo", "bar"}, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+		errs := checkerErr.Errors
+		require.Len(t, errs, 1)
+
+		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
+	})
+
+	t.Run("update names through reference", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            transaction {
+                prepare(signer: AuthAccount) {
+                    var namesRef = &signer.contracts.names as &[String]
+                    namesRef[0] = "baz"
+
+                    assert(signer.contracts.names[0] == "bar")
+                }
+            }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				return []string{"foo", "bar"}, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthAccountCapabilities(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("issue, publish, get", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		type issuedController struct {
+			address    Address
+			targetPath cadence.Path
+		}
+
+		var issuedControllers []issuedController
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			issueStorageCapabilityController: func(address Address, targetPath cadence.Path) (uint64, error) {
+				issuedControllers = append(
+					issuedControllers,
+					issuedController{
+						address:    address,
+						targetPath: targetPath,
+					},
+				)
+				return uint64(len(issuedControllers)), nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
+
+                          let cap = signer.capabilities.issue<&String>(target: /storage/greeting)
+                          signer.capabilities.publish(cap, at: /public/greeting)
+
+                          let publishedCap = signer.capabilities.get<&String>(/public/greeting)!
+                          assert(publishedCap.borrow()!.length == 5)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t,
+			[]issuedController{
+				{
+					address:    address,
+					targetPath: cadence.NewPath("storage", "greeting"),
+				},
+			},
+			issuedControllers,
+		)
+
+		// The published capability is persisted
+
+		err = rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let publishedCap = signer.capabilities.get<&String>(/public/greeting)!
+                          assert(publishedCap.borrow()!.length == 5)
+
+                          assert(signer.capabilities.get<&Int>(/public/greeting) == nil)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("unpublished path", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
+                          signer.link<&String>(/public/greeting, target: /storage/greeting)
+
+                          assert(signer.capabilities.get<&String>(/public/greeting) == nil)
+                          assert(signer.capabilities.get<&String>(/public/nothing) == nil)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("get controllers", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		issuedControllers := map[string][]uint64{}
+		var nextCapabilityID uint64
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			issueStorageCapabilityController: func(_ Address, targetPath cadence.Path) (uint64, error) {
+				nextCapabilityID++
+				key := targetPath.String()
+				issuedControllers[key] = append(issuedControllers[key], nextCapabilityID)
+				return nextCapabilityID, nil
+			},
+			getStorageCapabilityControllerIDs: func(_ Address, targetPath cadence.Path) ([]uint64, error) {
+				return issuedControllers[targetPath.String()], nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
+                          signer.save(42, to: /storage/answer)
+
+                          signer.capabilities.issue<&String>(target: /storage/greeting)
+                          signer.capabilities.issue<&Int>(target: /storage/answer)
+                          signer.capabilities.issue<&AnyStruct>(target: /storage/greeting)
+
+                          let controllers = signer.capabilities.getControllers(forPath: /storage/greeting)
+                          assert(controllers.length == 2)
+
+                          assert(controllers[0].capabilityID == 1)
+                          assert(controllers[0].target == /storage/greeting)
+
+                          assert(controllers[1].capabilityID == 3)
+                          assert(controllers[1].target == /storage/greeting)
+
+                          assert(signer.capabilities.getControllers(forPath: /storage/nothing).length == 0)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("get controllers in sorted order", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			getStorageCapabilityControllerIDs: func(_ Address, _ cadence.Path) ([]uint64, error) {
+				return []uint64{3, 1, 2}, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let controllers = signer.capabilities.getControllers(forPath: /storage/greeting)
+                          assert(controllers.length == 3)
+
+                          assert(controllers[0].capabilityID == 1)
+                          assert(controllers[1].capabilityID == 2)
+                          assert(controllers[2].capabilityID == 3)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+	t.Run("check", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		var nextCapabilityID uint64
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			issueStorageCapabilityController: func(_ Address, _ cadence.Path) (uint64, error) {
+				nextCapabilityID++
+				return nextCapabilityID, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
+
+                          let cap = signer.capabilities.issue<&String>(target: /storage/greeting)
+                          let intCap = signer.capabilities.issue<&Int>(target: /storage/greeting)
+
+                          // Valid capability
+                          assert(cap.check())
+
+                          // Type mismatch
+                          assert(!intCap.check())
+
+                          // Deleted target
+                          signer.load<String>(from: /storage/greeting)
+                          assert(!cap.check())
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("issue, not supported", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		// The host environment does not implement StorageCapabilityControllerIssuer
+		runtimeInterface := &testLogOnlyRuntimeInterface{}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      let cap = getAuthAccount(0x1).capabilities.issue<&String>(target: /storage/greeting)
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+
+		var notImplementedErr NotImplementedError
+		require.ErrorAs(t, err, &notImplementedErr)
+		assert.Equal(t, "IssueStorageCapabilityController", notImplementedErr.Function)
+	})
+
+	t.Run("get controllers, not supported", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		// The host environment does not implement StorageCapabilityControllerEnumerator
+		runtimeInterface := &testLogOnlyRuntimeInterface{}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      let controllers = getAuthAccount(0x1).capabilities.getControllers(forPath: /storage/greeting)
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+
+		var notImplementedErr NotImplementedError
+		require.ErrorAs(t, err, &notImplementedErr)
+		assert.Equal(t, "GetStorageCapabilityControllerIDs", notImplementedErr.Function)
+	})
+}
+
+func TestAuthAccountInbox(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("publish, claim", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		provider := common.MustBytesToAddress([]byte{0x1})
+		recipient := common.MustBytesToAddress([]byte{0x2})
+
+		var signer Address
+		var events []cadence.Event
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{signer}, nil
+			},
+			issueStorageCapabilityController: func(_ Address, _ cadence.Path) (uint64, error) {
+				return 1, nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
 
-	value, err := runtime.ExecuteScript(
-		Script{
-			Source:    []byte(test.code),
-			Arguments: args,
-		},
-		Context{
-			Interface: runtimeInterface,
-			Location:  common.ScriptLocation{},
-		},
-	)
-	return value, err
-}
+		// Publish
 
-func newTestAccountKeyStorage() *testAccountKeyStorage {
-	return &testAccountKeyStorage{
-		events: make([]cadence.Event, 0),
-		keys:   make([]*stdlib.AccountKey, 0),
-	}
-}
+		signer = provider
 
-type testAccountKeyStorage struct {
-	events      []cadence.Event
-	keys        []*stdlib.AccountKey
-	returnedKey *stdlib.AccountKey
-	logs        []string
-}
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
+
+                          let cap = signer.capabilities.issue<&String>(target: /storage/greeting)
+                          signer.inbox.publish(cap, name: "greeting", recipient: 0x2)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
 
-func TestRuntimePublicKey(t *testing.T) {
+		require.Len(t, events, 1)
+		assert.Equal(t,
+			string(stdlib.InboxValuePublishedEventType.ID()),
+			events[0].EventType.ID(),
+		)
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.Address(provider),
+				cadence.Address(recipient),
+				cadence.String("greeting"),
+				cadence.TypeValue{
+					StaticType: cadence.CapabilityType{
+						BorrowType: cadence.ReferenceType{
+							Type: cadence.StringType{},
+						},
+					},
+				},
+			},
+			events[0].Fields,
+		)
 
-	t.Parallel()
+		// Claim
 
-	rt := newTestInterpreterRuntime()
+		events = nil
+		signer = recipient
 
-	executeScript := func(code string, runtimeInterface Interface) (cadence.Value, error) {
-		return rt.ExecuteScript(
+		err = rt.ExecuteTransaction(
 			Script{
-				Source: []byte(code),
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          // Only the recipient can claim, and only with a matching type
+                          assert(signer.inbox.claim<&Int>("greeting", provider: 0x2) == nil)
+                          assert(signer.inbox.claim<&Int>("greeting", provider: 0x1) == nil)
+
+                          let cap = signer.inbox.claim<&String>("greeting", provider: 0x1)!
+                          assert(cap.borrow()!.length == 5)
+
+                          // The value can only be claimed once
+                          assert(signer.inbox.claim<&String>("greeting", provider: 0x1) == nil)
+                      }
+                  }
+                `),
 			},
 			Context{
 				Interface: runtimeInterface,
-				Location:  utils.TestLocation,
+				Location:  nextTransactionLocation(),
 			},
 		)
-	}
+		require.NoError(t, err)
 
-	t.Run("Constructor", func(t *testing.T) {
-		script := `
-            pub fun main(): PublicKey {
-                let publicKey = PublicKey(
-                    publicKey: "0102".decodeHex(),
-                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                )
+		require.Len(t, events, 1)
+		assert.Equal(t,
+			string(stdlib.InboxValueClaimedEventType.ID()),
+			events[0].EventType.ID(),
+		)
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.Address(provider),
+				cadence.Address(recipient),
+				cadence.String("greeting"),
+			},
+			events[0].Fields,
+		)
+	})
 
-                return publicKey
-            }
-        `
+	t.Run("publish, unpublish", func(t *testing.T) {
+		t.Parallel()
 
-		storage := newTestLedger(nil, nil)
+		rt := newTestInterpreterRuntime()
+
+		var events []cadence.Event
 
 		runtimeInterface := &testRuntimeInterface{
-			storage: storage,
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			issueStorageCapabilityController: func(_ Address, _ cadence.Path) (uint64, error) {
+				return 1, nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
 		}
-		addPublicKeyValidation(runtimeInterface, nil)
 
-		value, err := executeScript(script, runtimeInterface)
-		require.NoError(t, err)
+		nextTransactionLocation := newTransactionLocationGenerator()
 
-		expected := cadence.Struct{
-			StructType: PublicKeyType,
-			Fields: []cadence.Value{
-				// Public key (bytes)
-				newBytesValue([]byte{1, 2}),
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save("hello", to: /storage/greeting)
 
-				// Signature Algo
-				newSignAlgoValue(sema.SignatureAlgorithmECDSA_P256),
+                          let cap = signer.capabilities.issue<&String>(target: /storage/greeting)
+                          signer.inbox.publish(cap, name: "greeting", recipient: 0x2)
+
+                          let unpublishedCap = signer.inbox.unpublish<&String>("greeting")!
+                          assert(unpublishedCap.borrow()!.length == 5)
+
+                          assert(signer.inbox.unpublish<&String>("greeting") == nil)
+                      }
+                  }
+                `),
 			},
-		}
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
 
-		assert.Equal(t, expected, value)
+		require.Len(t, events, 2)
+		assert.Equal(t,
+			string(stdlib.InboxValueUnpublishedEventType.ID()),
+			events[1].EventType.ID(),
+		)
 	})
 
-	t.Run("Validate func", func(t *testing.T) {
-		script := `
-            pub fun main(): Bool {
-                let publicKey =  PublicKey(
-                    publicKey: "0102".decodeHex(),
-                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                )
+	t.Run("claim non-existent", func(t *testing.T) {
+		t.Parallel()
 
-                return publicKey.validate()
-            }
-        `
+		rt := newTestInterpreterRuntime()
 
-		runtimeInterface := &testRuntimeInterface{}
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x2})}, nil
+			},
+		}
 
-		_, err := executeScript(script, runtimeInterface)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "value of type `PublicKey` has no member `validate`")
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          assert(signer.inbox.claim<&String>("nothing", provider: 0x1) == nil)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
 	})
+}
 
-	t.Run("Construct PublicKey in Cadence code", func(t *testing.T) {
-		script := `
-              pub fun main(): PublicKey {
-                  let publicKey = PublicKey(
-                      publicKey: "0102".decodeHex(),
-                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                  )
+func TestPublicAccountContracts(t *testing.T) {
 
-                  return publicKey
-              }
-            `
+	t.Parallel()
 
-		fakeError := &fakeError{}
-		for _, errorToReturn := range []error{fakeError, nil} {
-			invoked := false
+	t.Run("get contract", func(t *testing.T) {
+		t.Parallel()
 
-			storage := newTestLedger(nil, nil)
+		rt := newTestInterpreterRuntime()
 
-			runtimeInterface := &testRuntimeInterface{
-				storage: storage,
-				validatePublicKey: func(publicKey *stdlib.PublicKey) error {
-					invoked = true
-					return errorToReturn
-				},
-			}
+		script := []byte(`
+            pub fun main(): [AnyStruct] {
+                let acc = getAccount(0x02)
+                let deployedContract: DeployedContract? = acc.contracts.get(name: "foo")
 
-			value, err := executeScript(script, runtimeInterface)
+                return [deployedContract!.name, deployedContract!.code]
+            }
+        `)
 
-			assert.True(t, invoked, "validatePublicKey was not invoked")
+		invoked := false
 
-			if errorToReturn == nil {
-				assert.NotNil(t, value)
-				assert.NoError(t, err)
-			} else {
-				assert.Nil(t, value)
-				assert.ErrorAs(t, err, &errorToReturn)
-				assert.ErrorAs(t, err, &interpreter.InvalidPublicKeyError{})
-			}
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				invoked = true
+				return []byte{1, 2}, nil
+			},
 		}
-	})
-
-	t.Run("PublicKey from host env", func(t *testing.T) {
-		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
 
-		for index := range storage.keys {
-			script := fmt.Sprintf(`
-                          pub fun main(): PublicKey {
-                              // Get a public key from host env
-                              let acc = getAccount(0x02)
-                              let publicKey = acc.keys.get(keyIndex: %d)!.publicKey
-                              return publicKey
-                          }`,
-				index,
-			)
+		nextTransactionLocation := newTransactionLocationGenerator()
 
-			invoked := false
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
 
-			runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
-			runtimeInterface.validatePublicKey = func(publicKey *stdlib.PublicKey) error {
-				invoked = true
-				return nil
-			}
+		require.NoError(t, err)
+		assert.True(t, invoked)
 
-			value, err := executeScript(script, runtimeInterface)
+		require.IsType(t, cadence.Array{}, result)
+		array := result.(cadence.Array)
 
-			// skip validation when key comes from host env aka FVM
-			assert.False(t, invoked, "validatePublicKey was not invoked")
+		require.Len(t, array.Values, 2)
 
-			assert.IsType(t, cadence.Struct{}, value)
-			assert.Nil(t, err)
-		}
+		assert.Equal(t, cadence.String("foo"), array.Values[0])
+		assert.Equal(t,
+			cadence.Array{
+				Values: []cadence.Value{
+					cadence.UInt8(1),
+					cadence.UInt8(2),
+				},
+			}.WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.UInt8Type{},
+			}),
+			array.Values[1],
+		)
 	})
 
-	t.Run("Verify", func(t *testing.T) {
-		script := `
-            pub fun main(): Bool {
-                let publicKey =  PublicKey(
-                    publicKey: "0102".decodeHex(),
-                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                )
-
-                return publicKey.verify(
-                    signature: [],
-                    signedData: [],
-                    domainSeparationTag: "something",
-                    hashAlgorithm: HashAlgorithm.SHA2_256
-                )
+	t.Run("get non existing contract", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            pub fun main() {
+                let acc = getAccount(0x02)
+                assert(acc.contracts.get(name: "foo") == nil)
             }
-        `
-		invoked := false
+        `)
 
-		storage := newTestLedger(nil, nil)
+		invoked := false
 
 		runtimeInterface := &testRuntimeInterface{
-			storage: storage,
-			verifySignature: func(
-				_ []byte,
-				_ string,
-				_ []byte,
-				_ []byte,
-				_ SignatureAlgorithm,
-				_ HashAlgorithm,
-			) (bool, error) {
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
 				invoked = true
-				return true, nil
+				return nil, nil
 			},
 		}
-		addPublicKeyValidation(runtimeInterface, nil)
 
-		value, err := executeScript(script, runtimeInterface)
-		require.NoError(t, err)
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
 
+		require.NoError(t, err)
 		assert.True(t, invoked)
-		assert.Equal(t, cadence.Bool(true), value)
 	})
 
-	t.Run("Verify - publicKey from host env", func(t *testing.T) {
-
-		storage := newTestAccountKeyStorage()
-		storage.keys = append(storage.keys, accountKeyA, accountKeyB)
+	t.Run("borrow contract", func(t *testing.T) {
+		t.Parallel()
 
-		script := `
-            pub fun main(): Bool {
-                // Get a public key from host env
-                let acc = getAccount(0x02)
-                let publicKey = acc.keys.get(keyIndex: 0)!.publicKey
+		rt := newTestInterpreterRuntime()
 
-                return publicKey.verify(
-                    signature: [],
-                    signedData: [],
-                    domainSeparationTag: "something",
-                    hashAlgorithm: HashAlgorithm.SHA2_256
-                )
-            }
-        `
-		invoked := false
+		accountCodes := map[common.Location][]byte{}
 
-		runtimeInterface := getAccountKeyTestRuntimeInterface(storage)
-		runtimeInterface.verifySignature = func(
-			_ []byte,
-			_ string,
-			_ []byte,
-			_ []byte,
-			_ SignatureAlgorithm,
-			_ HashAlgorithm,
-		) (bool, error) {
-			invoked = true
-			return true, nil
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x2})}, nil
+			},
+			getCode: func(location Location) ([]byte, error) {
+				return accountCodes[location], nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location], nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
 		}
 
-		value, err := executeScript(script, runtimeInterface)
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction(
+					"Test",
+					[]byte(`
+                      pub contract Test {
+                          pub let answer: Int
+
+                          init() {
+                              self.answer = 42
+                          }
+                      }
+                    `),
+				),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
 		require.NoError(t, err)
 
-		assert.True(t, invoked)
-		assert.Equal(t, cadence.Bool(true), value)
+		script := []byte(`
+            pub fun main(): &AnyStruct {
+                let acc = getAccount(0x02)
+                return acc.contracts.borrowContract(name: "Test")!
+            }
+        `)
+
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Contract{}, result)
+		contract := result.(cadence.Contract)
+
+		require.Len(t, contract.Fields, 1)
+		assert.Equal(t, "answer", contract.ContractType.Fields[0].Identifier)
+		assert.Equal(t, cadence.NewInt(42), contract.Fields[0])
 	})
 
-	t.Run("field mutability", func(t *testing.T) {
-		script := `
-            pub fun main(): PublicKey {
-                let publicKey =  PublicKey(
-                    publicKey: "0102".decodeHex(),
-                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                )
+	t.Run("borrow non existing contract", func(t *testing.T) {
+		t.Parallel()
 
-                publicKey.publicKey = []
-                publicKey.signatureAlgorithm = SignatureAlgorithm.ECDSA_secp256k1
+		rt := newTestInterpreterRuntime()
 
-                return publicKey
+		script := []byte(`
+            pub fun main() {
+                let acc = getAccount(0x02)
+                assert(acc.contracts.borrowContract(name: "foo") == nil)
             }
-        `
-
-		storage := newTestLedger(nil, nil)
+        `)
 
 		runtimeInterface := &testRuntimeInterface{
-			storage: storage,
+			storage: newTestLedger(nil, nil),
 		}
 
-		_, err := executeScript(script, runtimeInterface)
-		require.Error(t, err)
-
-		var checkerErr *sema.CheckerError
-		require.ErrorAs(t, err, &checkerErr)
-
-		errs := checkerErr.Errors
-		require.Len(t, errs, 4)
-
-		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
-		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[1])
-		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[2])
-		assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[3])
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
 	})
 
-	t.Run("raw-key mutability", func(t *testing.T) {
-		script := `
-            pub fun main(): PublicKey {
-                let publicKey =  PublicKey(
-                    publicKey: "0102".decodeHex(),
-                    signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-                )
+	t.Run("get names", func(t *testing.T) {
+		t.Parallel()
 
-                publicKey.publicKey[0] = 5
+		rt := newTestInterpreterRuntime()
 
-                return publicKey
+		script := []byte(`
+            pub fun main(): [String] {
+                let acc = getAccount(0x02)
+                return acc.contracts.names
             }
-        `
+        `)
 
-		storage := newTestLedger(nil, nil)
+		invoked := false
 
 		runtimeInterface := &testRuntimeInterface{
-			storage: storage,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				invoked = true
+				return []string{"foo", "bar"}, nil
+			},
 		}
-		addPublicKeyValidation(runtimeInterface, nil)
-
-		_, err := executeScript(script, runtimeInterface)
 
-		require.Error(t, err)
+		nextTransactionLocation := newTransactionLocationGenerator()
 
-		var checkerErr *sema.CheckerError
-		require.ErrorAs(t, err, &checkerErr)
-		errs := checkerErr.Errors
-		require.Len(t, errs, 1)
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
 
-		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
-	})
+		require.NoError(t, err)
+		assert.True(t, invoked)
 
-	t.Run("raw-key reference mutability", func(t *testing.T) {
-		script := `
-        pub fun main(): PublicKey {
-            let publicKey =  PublicKey(
-                publicKey: "0102".decodeHex(),
-                signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
-            )
-          
-            var publickeyRef = &publicKey.publicKey as &[UInt8]
-            publickeyRef[0] = 3
+		require.IsType(t, cadence.Array{}, result)
+		array := result.(cadence.Array)
 
-            return publicKey
-          }
-        `
+		require.Len(t, array.Values, 2)
+		// The names are sorted
 
-		storage := newTestLedger(nil, nil)
+		assert.Equal(t, cadence.String("bar"), array.Values[0])
+		assert.Equal(t, cadence.String("foo"), array.Values[1])
+	})
 
-		runtimeInterface := &testRuntimeInterface{
-			storage: storage,
-		}
-		addPublicKeyValidation(runtimeInterface, nil)
+	t.Run("names with prefix", func(t *testing.T) {
+		t.Parallel()
 
-		value, err := executeScript(script, runtimeInterface)
-		require.NoError(t, err)
+		rt := newTestInterpreterRuntime()
 
-		expected := cadence.Struct{
-			StructType: PublicKeyType,
-			Fields: []cadence.Value{
-				// Public key (bytes)
-				newBytesValue([]byte{1, 2}),
-				// Signature Algo
-				newSignAlgoValue(sema.SignatureAlgorithmECDSA_P256),
+		script := []byte(`
+            pub fun main(): [String] {
+                let acc = getAccount(0x02)
+                return acc.contracts.namesWithPrefix("Foo")
+            }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			getAccountContractNames: func(_ Address) ([]string, error) {
+				return []string{"FooToken", "Bar", "FooNFT", "BarFoo", "Foo"}, nil
 			},
 		}
-		assert.Equal(t, expected, value)
-	})
 
-}
+		nextTransactionLocation := newTransactionLocationGenerator()
 
-func TestAuthAccountContracts(t *testing.T) {
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
 
-	t.Parallel()
+		require.IsType(t, cadence.Array{}, result)
+		array := result.(cadence.Array)
 
-	t.Run("get names", func(t *testing.T) {
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.String("Foo"),
+				cadence.String("FooNFT"),
+				cadence.String("FooToken"),
+			},
+			array.Values,
+		)
+	})
+
+	t.Run("names with prefix, no match", func(t *testing.T) {
 		t.Parallel()
 
 		rt := newTestInterpreterRuntime()
 
 		script := []byte(`
-            transaction {
-                prepare(signer: AuthAccount) {
-                    let names = signer.contracts.names
-
-                    assert(names.isInstance(Type<[String]>()))
-                    assert(names.length == 2)
-                }
+            pub fun main(): [String] {
+                let acc = getAccount(0x02)
+                return acc.contracts.namesWithPrefix("Baz")
             }
         `)
 
-		invoked := false
-
 		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
-			},
 			getAccountContractNames: func(_ Address) ([]string, error) {
-				invoked = true
-				return []string{"foo", "bar"}, nil
+				return []string{"FooToken", "Bar"}, nil
 			},
 		}
 
 		nextTransactionLocation := newTransactionLocationGenerator()
 
-		err := rt.ExecuteTransaction(
+		result, err := rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
@@ -1385,9 +3557,10 @@ func TestAuthAccountContracts(t *testing.T) {
 				Location:  nextTransactionLocation(),
 			},
 		)
-
 		require.NoError(t, err)
-		assert.True(t, invoked)
+
+		require.IsType(t, cadence.Array{}, result)
+		assert.Empty(t, result.(cadence.Array).Values)
 	})
 
 	t.Run("update names", func(t *testing.T) {
@@ -1396,10 +3569,10 @@ func TestAuthAccountContracts(t *testing.T) {
 		rt := newTestInterpreterRuntime()
 
 		script := []byte(`
-            transaction {
-                prepare(signer: AuthAccount) {
-                    signer.contracts.names[0] = "baz"
-                }
+            pub fun main(): [String] {
+                let acc = getAccount(0x02)
+                acc.contracts.names[0] = "baz"
+                return acc.contracts.names
             }
         `)
 
@@ -1414,7 +3587,7 @@ func TestAuthAccountContracts(t *testing.T) {
 
 		nextTransactionLocation := newTransactionLocationGenerator()
 
-		err := rt.ExecuteTransaction(
+		_, err := rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
@@ -1423,6 +3596,7 @@ func TestAuthAccountContracts(t *testing.T) {
 				Location:  nextTransactionLocation(),
 			},
 		)
+
 		require.Error(t, err)
 
 		var checkerErr *sema.CheckerError
@@ -1433,19 +3607,16 @@ func TestAuthAccountContracts(t *testing.T) {
 		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
 	})
 
-	t.Run("update names through reference", func(t *testing.T) {
+	t.Run("append names", func(t *testing.T) {
 		t.Parallel()
 
 		rt := newTestInterpreterRuntime()
 
 		script := []byte(`
-            transaction {
-                prepare(signer: AuthAccount) {
-                    var namesRef = &signer.contracts.names as &[String]
-                    namesRef[0] = "baz"
-
-                    assert(signer.contracts.names[0] == "foo")
-                }
+            pub fun main(): [String] {
+                let acc = getAccount(0x02)
+                acc.contracts.names.append("baz")
+                return acc.contracts.names
             }
         `)
 
@@ -1460,7 +3631,7 @@ func TestAuthAccountContracts(t *testing.T) {
 
 		nextTransactionLocation := newTransactionLocationGenerator()
 
-		err := rt.ExecuteTransaction(
+		_, err := rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
@@ -1469,247 +3640,443 @@ func TestAuthAccountContracts(t *testing.T) {
 				Location:  nextTransactionLocation(),
 			},
 		)
-		require.NoError(t, err)
+
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+		errs := checkerErr.Errors
+		require.Len(t, errs, 1)
+
+		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
 	})
 }
 
-func TestPublicAccountContracts(t *testing.T) {
+type testBulkAccountContractNamesRuntimeInterface struct {
+	*testRuntimeInterface
+	getAccountContractNamesBatch func(addresses []Address) (map[Address][]string, error)
+}
+
+var _ BulkAccountContractNamesProvider = &testBulkAccountContractNamesRuntimeInterface{}
+
+func (i *testBulkAccountContractNamesRuntimeInterface) GetAccountContractNamesBatch(
+	addresses []Address,
+) (map[Address][]string, error) {
+	return i.getAccountContractNamesBatch(addresses)
+}
+
+func TestRuntimeGetContractNamesFor(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("get contract", func(t *testing.T) {
-		t.Parallel()
+	script := []byte(`
+      pub fun main(): {Address: [String]} {
+          return getContractNamesFor([0x1, 0x2, 0x1])
+      }
+    `)
 
-		rt := newTestInterpreterRuntime()
+	contractNames := map[Address][]string{
+		common.MustBytesToAddress([]byte{0x1}): {"A", "B"},
+	}
 
-		script := []byte(`
-            pub fun main(): [AnyStruct] {
-                let acc = getAccount(0x02)
-                let deployedContract: DeployedContract? = acc.contracts.get(name: "foo")
+	namesType := cadence.VariableSizedArrayType{
+		ElementType: cadence.StringType{},
+	}
 
-                return [deployedContract!.name, deployedContract!.code]
-            }
-        `)
+	expected := cadence.NewDictionary([]cadence.KeyValuePair{
+		{
+			Key: cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+			Value: cadence.NewArray([]cadence.Value{
+				cadence.String("A"),
+				cadence.String("B"),
+			}).WithType(namesType),
+		},
+		{
+			Key:   cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 2}),
+			Value: cadence.NewArray([]cadence.Value{}).WithType(namesType),
+		},
+	}).WithType(cadence.DictionaryType{
+		KeyType:     cadence.AddressType{},
+		ElementType: namesType,
+	})
 
-		invoked := false
+	t.Run("bulk provider", func(t *testing.T) {
+		t.Parallel()
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
+		rt := newTestInterpreterRuntime()
+
+		var batches [][]Address
+
+		runtimeInterface := &testBulkAccountContractNamesRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				getAccountContractNames: func(_ Address) ([]string, error) {
+					require.FailNow(t, "unexpected call of GetAccountContractNames")
+					return nil, nil
+				},
 			},
-			getAccountContractCode: func(address Address, name string) ([]byte, error) {
-				invoked = true
-				return []byte{1, 2}, nil
+			getAccountContractNamesBatch: func(addresses []Address) (map[Address][]string, error) {
+				batches = append(batches, addresses)
+				return contractNames, nil
 			},
 		}
 
-		nextTransactionLocation := newTransactionLocationGenerator()
-
 		result, err := rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
 			Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.ScriptLocation{},
 			},
 		)
-
 		require.NoError(t, err)
-		assert.True(t, invoked)
-
-		require.IsType(t, cadence.Array{}, result)
-		array := result.(cadence.Array)
 
-		require.Len(t, array.Values, 2)
+		assert.Equal(t, expected, result)
 
-		assert.Equal(t, cadence.String("foo"), array.Values[0])
 		assert.Equal(t,
-			cadence.Array{
-				Values: []cadence.Value{
-					cadence.UInt8(1),
-					cadence.UInt8(2),
+			[][]Address{
+				{
+					common.MustBytesToAddress([]byte{0x1}),
+					common.MustBytesToAddress([]byte{0x2}),
 				},
-			}.WithType(cadence.VariableSizedArrayType{
-				ElementType: cadence.UInt8Type{},
-			}),
-			array.Values[1],
+			},
+			batches,
 		)
 	})
 
-	t.Run("get non existing contract", func(t *testing.T) {
+	t.Run("fallback", func(t *testing.T) {
 		t.Parallel()
 
 		rt := newTestInterpreterRuntime()
 
-		script := []byte(`
-            pub fun main() {
-                let acc = getAccount(0x02)
-                assert(acc.contracts.get(name: "foo") == nil)
-            }
-        `)
-
-		invoked := false
+		var requested []Address
 
 		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
-			},
-			getAccountContractCode: func(address Address, name string) ([]byte, error) {
-				invoked = true
-				return nil, nil
+			getAccountContractNames: func(address Address) ([]string, error) {
+				requested = append(requested, address)
+				return contractNames[address], nil
 			},
 		}
 
-		nextTransactionLocation := newTransactionLocationGenerator()
-
-		_, err := rt.ExecuteScript(
+		result, err := rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
 			Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.ScriptLocation{},
 			},
 		)
-
 		require.NoError(t, err)
-		assert.True(t, invoked)
+
+		assert.Equal(t, expected, result)
+
+		assert.Equal(t,
+			[]Address{
+				common.MustBytesToAddress([]byte{0x1}),
+				common.MustBytesToAddress([]byte{0x2}),
+			},
+			requested,
+		)
 	})
+}
 
-	t.Run("get names", func(t *testing.T) {
-		t.Parallel()
+type testContractHistoryRuntimeInterface struct {
+	*testRuntimeInterface
+	getAccountContractUpdateHistory func(address Address, name string) ([]ContractVersion, error)
+}
 
-		rt := newTestInterpreterRuntime()
+var _ ContractHistoryProvider = &testContractHistoryRuntimeInterface{}
 
-		script := []byte(`
-            pub fun main(): [String] {
-                let acc = getAccount(0x02)
-                return acc.contracts.names
-            }
-        `)
+func (i *testContractHistoryRuntimeInterface) GetAccountContractUpdateHistory(
+	address Address,
+	name string,
+) ([]ContractVersion, error) {
+	return i.getAccountContractUpdateHistory(address, name)
+}
 
-		invoked := false
+func TestRuntimeAccountContractsUpdateHistory(t *testing.T) {
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
-			},
-			getAccountContractNames: func(_ Address) ([]string, error) {
-				invoked = true
-				return []string{"foo", "bar"}, nil
-			},
-		}
+	t.Parallel()
 
-		nextTransactionLocation := newTransactionLocationGenerator()
+	script := []byte(`
+      pub fun main(): [String] {
+          let history = getAccount(0x1).contracts.updateHistory(name: "Test")
+          let authHistory = getAuthAccount(0x1).contracts.updateHistory(name: "Test")
+          assert(history.length == authHistory.length)
+
+          let versions: [String] = []
+          for version in history {
+              versions.append(
+                  version.version.toString()
+                      .concat(" ")
+                      .concat(version.codeHash.toHexString())
+                      .concat(" ")
+                      .concat(version.timestamp.toString())
+              )
+          }
+          return versions
+      }
+    `)
 
-		result, err := rt.ExecuteScript(
+	executeScript := func(runtimeInterface Interface) (cadence.Value, error) {
+		rt := newTestInterpreterRuntime()
+		return rt.ExecuteScript(
 			Script{
 				Source: script,
 			},
 			Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.ScriptLocation{},
 			},
 		)
+	}
+
+	t.Run("provider", func(t *testing.T) {
+		t.Parallel()
+
+		runtimeInterface := &testContractHistoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{},
+			getAccountContractUpdateHistory: func(address Address, name string) ([]ContractVersion, error) {
+				assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+				assert.Equal(t, "Test", name)
+
+				return []ContractVersion{
+					{
+						Version:   1,
+						CodeHash:  []byte{0x01, 0x02},
+						Timestamp: 1_000_000_000,
+					},
+					{
+						Version:   2,
+						CodeHash:  []byte{0xca, 0xde},
+						Timestamp: 5_000_000_000,
+					},
+				}, nil
+			},
+		}
 
+		result, err := executeScript(runtimeInterface)
 		require.NoError(t, err)
-		assert.True(t, invoked)
 
-		require.IsType(t, cadence.Array{}, result)
-		array := result.(cadence.Array)
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String("1 0102 1.00000000"),
+				cadence.String("2 cade 5.00000000"),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.StringType{},
+			}),
+			result,
+		)
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		t.Parallel()
+
+		runtimeInterface := &testContractHistoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{},
+			getAccountContractUpdateHistory: func(_ Address, _ string) ([]ContractVersion, error) {
+				return nil, nil
+			},
+		}
+
+		result, err := executeScript(runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewArray([]cadence.Value{}), result.(cadence.Array).WithType(nil))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := executeScript(&testRuntimeInterface{})
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewArray([]cadence.Value{}), result.(cadence.Array).WithType(nil))
+	})
+}
+
+func TestRuntimeAccountEquality(t *testing.T) {
+
+	t.Parallel()
+
+	rt := newTestInterpreterRuntime()
+
+	script := []byte(`
+        pub fun main(): [Bool] {
+            let a = getAccount(0x01)
+            let b = getAccount(0x01)
+            let c = getAccount(0x02)
+
+            let authA = getAuthAccount(0x01)
+            let authB = getAuthAccount(0x01)
+            let authC = getAuthAccount(0x02)
+
+            return [
+                a == b,
+                a == c,
+                a != c,
+                authA == authB,
+                authA == authC,
+                [a, c].contains(b)
+            ]
+        }
+    `)
+
+	runtimeInterface := &testRuntimeInterface{}
+
+	result, err := rt.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{0x1},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewBool(true),
+			cadence.NewBool(false),
+			cadence.NewBool(true),
+			cadence.NewBool(true),
+			cadence.NewBool(false),
+			cadence.NewBool(true),
+		}).WithType(cadence.VariableSizedArrayType{
+			ElementType: cadence.BoolType{},
+		}),
+		result,
+	)
+}
+
+type testNameResolver map[string]common.Address
+
+var _ stdlib.NameResolver = testNameResolver{}
+
+func (r testNameResolver) ResolveAccountName(name string) (common.Address, bool, error) {
+	address, ok := r[name]
+	return address, ok, nil
+}
 
-		require.Len(t, array.Values, 2)
-		assert.Equal(t, cadence.String("foo"), array.Values[0])
-		assert.Equal(t, cadence.String("bar"), array.Values[1])
-	})
+func TestRuntimeGetAccountByName(t *testing.T) {
 
-	t.Run("update names", func(t *testing.T) {
-		t.Parallel()
+	t.Parallel()
 
-		rt := newTestInterpreterRuntime()
+	rt := newTestInterpreterRuntime()
 
-		script := []byte(`
-            pub fun main(): [String] {
-                let acc = getAccount(0x02)
-                acc.contracts.names[0] = "baz"
-                return acc.contracts.names
-            }
-        `)
+	resolver := testNameResolver{
+		"alice": common.MustBytesToAddress([]byte{0x2}),
+	}
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
-			},
-			getAccountContractNames: func(_ Address) ([]string, error) {
-				return []string{"foo", "bar"}, nil
-			},
-		}
+	environment := NewScriptInterpreterEnvironment(Config{})
+	environment.Declare(
+		stdlib.NewGetAccountByNameFunction(
+			resolver,
+			environment.(stdlib.PublicAccountHandler),
+		),
+	)
 
-		nextTransactionLocation := newTransactionLocationGenerator()
+	script := []byte(`
+        pub fun main(): [Address?] {
+            return [
+                getAccountByName("alice")?.address,
+                getAccountByName("bob")?.address
+            ]
+        }
+    `)
 
-		_, err := rt.ExecuteScript(
-			Script{
-				Source: script,
-			},
-			Context{
-				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
-			},
-		)
+	runtimeInterface := &testRuntimeInterface{}
 
-		require.Error(t, err)
+	result, err := rt.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:   runtimeInterface,
+			Location:    common.ScriptLocation{0x1},
+			Environment: environment,
+		},
+	)
+	require.NoError(t, err)
 
-		var checkerErr *sema.CheckerError
-		require.ErrorAs(t, err, &checkerErr)
-		errs := checkerErr.Errors
-		require.Len(t, errs, 1)
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewOptional(cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 0x2})),
+			cadence.NewOptional(nil),
+		}).WithType(cadence.VariableSizedArrayType{
+			ElementType: cadence.OptionalType{
+				Type: cadence.AddressType{},
+			},
+		}),
+		result,
+	)
+}
 
-		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
-	})
+func TestRuntimeAddressValidation(t *testing.T) {
 
-	t.Run("append names", func(t *testing.T) {
-		t.Parallel()
+	t.Parallel()
 
-		rt := newTestInterpreterRuntime()
+	// Simulate a chain with shorter addresses,
+	// where the first two bytes of an address must be zero
 
-		script := []byte(`
-            pub fun main(): [String] {
-                let acc = getAccount(0x02)
-                acc.contracts.names.append("baz")
-                return acc.contracts.names
-            }
-        `)
+	errInvalidAddressFormat := goerrors.New("address exceeds the address length of the chain")
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return []Address{{42}}, nil
-			},
-			getAccountContractNames: func(_ Address) ([]string, error) {
-				return []string{"foo", "bar"}, nil
-			},
-		}
+	rt := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled: true,
+		AddressValidator: func(address common.Address) error {
+			if address[0] != 0 || address[1] != 0 {
+				return errInvalidAddressFormat
+			}
+			return nil
+		},
+	})
 
-		nextTransactionLocation := newTransactionLocationGenerator()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+	}
 
-		_, err := rt.ExecuteScript(
+	execute := func(address string) (cadence.Value, error) {
+		return rt.ExecuteScript(
 			Script{
-				Source: script,
+				Source: []byte(fmt.Sprintf(
+					`
+                      pub fun main(): Address {
+                          return getAccount(%s).address
+                      }
+                    `,
+					address,
+				)),
 			},
 			Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.ScriptLocation{},
 			},
 		)
+	}
 
-		require.Error(t, err)
+	t.Run("valid address", func(t *testing.T) {
+		t.Parallel()
 
-		var checkerErr *sema.CheckerError
-		require.ErrorAs(t, err, &checkerErr)
-		errs := checkerErr.Errors
-		require.Len(t, errs, 1)
+		result, err := execute("0x0000000000000001")
+		require.NoError(t, err)
 
-		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
+		assert.Equal(t,
+			cadence.Address(common.MustBytesToAddress([]byte{0x1})),
+			result,
+		)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := execute("0x0100000000000001")
+		require.Error(t, err)
+
+		var invalidAddressErr *stdlib.InvalidAddressError
+		require.ErrorAs(t, err, &invalidAddressErr)
+		require.ErrorIs(t, err, errInvalidAddressFormat)
 	})
 }
 
@@ -1889,8 +4256,226 @@ func TestGetAuthAccount(t *testing.T) {
 	})
 }
 
+func TestGetAuthAccounts(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("script", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            pub fun main(): [UInt64] {
+                let accounts = getAuthAccounts([0x02, 0x03])
+                assert(accounts.length == 2)
+                assert(accounts[0].address == 0x02)
+                assert(accounts[1].address == 0x03)
+                return [accounts[0].storageUsed, accounts[1].storageUsed]
+            }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			getStorageUsed: func(address Address) (uint64, error) {
+				return uint64(address[len(address)-1]), nil
+			},
+		}
+
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{0x1},
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.UInt64(2),
+				cadence.UInt64(3),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.UInt64Type{},
+			}),
+			result,
+		)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            transaction {
+                prepare() {
+                    let accounts = getAuthAccounts([0x02])
+                    log(accounts[0].storageUsed)
+                }
+            }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			getStorageUsed: func(_ Address) (uint64, error) {
+				return 1, nil
+			},
+		}
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x1},
+			},
+		)
+
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+		errs := checkerErr.Errors
+		require.Len(t, errs, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+}
+
 type fakeError struct{}
 
 func (fakeError) Error() string {
 	return "fake error for testing"
 }
+
+type testAddressFromPublicKeyRuntimeInterface struct {
+	*testRuntimeInterface
+	getAddressForPublicKey func(publicKey *stdlib.PublicKey) (Address, bool, error)
+}
+
+var _ AddressFromPublicKeyProvider = testAddressFromPublicKeyRuntimeInterface{}
+
+func (i testAddressFromPublicKeyRuntimeInterface) GetAddressForPublicKey(
+	publicKey *stdlib.PublicKey,
+) (Address, bool, error) {
+	return i.getAddressForPublicKey(publicKey)
+}
+
+func TestRuntimeGetAddressForPublicKey(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): Address? {
+          let publicKey = PublicKey(
+              publicKey: "010203".decodeHex(),
+              signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+          )
+          return getAddressForPublicKey(publicKey)
+      }
+    `)
+
+	executeScript := func(runtimeInterface Interface) (cadence.Value, error) {
+		runtime := newTestInterpreterRuntime()
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	t.Run("supported", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		var requestedKey *stdlib.PublicKey
+
+		result, err := executeScript(
+			testAddressFromPublicKeyRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				getAddressForPublicKey: func(publicKey *stdlib.PublicKey) (Address, bool, error) {
+					requestedKey = publicKey
+					return Address{0x42}, true, nil
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewOptional(cadence.Address{0x42}),
+			result,
+		)
+
+		assert.Equal(t,
+			&stdlib.PublicKey{
+				PublicKey: []byte{1, 2, 3},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+			},
+			requestedKey,
+		)
+	})
+
+	t.Run("not derivable", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		result, err := executeScript(
+			testAddressFromPublicKeyRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				getAddressForPublicKey: func(_ *stdlib.PublicKey) (Address, bool, error) {
+					return Address{}, false, nil
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewOptional(nil), result)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		_, err := executeScript(
+			testAddressFromPublicKeyRuntimeInterface{
+				testRuntimeInterface: runtimeInterface,
+				getAddressForPublicKey: func(_ *stdlib.PublicKey) (Address, bool, error) {
+					return Address{}, false, goerrors.New("address derivation failed")
+				},
+			},
+		)
+		require.Error(t, err)
+
+		// Errors of the host environment are reported as external errors
+
+		assertRuntimeErrorIsExternalError(t, err)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		result, err := executeScript(runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewOptional(nil), result)
+	})
+}
@@ -0,0 +1,219 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/onflow/atree"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// BaseInterface is a default implementation of Interface.
+//
+// Functions which are optional hooks, i.e. program caching, metering,
+// tracing, debug logging, and resource owner change notifications, are no-ops.
+// All other functions return a NotImplementedError.
+//
+// BaseInterface is intended to be embedded into a host implementation,
+// which then only needs to implement the functions it requires.
+//
+type BaseInterface struct{}
+
+var _ Interface = BaseInterface{}
+
+func (BaseInterface) ResolveLocation(_ []Identifier, _ Location) ([]ResolvedLocation, error) {
+	return nil, NotImplementedError{Function: "ResolveLocation"}
+}
+
+func (BaseInterface) GetCode(_ Location) ([]byte, error) {
+	return nil, NotImplementedError{Function: "GetCode"}
+}
+
+func (BaseInterface) GetProgram(_ Location) (*interpreter.Program, error) {
+	return nil, nil
+}
+
+func (BaseInterface) SetProgram(_ Location, _ *interpreter.Program) error {
+	return nil
+}
+
+func (BaseInterface) GetValue(_, _ []byte) ([]byte, error) {
+	return nil, NotImplementedError{Function: "GetValue"}
+}
+
+func (BaseInterface) SetValue(_, _, _ []byte) error {
+	return NotImplementedError{Function: "SetValue"}
+}
+
+func (BaseInterface) ValueExists(_, _ []byte) (bool, error) {
+	return false, NotImplementedError{Function: "ValueExists"}
+}
+
+func (BaseInterface) AllocateStorageIndex(_ []byte) (atree.StorageIndex, error) {
+	return atree.StorageIndex{}, NotImplementedError{Function: "AllocateStorageIndex"}
+}
+
+func (BaseInterface) CreateAccount(_ Address) (Address, error) {
+	return Address{}, NotImplementedError{Function: "CreateAccount"}
+}
+
+func (BaseInterface) AddEncodedAccountKey(_ Address, _ []byte) error {
+	return NotImplementedError{Function: "AddEncodedAccountKey"}
+}
+
+func (BaseInterface) RevokeEncodedAccountKey(_ Address, _ int) ([]byte, error) {
+	return nil, NotImplementedError{Function: "RevokeEncodedAccountKey"}
+}
+
+func (BaseInterface) AddAccountKey(_ Address, _ *PublicKey, _ HashAlgorithm, _ int) (*AccountKey, error) {
+	return nil, NotImplementedError{Function: "AddAccountKey"}
+}
+
+func (BaseInterface) GetAccountKey(_ Address, _ int) (*AccountKey, error) {
+	return nil, NotImplementedError{Function: "GetAccountKey"}
+}
+
+func (BaseInterface) RevokeAccountKey(_ Address, _ int) (*AccountKey, error) {
+	return nil, NotImplementedError{Function: "RevokeAccountKey"}
+}
+
+func (BaseInterface) UpdateAccountContractCode(_ Address, _ string, _ []byte) error {
+	return NotImplementedError{Function: "UpdateAccountContractCode"}
+}
+
+func (BaseInterface) GetAccountContractCode(_ Address, _ string) ([]byte, error) {
+	return nil, NotImplementedError{Function: "GetAccountContractCode"}
+}
+
+func (BaseInterface) RemoveAccountContractCode(_ Address, _ string) error {
+	return NotImplementedError{Function: "RemoveAccountContractCode"}
+}
+
+func (BaseInterface) GetSigningAccounts() ([]Address, error) {
+	return nil, NotImplementedError{Function: "GetSigningAccounts"}
+}
+
+func (BaseInterface) ProgramLog(_ string) error {
+	return NotImplementedError{Function: "ProgramLog"}
+}
+
+func (BaseInterface) EmitEvent(_ cadence.Event) error {
+	return NotImplementedError{Function: "EmitEvent"}
+}
+
+func (BaseInterface) GenerateUUID() (uint64, error) {
+	return 0, NotImplementedError{Function: "GenerateUUID"}
+}
+
+func (BaseInterface) MeterComputation(_ common.ComputationKind, _ uint) error {
+	return nil
+}
+
+func (BaseInterface) DecodeArgument(_ []byte, _ cadence.Type) (cadence.Value, error) {
+	return nil, NotImplementedError{Function: "DecodeArgument"}
+}
+
+func (BaseInterface) GetCurrentBlockHeight() (uint64, error) {
+	return 0, NotImplementedError{Function: "GetCurrentBlockHeight"}
+}
+
+func (BaseInterface) GetBlockAtHeight(_ uint64) (Block, bool, error) {
+	return Block{}, false, NotImplementedError{Function: "GetBlockAtHeight"}
+}
+
+func (BaseInterface) UnsafeRandom() (uint64, error) {
+	return 0, NotImplementedError{Function: "UnsafeRandom"}
+}
+
+func (BaseInterface) VerifySignature(
+	_ []byte,
+	_ string,
+	_ []byte,
+	_ []byte,
+	_ SignatureAlgorithm,
+	_ HashAlgorithm,
+) (bool, error) {
+	return false, NotImplementedError{Function: "VerifySignature"}
+}
+
+func (BaseInterface) Hash(_ []byte, _ string, _ HashAlgorithm) ([]byte, error) {
+	return nil, NotImplementedError{Function: "Hash"}
+}
+
+func (BaseInterface) GetAccountBalance(_ common.Address) (uint64, error) {
+	return 0, NotImplementedError{Function: "GetAccountBalance"}
+}
+
+func (BaseInterface) GetAccountAvailableBalance(_ common.Address) (uint64, error) {
+	return 0, NotImplementedError{Function: "GetAccountAvailableBalance"}
+}
+
+func (BaseInterface) GetStorageUsed(_ Address) (uint64, error) {
+	return 0, NotImplementedError{Function: "GetStorageUsed"}
+}
+
+func (BaseInterface) GetStorageCapacity(_ Address) (uint64, error) {
+	return 0, NotImplementedError{Function: "GetStorageCapacity"}
+}
+
+func (BaseInterface) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (BaseInterface) ValidatePublicKey(_ *PublicKey) error {
+	return NotImplementedError{Function: "ValidatePublicKey"}
+}
+
+func (BaseInterface) GetAccountContractNames(_ Address) ([]string, error) {
+	return nil, NotImplementedError{Function: "GetAccountContractNames"}
+}
+
+func (BaseInterface) RecordTrace(_ string, _ Location, _ time.Duration, _ []attribute.KeyValue) {
+	// NO-OP
+}
+
+func (BaseInterface) BLSVerifyPOP(_ *PublicKey, _ []byte) (bool, error) {
+	return false, NotImplementedError{Function: "BLSVerifyPOP"}
+}
+
+func (BaseInterface) BLSAggregateSignatures(_ [][]byte) ([]byte, error) {
+	return nil, NotImplementedError{Function: "BLSAggregateSignatures"}
+}
+
+func (BaseInterface) BLSAggregatePublicKeys(_ []*PublicKey) (*PublicKey, error) {
+	return nil, NotImplementedError{Function: "BLSAggregatePublicKeys"}
+}
+
+func (BaseInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
+
+func (BaseInterface) MeterMemory(_ common.MemoryUsage) error {
+	return nil
+}
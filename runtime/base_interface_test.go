@@ -0,0 +1,99 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testLogOnlyRuntimeInterface struct {
+	BaseInterface
+	logs []string
+}
+
+var _ Interface = &testLogOnlyRuntimeInterface{}
+
+func (i *testLogOnlyRuntimeInterface) ProgramLog(message string) error {
+	i.logs = append(i.logs, message)
+	return nil
+}
+
+func TestRuntimeBaseInterface(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("overridden function", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testLogOnlyRuntimeInterface{}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      log("hello")
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{`"hello"`}, runtimeInterface.logs)
+	})
+
+	t.Run("default function", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testLogOnlyRuntimeInterface{}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main(): UInt64 {
+                      return getCurrentBlock().height
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+		require.Error(t, err)
+
+		var notImplementedErr NotImplementedError
+		require.ErrorAs(t, err, &notImplementedErr)
+		assert.Equal(t, "GetCurrentBlockHeight", notImplementedErr.Function)
+	})
+}
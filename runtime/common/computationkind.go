@@ -142,4 +142,11 @@ const (
 	// RLP
 	ComputationKindSTDLIBRLPDecodeString
 	ComputationKindSTDLIBRLPDecodeList
+	// Crypto
+	ComputationKindSTDLIBVerifySignatures
+	// RLP encoding
+	ComputationKindSTDLIBRLPEncodeString
+	ComputationKindSTDLIBRLPEncodeList
+	// Crypto
+	ComputationKindSTDLIBHashWithSalt
 )
@@ -26,6 +26,10 @@ func _() {
 	_ = x[ComputationKindSTDLIBUnsafeRandom-1102]
 	_ = x[ComputationKindSTDLIBRLPDecodeString-1108]
 	_ = x[ComputationKindSTDLIBRLPDecodeList-1109]
+	_ = x[ComputationKindSTDLIBVerifySignatures-1110]
+	_ = x[ComputationKindSTDLIBRLPEncodeString-1111]
+	_ = x[ComputationKindSTDLIBRLPEncodeList-1112]
+	_ = x[ComputationKindSTDLIBHashWithSalt-1113]
 }
 
 const (
@@ -35,7 +39,7 @@ const (
 	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValue"
 	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValue"
 	_ComputationKind_name_5 = "STDLIBPanicSTDLIBAssertSTDLIBUnsafeRandom"
-	_ComputationKind_name_6 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
+	_ComputationKind_name_6 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeListSTDLIBVerifySignaturesSTDLIBRLPEncodeStringSTDLIBRLPEncodeListSTDLIBHashWithSalt"
 )
 
 var (
@@ -44,7 +48,7 @@ var (
 	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51}
 	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66}
 	_ComputationKind_index_5 = [...]uint8{0, 11, 23, 41}
-	_ComputationKind_index_6 = [...]uint8{0, 21, 40}
+	_ComputationKind_index_6 = [...]uint8{0, 21, 40, 62, 83, 102, 120}
 )
 
 func (i ComputationKind) String() string {
@@ -66,7 +70,7 @@ func (i ComputationKind) String() string {
 	case 1100 <= i && i <= 1102:
 		i -= 1100
 		return _ComputationKind_name_5[_ComputationKind_index_5[i]:_ComputationKind_index_5[i+1]]
-	case 1108 <= i && i <= 1109:
+	case 1108 <= i && i <= 1113:
 		i -= 1108
 		return _ComputationKind_name_6[_ComputationKind_index_6[i]:_ComputationKind_index_6[i+1]]
 	default:
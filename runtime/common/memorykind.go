@@ -52,6 +52,8 @@ const (
 	MemoryKindBoundFunctionValue
 	MemoryKindBigInt
 	MemoryKindSimpleCompositeValue
+	MemoryKindStorageCapabilityController
+	MemoryKindAccountCapabilityController
 
 	// Atree Nodes
 	MemoryKindAtreeArrayDataSlab
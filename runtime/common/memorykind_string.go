@@ -32,165 +32,167 @@ func _() {
 	_ = x[MemoryKindBoundFunctionValue-21]
 	_ = x[MemoryKindBigInt-22]
 	_ = x[MemoryKindSimpleCompositeValue-23]
-	_ = x[MemoryKindAtreeArrayDataSlab-24]
-	_ = x[MemoryKindAtreeArrayMetaDataSlab-25]
-	_ = x[MemoryKindAtreeArrayElementOverhead-26]
-	_ = x[MemoryKindAtreeMapDataSlab-27]
-	_ = x[MemoryKindAtreeMapMetaDataSlab-28]
-	_ = x[MemoryKindAtreeMapElementOverhead-29]
-	_ = x[MemoryKindAtreeMapPreAllocatedElement-30]
-	_ = x[MemoryKindAtreeEncodedSlab-31]
-	_ = x[MemoryKindPrimitiveStaticType-32]
-	_ = x[MemoryKindCompositeStaticType-33]
-	_ = x[MemoryKindInterfaceStaticType-34]
-	_ = x[MemoryKindVariableSizedStaticType-35]
-	_ = x[MemoryKindConstantSizedStaticType-36]
-	_ = x[MemoryKindDictionaryStaticType-37]
-	_ = x[MemoryKindOptionalStaticType-38]
-	_ = x[MemoryKindRestrictedStaticType-39]
-	_ = x[MemoryKindReferenceStaticType-40]
-	_ = x[MemoryKindCapabilityStaticType-41]
-	_ = x[MemoryKindFunctionStaticType-42]
-	_ = x[MemoryKindCadenceVoidValue-43]
-	_ = x[MemoryKindCadenceOptionalValue-44]
-	_ = x[MemoryKindCadenceBoolValue-45]
-	_ = x[MemoryKindCadenceStringValue-46]
-	_ = x[MemoryKindCadenceCharacterValue-47]
-	_ = x[MemoryKindCadenceAddressValue-48]
-	_ = x[MemoryKindCadenceIntValue-49]
-	_ = x[MemoryKindCadenceNumberValue-50]
-	_ = x[MemoryKindCadenceArrayValueBase-51]
-	_ = x[MemoryKindCadenceArrayValueLength-52]
-	_ = x[MemoryKindCadenceDictionaryValue-53]
-	_ = x[MemoryKindCadenceKeyValuePair-54]
-	_ = x[MemoryKindCadenceStructValueBase-55]
-	_ = x[MemoryKindCadenceStructValueSize-56]
-	_ = x[MemoryKindCadenceResourceValueBase-57]
-	_ = x[MemoryKindCadenceResourceValueSize-58]
-	_ = x[MemoryKindCadenceEventValueBase-59]
-	_ = x[MemoryKindCadenceEventValueSize-60]
-	_ = x[MemoryKindCadenceContractValueBase-61]
-	_ = x[MemoryKindCadenceContractValueSize-62]
-	_ = x[MemoryKindCadenceEnumValueBase-63]
-	_ = x[MemoryKindCadenceEnumValueSize-64]
-	_ = x[MemoryKindCadenceLinkValue-65]
-	_ = x[MemoryKindCadencePathValue-66]
-	_ = x[MemoryKindCadenceTypeValue-67]
-	_ = x[MemoryKindCadenceCapabilityValue-68]
-	_ = x[MemoryKindCadenceSimpleType-69]
-	_ = x[MemoryKindCadenceOptionalType-70]
-	_ = x[MemoryKindCadenceVariableSizedArrayType-71]
-	_ = x[MemoryKindCadenceConstantSizedArrayType-72]
-	_ = x[MemoryKindCadenceDictionaryType-73]
-	_ = x[MemoryKindCadenceField-74]
-	_ = x[MemoryKindCadenceParameter-75]
-	_ = x[MemoryKindCadenceStructType-76]
-	_ = x[MemoryKindCadenceResourceType-77]
-	_ = x[MemoryKindCadenceEventType-78]
-	_ = x[MemoryKindCadenceContractType-79]
-	_ = x[MemoryKindCadenceStructInterfaceType-80]
-	_ = x[MemoryKindCadenceResourceInterfaceType-81]
-	_ = x[MemoryKindCadenceContractInterfaceType-82]
-	_ = x[MemoryKindCadenceFunctionType-83]
-	_ = x[MemoryKindCadenceReferenceType-84]
-	_ = x[MemoryKindCadenceRestrictedType-85]
-	_ = x[MemoryKindCadenceCapabilityType-86]
-	_ = x[MemoryKindCadenceEnumType-87]
-	_ = x[MemoryKindRawString-88]
-	_ = x[MemoryKindAddressLocation-89]
-	_ = x[MemoryKindBytes-90]
-	_ = x[MemoryKindVariable-91]
-	_ = x[MemoryKindCompositeTypeInfo-92]
-	_ = x[MemoryKindCompositeField-93]
-	_ = x[MemoryKindInvocation-94]
-	_ = x[MemoryKindStorageMap-95]
-	_ = x[MemoryKindStorageKey-96]
-	_ = x[MemoryKindTypeToken-97]
-	_ = x[MemoryKindErrorToken-98]
-	_ = x[MemoryKindSpaceToken-99]
-	_ = x[MemoryKindProgram-100]
-	_ = x[MemoryKindIdentifier-101]
-	_ = x[MemoryKindArgument-102]
-	_ = x[MemoryKindBlock-103]
-	_ = x[MemoryKindFunctionBlock-104]
-	_ = x[MemoryKindParameter-105]
-	_ = x[MemoryKindParameterList-106]
-	_ = x[MemoryKindTransfer-107]
-	_ = x[MemoryKindMembers-108]
-	_ = x[MemoryKindTypeAnnotation-109]
-	_ = x[MemoryKindDictionaryEntry-110]
-	_ = x[MemoryKindFunctionDeclaration-111]
-	_ = x[MemoryKindCompositeDeclaration-112]
-	_ = x[MemoryKindInterfaceDeclaration-113]
-	_ = x[MemoryKindEnumCaseDeclaration-114]
-	_ = x[MemoryKindFieldDeclaration-115]
-	_ = x[MemoryKindTransactionDeclaration-116]
-	_ = x[MemoryKindImportDeclaration-117]
-	_ = x[MemoryKindVariableDeclaration-118]
-	_ = x[MemoryKindSpecialFunctionDeclaration-119]
-	_ = x[MemoryKindPragmaDeclaration-120]
-	_ = x[MemoryKindAssignmentStatement-121]
-	_ = x[MemoryKindBreakStatement-122]
-	_ = x[MemoryKindContinueStatement-123]
-	_ = x[MemoryKindEmitStatement-124]
-	_ = x[MemoryKindExpressionStatement-125]
-	_ = x[MemoryKindForStatement-126]
-	_ = x[MemoryKindIfStatement-127]
-	_ = x[MemoryKindReturnStatement-128]
-	_ = x[MemoryKindSwapStatement-129]
-	_ = x[MemoryKindSwitchStatement-130]
-	_ = x[MemoryKindWhileStatement-131]
-	_ = x[MemoryKindBooleanExpression-132]
-	_ = x[MemoryKindNilExpression-133]
-	_ = x[MemoryKindStringExpression-134]
-	_ = x[MemoryKindIntegerExpression-135]
-	_ = x[MemoryKindFixedPointExpression-136]
-	_ = x[MemoryKindArrayExpression-137]
-	_ = x[MemoryKindDictionaryExpression-138]
-	_ = x[MemoryKindIdentifierExpression-139]
-	_ = x[MemoryKindInvocationExpression-140]
-	_ = x[MemoryKindMemberExpression-141]
-	_ = x[MemoryKindIndexExpression-142]
-	_ = x[MemoryKindConditionalExpression-143]
-	_ = x[MemoryKindUnaryExpression-144]
-	_ = x[MemoryKindBinaryExpression-145]
-	_ = x[MemoryKindFunctionExpression-146]
-	_ = x[MemoryKindCastingExpression-147]
-	_ = x[MemoryKindCreateExpression-148]
-	_ = x[MemoryKindDestroyExpression-149]
-	_ = x[MemoryKindReferenceExpression-150]
-	_ = x[MemoryKindForceExpression-151]
-	_ = x[MemoryKindPathExpression-152]
-	_ = x[MemoryKindConstantSizedType-153]
-	_ = x[MemoryKindDictionaryType-154]
-	_ = x[MemoryKindFunctionType-155]
-	_ = x[MemoryKindInstantiationType-156]
-	_ = x[MemoryKindNominalType-157]
-	_ = x[MemoryKindOptionalType-158]
-	_ = x[MemoryKindReferenceType-159]
-	_ = x[MemoryKindRestrictedType-160]
-	_ = x[MemoryKindVariableSizedType-161]
-	_ = x[MemoryKindPosition-162]
-	_ = x[MemoryKindRange-163]
-	_ = x[MemoryKindElaboration-164]
-	_ = x[MemoryKindActivation-165]
-	_ = x[MemoryKindActivationEntries-166]
-	_ = x[MemoryKindVariableSizedSemaType-167]
-	_ = x[MemoryKindConstantSizedSemaType-168]
-	_ = x[MemoryKindDictionarySemaType-169]
-	_ = x[MemoryKindOptionalSemaType-170]
-	_ = x[MemoryKindRestrictedSemaType-171]
-	_ = x[MemoryKindReferenceSemaType-172]
-	_ = x[MemoryKindCapabilitySemaType-173]
-	_ = x[MemoryKindOrderedMap-174]
-	_ = x[MemoryKindOrderedMapEntryList-175]
-	_ = x[MemoryKindOrderedMapEntry-176]
-	_ = x[MemoryKindLast-177]
+	_ = x[MemoryKindStorageCapabilityController-24]
+	_ = x[MemoryKindAccountCapabilityController-25]
+	_ = x[MemoryKindAtreeArrayDataSlab-26]
+	_ = x[MemoryKindAtreeArrayMetaDataSlab-27]
+	_ = x[MemoryKindAtreeArrayElementOverhead-28]
+	_ = x[MemoryKindAtreeMapDataSlab-29]
+	_ = x[MemoryKindAtreeMapMetaDataSlab-30]
+	_ = x[MemoryKindAtreeMapElementOverhead-31]
+	_ = x[MemoryKindAtreeMapPreAllocatedElement-32]
+	_ = x[MemoryKindAtreeEncodedSlab-33]
+	_ = x[MemoryKindPrimitiveStaticType-34]
+	_ = x[MemoryKindCompositeStaticType-35]
+	_ = x[MemoryKindInterfaceStaticType-36]
+	_ = x[MemoryKindVariableSizedStaticType-37]
+	_ = x[MemoryKindConstantSizedStaticType-38]
+	_ = x[MemoryKindDictionaryStaticType-39]
+	_ = x[MemoryKindOptionalStaticType-40]
+	_ = x[MemoryKindRestrictedStaticType-41]
+	_ = x[MemoryKindReferenceStaticType-42]
+	_ = x[MemoryKindCapabilityStaticType-43]
+	_ = x[MemoryKindFunctionStaticType-44]
+	_ = x[MemoryKindCadenceVoidValue-45]
+	_ = x[MemoryKindCadenceOptionalValue-46]
+	_ = x[MemoryKindCadenceBoolValue-47]
+	_ = x[MemoryKindCadenceStringValue-48]
+	_ = x[MemoryKindCadenceCharacterValue-49]
+	_ = x[MemoryKindCadenceAddressValue-50]
+	_ = x[MemoryKindCadenceIntValue-51]
+	_ = x[MemoryKindCadenceNumberValue-52]
+	_ = x[MemoryKindCadenceArrayValueBase-53]
+	_ = x[MemoryKindCadenceArrayValueLength-54]
+	_ = x[MemoryKindCadenceDictionaryValue-55]
+	_ = x[MemoryKindCadenceKeyValuePair-56]
+	_ = x[MemoryKindCadenceStructValueBase-57]
+	_ = x[MemoryKindCadenceStructValueSize-58]
+	_ = x[MemoryKindCadenceResourceValueBase-59]
+	_ = x[MemoryKindCadenceResourceValueSize-60]
+	_ = x[MemoryKindCadenceEventValueBase-61]
+	_ = x[MemoryKindCadenceEventValueSize-62]
+	_ = x[MemoryKindCadenceContractValueBase-63]
+	_ = x[MemoryKindCadenceContractValueSize-64]
+	_ = x[MemoryKindCadenceEnumValueBase-65]
+	_ = x[MemoryKindCadenceEnumValueSize-66]
+	_ = x[MemoryKindCadenceLinkValue-67]
+	_ = x[MemoryKindCadencePathValue-68]
+	_ = x[MemoryKindCadenceTypeValue-69]
+	_ = x[MemoryKindCadenceCapabilityValue-70]
+	_ = x[MemoryKindCadenceSimpleType-71]
+	_ = x[MemoryKindCadenceOptionalType-72]
+	_ = x[MemoryKindCadenceVariableSizedArrayType-73]
+	_ = x[MemoryKindCadenceConstantSizedArrayType-74]
+	_ = x[MemoryKindCadenceDictionaryType-75]
+	_ = x[MemoryKindCadenceField-76]
+	_ = x[MemoryKindCadenceParameter-77]
+	_ = x[MemoryKindCadenceStructType-78]
+	_ = x[MemoryKindCadenceResourceType-79]
+	_ = x[MemoryKindCadenceEventType-80]
+	_ = x[MemoryKindCadenceContractType-81]
+	_ = x[MemoryKindCadenceStructInterfaceType-82]
+	_ = x[MemoryKindCadenceResourceInterfaceType-83]
+	_ = x[MemoryKindCadenceContractInterfaceType-84]
+	_ = x[MemoryKindCadenceFunctionType-85]
+	_ = x[MemoryKindCadenceReferenceType-86]
+	_ = x[MemoryKindCadenceRestrictedType-87]
+	_ = x[MemoryKindCadenceCapabilityType-88]
+	_ = x[MemoryKindCadenceEnumType-89]
+	_ = x[MemoryKindRawString-90]
+	_ = x[MemoryKindAddressLocation-91]
+	_ = x[MemoryKindBytes-92]
+	_ = x[MemoryKindVariable-93]
+	_ = x[MemoryKindCompositeTypeInfo-94]
+	_ = x[MemoryKindCompositeField-95]
+	_ = x[MemoryKindInvocation-96]
+	_ = x[MemoryKindStorageMap-97]
+	_ = x[MemoryKindStorageKey-98]
+	_ = x[MemoryKindTypeToken-99]
+	_ = x[MemoryKindErrorToken-100]
+	_ = x[MemoryKindSpaceToken-101]
+	_ = x[MemoryKindProgram-102]
+	_ = x[MemoryKindIdentifier-103]
+	_ = x[MemoryKindArgument-104]
+	_ = x[MemoryKindBlock-105]
+	_ = x[MemoryKindFunctionBlock-106]
+	_ = x[MemoryKindParameter-107]
+	_ = x[MemoryKindParameterList-108]
+	_ = x[MemoryKindTransfer-109]
+	_ = x[MemoryKindMembers-110]
+	_ = x[MemoryKindTypeAnnotation-111]
+	_ = x[MemoryKindDictionaryEntry-112]
+	_ = x[MemoryKindFunctionDeclaration-113]
+	_ = x[MemoryKindCompositeDeclaration-114]
+	_ = x[MemoryKindInterfaceDeclaration-115]
+	_ = x[MemoryKindEnumCaseDeclaration-116]
+	_ = x[MemoryKindFieldDeclaration-117]
+	_ = x[MemoryKindTransactionDeclaration-118]
+	_ = x[MemoryKindImportDeclaration-119]
+	_ = x[MemoryKindVariableDeclaration-120]
+	_ = x[MemoryKindSpecialFunctionDeclaration-121]
+	_ = x[MemoryKindPragmaDeclaration-122]
+	_ = x[MemoryKindAssignmentStatement-123]
+	_ = x[MemoryKindBreakStatement-124]
+	_ = x[MemoryKindContinueStatement-125]
+	_ = x[MemoryKindEmitStatement-126]
+	_ = x[MemoryKindExpressionStatement-127]
+	_ = x[MemoryKindForStatement-128]
+	_ = x[MemoryKindIfStatement-129]
+	_ = x[MemoryKindReturnStatement-130]
+	_ = x[MemoryKindSwapStatement-131]
+	_ = x[MemoryKindSwitchStatement-132]
+	_ = x[MemoryKindWhileStatement-133]
+	_ = x[MemoryKindBooleanExpression-134]
+	_ = x[MemoryKindNilExpression-135]
+	_ = x[MemoryKindStringExpression-136]
+	_ = x[MemoryKindIntegerExpression-137]
+	_ = x[MemoryKindFixedPointExpression-138]
+	_ = x[MemoryKindArrayExpression-139]
+	_ = x[MemoryKindDictionaryExpression-140]
+	_ = x[MemoryKindIdentifierExpression-141]
+	_ = x[MemoryKindInvocationExpression-142]
+	_ = x[MemoryKindMemberExpression-143]
+	_ = x[MemoryKindIndexExpression-144]
+	_ = x[MemoryKindConditionalExpression-145]
+	_ = x[MemoryKindUnaryExpression-146]
+	_ = x[MemoryKindBinaryExpression-147]
+	_ = x[MemoryKindFunctionExpression-148]
+	_ = x[MemoryKindCastingExpression-149]
+	_ = x[MemoryKindCreateExpression-150]
+	_ = x[MemoryKindDestroyExpression-151]
+	_ = x[MemoryKindReferenceExpression-152]
+	_ = x[MemoryKindForceExpression-153]
+	_ = x[MemoryKindPathExpression-154]
+	_ = x[MemoryKindConstantSizedType-155]
+	_ = x[MemoryKindDictionaryType-156]
+	_ = x[MemoryKindFunctionType-157]
+	_ = x[MemoryKindInstantiationType-158]
+	_ = x[MemoryKindNominalType-159]
+	_ = x[MemoryKindOptionalType-160]
+	_ = x[MemoryKindReferenceType-161]
+	_ = x[MemoryKindRestrictedType-162]
+	_ = x[MemoryKindVariableSizedType-163]
+	_ = x[MemoryKindPosition-164]
+	_ = x[MemoryKindRange-165]
+	_ = x[MemoryKindElaboration-166]
+	_ = x[MemoryKindActivation-167]
+	_ = x[MemoryKindActivationEntries-168]
+	_ = x[MemoryKindVariableSizedSemaType-169]
+	_ = x[MemoryKindConstantSizedSemaType-170]
+	_ = x[MemoryKindDictionarySemaType-171]
+	_ = x[MemoryKindOptionalSemaType-172]
+	_ = x[MemoryKindRestrictedSemaType-173]
+	_ = x[MemoryKindReferenceSemaType-174]
+	_ = x[MemoryKindCapabilitySemaType-175]
+	_ = x[MemoryKindOrderedMap-176]
+	_ = x[MemoryKindOrderedMapEntryList-177]
+	_ = x[MemoryKindOrderedMapEntry-178]
+	_ = x[MemoryKindLast-179]
 }
 
-const _MemoryKind_name = "UnknownBoolValueAddressValueStringValueCharacterValueNumberValueArrayValueBaseDictionaryValueBaseCompositeValueBaseSimpleCompositeValueBaseOptionalValueNilValueVoidValueTypeValuePathValueCapabilityValueLinkValueStorageReferenceValueEphemeralReferenceValueInterpretedFunctionValueHostFunctionValueBoundFunctionValueBigIntSimpleCompositeValueAtreeArrayDataSlabAtreeArrayMetaDataSlabAtreeArrayElementOverheadAtreeMapDataSlabAtreeMapMetaDataSlabAtreeMapElementOverheadAtreeMapPreAllocatedElementAtreeEncodedSlabPrimitiveStaticTypeCompositeStaticTypeInterfaceStaticTypeVariableSizedStaticTypeConstantSizedStaticTypeDictionaryStaticTypeOptionalStaticTypeRestrictedStaticTypeReferenceStaticTypeCapabilityStaticTypeFunctionStaticTypeCadenceVoidValueCadenceOptionalValueCadenceBoolValueCadenceStringValueCadenceCharacterValueCadenceAddressValueCadenceIntValueCadenceNumberValueCadenceArrayValueBaseCadenceArrayValueLengthCadenceDictionaryValueCadenceKeyValuePairCadenceStructValueBaseCadenceStructValueSizeCadenceResourceValueBaseCadenceResourceValueSizeCadenceEventValueBaseCadenceEventValueSizeCadenceContractValueBaseCadenceContractValueSizeCadenceEnumValueBaseCadenceEnumValueSizeCadenceLinkValueCadencePathValueCadenceTypeValueCadenceCapabilityValueCadenceSimpleTypeCadenceOptionalTypeCadenceVariableSizedArrayTypeCadenceConstantSizedArrayTypeCadenceDictionaryTypeCadenceFieldCadenceParameterCadenceStructTypeCadenceResourceTypeCadenceEventTypeCadenceContractTypeCadenceStructInterfaceTypeCadenceResourceInterfaceTypeCadenceContractInterfaceTypeCadenceFunctionTypeCadenceReferenceTypeCadenceRestrictedTypeCadenceCapabilityTypeCadenceEnumTypeRawStringAddressLocationBytesVariableCompositeTypeInfoCompositeFieldInvocationStorageMapStorageKeyTypeTokenErrorTokenSpaceTokenProgramIdentifierArgumentBlockFunctionBlockParameterParameterListTransferMembersTypeAnnotationDictionaryEntryFunctionDeclarationCompositeDeclarationInterfaceDeclarationEnumCaseDeclarationFieldDeclarationTransactionDeclarationImportDeclarationVariableDeclarationSpecialFunctionDeclarationPragmaDeclarationAssignmentStatementBreakStatementContinueStatementEmitStatementExpressionStatementForStatementIfStatementReturnStatementSwapStatementSwitchStatementWhileStatementBooleanExpressionNilExpressionStringExpressionIntegerExpressionFixedPointExpressionArrayExpressionDictionaryExpressionIdentifierExpressionInvocationExpressionMemberExpressionIndexExpressionConditionalExpressionUnaryExpressionBinaryExpressionFunctionExpressionCastingExpressionCreateExpressionDestroyExpressionReferenceExpressionForceExpressionPathExpressionConstantSizedTypeDictionaryTypeFunctionTypeInstantiationTypeNominalTypeOptionalTypeReferenceTypeRestrictedTypeVariableSizedTypePositionRangeElaborationActivationActivationEntriesVariableSizedSemaTypeConstantSizedSemaTypeDictionarySemaTypeOptionalSemaTypeRestrictedSemaTypeReferenceSemaTypeCapabilitySemaTypeOrderedMapOrderedMapEntryListOrderedMapEntryLast"
+const _MemoryKind_name = "UnknownBoolValueAddressValueStringValueCharacterValueNumberValueArrayValueBaseDictionaryValueBaseCompositeValueBaseSimpleCompositeValueBaseOptionalValueNilValueVoidValueTypeValuePathValueCapabilityValueLinkValueStorageReferenceValueEphemeralReferenceValueInterpretedFunctionValueHostFunctionValueBoundFunctionValueBigIntSimpleCompositeValueStorageCapabilityControllerAccountCapabilityControllerAtreeArrayDataSlabAtreeArrayMetaDataSlabAtreeArrayElementOverheadAtreeMapDataSlabAtreeMapMetaDataSlabAtreeMapElementOverheadAtreeMapPreAllocatedElementAtreeEncodedSlabPrimitiveStaticTypeCompositeStaticTypeInterfaceStaticTypeVariableSizedStaticTypeConstantSizedStaticTypeDictionaryStaticTypeOptionalStaticTypeRestrictedStaticTypeReferenceStaticTypeCapabilityStaticTypeFunctionStaticTypeCadenceVoidValueCadenceOptionalValueCadenceBoolValueCadenceStringValueCadenceCharacterValueCadenceAddressValueCadenceIntValueCadenceNumberValueCadenceArrayValueBaseCadenceArrayValueLengthCadenceDictionaryValueCadenceKeyValuePairCadenceStructValueBaseCadenceStructValueSizeCadenceResourceValueBaseCadenceResourceValueSizeCadenceEventValueBaseCadenceEventValueSizeCadenceContractValueBaseCadenceContractValueSizeCadenceEnumValueBaseCadenceEnumValueSizeCadenceLinkValueCadencePathValueCadenceTypeValueCadenceCapabilityValueCadenceSimpleTypeCadenceOptionalTypeCadenceVariableSizedArrayTypeCadenceConstantSizedArrayTypeCadenceDictionaryTypeCadenceFieldCadenceParameterCadenceStructTypeCadenceResourceTypeCadenceEventTypeCadenceContractTypeCadenceStructInterfaceTypeCadenceResourceInterfaceTypeCadenceContractInterfaceTypeCadenceFunctionTypeCadenceReferenceTypeCadenceRestrictedTypeCadenceCapabilityTypeCadenceEnumTypeRawStringAddressLocationBytesVariableCompositeTypeInfoCompositeFieldInvocationStorageMapStorageKeyTypeTokenErrorTokenSpaceTokenProgramIdentifierArgumentBlockFunctionBlockParameterParameterListTransferMembersTypeAnnotationDictionaryEntryFunctionDeclarationCompositeDeclarationInterfaceDeclarationEnumCaseDeclarationFieldDeclarationTransactionDeclarationImportDeclarationVariableDeclarationSpecialFunctionDeclarationPragmaDeclarationAssignmentStatementBreakStatementContinueStatementEmitStatementExpressionStatementForStatementIfStatementReturnStatementSwapStatementSwitchStatementWhileStatementBooleanExpressionNilExpressionStringExpressionIntegerExpressionFixedPointExpressionArrayExpressionDictionaryExpressionIdentifierExpressionInvocationExpressionMemberExpressionIndexExpressionConditionalExpressionUnaryExpressionBinaryExpressionFunctionExpressionCastingExpressionCreateExpressionDestroyExpressionReferenceExpressionForceExpressionPathExpressionConstantSizedTypeDictionaryTypeFunctionTypeInstantiationTypeNominalTypeOptionalTypeReferenceTypeRestrictedTypeVariableSizedTypePositionRangeElaborationActivationActivationEntriesVariableSizedSemaTypeConstantSizedSemaTypeDictionarySemaTypeOptionalSemaTypeRestrictedSemaTypeReferenceSemaTypeCapabilitySemaTypeOrderedMapOrderedMapEntryListOrderedMapEntryLast"
 
-var _MemoryKind_index = [...]uint16{0, 7, 16, 28, 39, 53, 64, 78, 97, 115, 139, 152, 160, 169, 178, 187, 202, 211, 232, 255, 279, 296, 314, 320, 340, 358, 380, 405, 421, 441, 464, 491, 507, 526, 545, 564, 587, 610, 630, 648, 668, 687, 707, 725, 741, 761, 777, 795, 816, 835, 850, 868, 889, 912, 934, 953, 975, 997, 1021, 1045, 1066, 1087, 1111, 1135, 1155, 1175, 1191, 1207, 1223, 1245, 1262, 1281, 1310, 1339, 1360, 1372, 1388, 1405, 1424, 1440, 1459, 1485, 1513, 1541, 1560, 1580, 1601, 1622, 1637, 1646, 1661, 1666, 1674, 1691, 1705, 1715, 1725, 1735, 1744, 1754, 1764, 1771, 1781, 1789, 1794, 1807, 1816, 1829, 1837, 1844, 1858, 1873, 1892, 1912, 1932, 1951, 1967, 1989, 2006, 2025, 2051, 2068, 2087, 2101, 2118, 2131, 2150, 2162, 2173, 2188, 2201, 2216, 2230, 2247, 2260, 2276, 2293, 2313, 2328, 2348, 2368, 2388, 2404, 2419, 2440, 2455, 2471, 2489, 2506, 2522, 2539, 2558, 2573, 2587, 2604, 2618, 2630, 2647, 2658, 2670, 2683, 2697, 2714, 2722, 2727, 2738, 2748, 2765, 2786, 2807, 2825, 2841, 2859, 2876, 2894, 2904, 2923, 2938, 2942}
+var _MemoryKind_index = [...]uint16{0, 7, 16, 28, 39, 53, 64, 78, 97, 115, 139, 152, 160, 169, 178, 187, 202, 211, 232, 255, 279, 296, 314, 320, 340, 367, 394, 412, 434, 459, 475, 495, 518, 545, 561, 580, 599, 618, 641, 664, 684, 702, 722, 741, 761, 779, 795, 815, 831, 849, 870, 889, 904, 922, 943, 966, 988, 1007, 1029, 1051, 1075, 1099, 1120, 1141, 1165, 1189, 1209, 1229, 1245, 1261, 1277, 1299, 1316, 1335, 1364, 1393, 1414, 1426, 1442, 1459, 1478, 1494, 1513, 1539, 1567, 1595, 1614, 1634, 1655, 1676, 1691, 1700, 1715, 1720, 1728, 1745, 1759, 1769, 1779, 1789, 1798, 1808, 1818, 1825, 1835, 1843, 1848, 1861, 1870, 1883, 1891, 1898, 1912, 1927, 1946, 1966, 1986, 2005, 2021, 2043, 2060, 2079, 2105, 2122, 2141, 2155, 2172, 2185, 2204, 2216, 2227, 2242, 2255, 2270, 2284, 2301, 2314, 2330, 2347, 2367, 2382, 2402, 2422, 2442, 2458, 2473, 2494, 2509, 2525, 2543, 2560, 2576, 2593, 2612, 2627, 2641, 2658, 2672, 2684, 2701, 2712, 2724, 2737, 2751, 2768, 2776, 2781, 2792, 2802, 2819, 2840, 2861, 2879, 2895, 2913, 2930, 2948, 2958, 2977, 2992, 2996}
 
 func (i MemoryKind) String() string {
 	if i >= MemoryKind(len(_MemoryKind_index)-1) {
@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 )
 
@@ -36,4 +37,60 @@ type Config struct {
 	CoverageReportingEnabled bool
 	// StackDepthLimit specifies the maximum depth for call stacks.
 	StackDepthLimit uint64
+	// ImportDepthLimit specifies the maximum depth of nested imports.
+	// If zero, a default limit is used.
+	ImportDepthLimit uint64
+	// AccountKeyEventsDisabled configures if the AccountKeyAdded and AccountKeyRemoved events
+	// are not emitted when keys are added or revoked through the account keys API.
+	AccountKeyEventsDisabled bool
+	// RejectDuplicateAccountKeys configures if adding a key through the account keys API is rejected
+	// if the account already has an unrevoked key with the same public key.
+	// By default, duplicate keys are allowed, for backwards compatibility.
+	// Requires the Interface to implement AccountKeyByPublicKeyProvider.
+	RejectDuplicateAccountKeys bool
+	// AccountEventIndicesEnabled configures if the events emitted by the account functions
+	// are passed to the Interface together with a sequence index, using IndexedEventEmitter.
+	// The index starts at zero for each transaction or script, and increases by one for each event.
+	// Requires the Interface to implement IndexedEventEmitter.
+	AccountEventIndicesEnabled bool
+	// DisableContractUpdateValidation configures if contract updates are NOT validated.
+	// By default, an update is rejected if it is incompatible with the already stored data.
+	// Disabling the validation is unsafe and should only be used deliberately,
+	// e.g. on a fresh network or in tests.
+	DisableContractUpdateValidation bool
+	// MaxContractsPerAccount is the maximum number of contracts an account may contain.
+	// Adding a contract beyond the limit fails with a MaxContractsExceededError.
+	// Zero means unlimited.
+	MaxContractsPerAccount uint64
+	// StorageGrowthLimit is the maximum number of bytes an account may use.
+	// It is checked for each written account whenever the storage is committed,
+	// i.e. at the end of a transaction, and when the storage used or capacity of an account is read.
+	// Exceeding it fails with a StorageGrowthLimitError.
+	// Zero means unlimited.
+	StorageGrowthLimit uint64
+	// ComputationWeights specifies the weight of each kind of computation.
+	// The intensity of metered computation is multiplied by the weight of its kind.
+	// Kinds without a weight are metered with their intensity as-is.
+	ComputationWeights map[common.ComputationKind]uint64
+	// StorageCapacityChangedEventsEnabled configures if the AccountStorageCapacityChanged event
+	// is emitted when committing storage, for each accessed account whose storage capacity changed.
+	StorageCapacityChangedEventsEnabled bool
+	// AddressValidator validates the addresses for which account values are constructed,
+	// and returns an error if an address does not match the address format of the chain.
+	// If nil, all addresses are accepted.
+	AddressValidator func(address common.Address) error
+	// ProgramCacheMetrics, if set, records the program cache hits and misses, and the number of parsed programs.
+	// If nil, no metrics are recorded.
+	ProgramCacheMetrics *ProgramCacheMetrics
+	// HostErrorMapper, if set, maps the errors returned by the host functions called by the account functions,
+	// e.g. to a stdlib.HostUserError, to report an expected failure as a user error with a clean message.
+	// Errors which are not mapped to a user, internal, or external error are reported as external errors.
+	HostErrorMapper func(err error) error
+	// MemoryUsageReporter, if set, is called at the end of each execution
+	// with the total metered memory usage of the execution, per memory kind.
+	// If nil, the memory usage is not recorded.
+	MemoryUsageReporter func(usage map[common.MemoryKind]uint64)
+	// PreparedContractPrograms is an optional set of contract programs which were already parsed and checked.
+	// Contract deployments use a prepared program instead of parsing the deployed code again.
+	PreparedContractPrograms *PreparedContractPrograms
 }
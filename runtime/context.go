@@ -27,6 +27,9 @@ type Context struct {
 	Location       Location
 	Environment    Environment
 	CoverageReport *CoverageReport
+	// StorageIndexAllocator is an optional allocator for storage indices.
+	// If nil, the storage indices are allocated by the Interface.
+	StorageIndexAllocator StorageIndexAllocator
 }
 
 type codesAndPrograms struct {
@@ -109,7 +109,7 @@ func (executor *interpreterContractFunctionExecutor) preprocess() (err error) {
 
 	runtimeInterface := context.Interface
 
-	storage := NewStorage(runtimeInterface, runtimeInterface)
+	storage := NewStorage(context.storageLedger(), runtimeInterface)
 	executor.storage = storage
 
 	environment := context.Environment
@@ -139,6 +139,8 @@ func (executor *interpreterContractFunctionExecutor) execute() (val cadence.Valu
 	codesAndPrograms := executor.codesAndPrograms
 	interpreterRuntime := executor.runtime
 
+	defer environment.ReportMemoryUsage()
+
 	defer interpreterRuntime.Recover(
 		func(internalErr Error) {
 			err = internalErr
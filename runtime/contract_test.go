@@ -22,9 +22,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/stdlib"
@@ -33,6 +35,8 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func TestRuntimeContract(t *testing.T) {
@@ -806,3 +810,2172 @@ func TestRuntimeImportMultipleContracts(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRuntimeContractAddInferred(t *testing.T) {
+
+	t.Parallel()
+
+	addTx := func(code string) []byte {
+		return []byte(
+			fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let deployed = signer.contracts.addInferred(code: "%s".decodeHex())
+                          log(deployed.name)
+                      }
+                   }
+                `,
+				hex.EncodeToString([]byte(code)),
+			),
+		)
+	}
+
+	newRuntimeInterface := func(
+		accountCodes map[common.Location][]byte,
+		loggedMessages *[]string,
+	) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location] = code
+				return nil
+			},
+			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				code = accountCodes[location]
+				return code, nil
+			},
+			log: func(message string) {
+				*loggedMessages = append(*loggedMessages, message)
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("single contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		const contract = `
+          pub contract Test {
+
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `
+
+		accountCodes := map[common.Location][]byte{}
+		var loggedMessages []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := newRuntimeInterface(accountCodes, &loggedMessages)
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: addTx(contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		location := common.AddressLocation{
+			Address: common.MustBytesToAddress([]byte{0x1}),
+			Name:    "Test",
+		}
+		assert.Equal(t, []byte(contract), accountCodes[location])
+		assert.Equal(t, []string{`"Test"`}, loggedMessages)
+	})
+
+	t.Run("multiple contracts", func(t *testing.T) {
+
+		t.Parallel()
+
+		const contract = `
+          pub contract A {}
+
+          pub contract B {}
+        `
+
+		accountCodes := map[common.Location][]byte{}
+		var loggedMessages []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := newRuntimeInterface(accountCodes, &loggedMessages)
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: addTx(contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot infer contract name")
+		assert.Empty(t, accountCodes)
+	})
+}
+
+func TestRuntimeContractDeploymentResult(t *testing.T) {
+
+	t.Parallel()
+
+	const oldContract = `
+      pub contract Test {}
+    `
+
+	const newContract = `
+      pub contract Test {
+          pub fun test(): Int {
+              return 1
+          }
+      }
+    `
+
+	changeTx := func(function string, code string) []byte {
+		return []byte(
+			fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let result = signer.contracts.%s(name: "Test", code: "%s".decodeHex())
+                          log(result.contract.name)
+                          log(result.wasUpdate)
+                          log(result.oldCodeHash == nil ? "nil" : String.encodeHex(result.oldCodeHash!))
+                          log(String.encodeHex(result.codeHash))
+                      }
+                   }
+                `,
+				function,
+				hex.EncodeToString([]byte(code)),
+			),
+		)
+	}
+
+	codeHash := func(code string) string {
+		hash := sha3.Sum256([]byte(code))
+		return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:]))
+	}
+
+	accountCodes := map[common.Location][]byte{}
+	var loggedMessages []string
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			code = accountCodes[location]
+			return code, nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Add
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: changeTx("addWithResult", oldContract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{
+			`"Test"`,
+			`false`,
+			`"nil"`,
+			codeHash(oldContract),
+		},
+		loggedMessages,
+	)
+
+	// Update
+
+	loggedMessages = nil
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: changeTx("updateWithResult", newContract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{
+			`"Test"`,
+			`true`,
+			codeHash(oldContract),
+			codeHash(newContract),
+		},
+		loggedMessages,
+	)
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Test",
+	}
+	assert.Equal(t, []byte(newContract), accountCodes[location])
+}
+
+func TestRuntimeContractAddWithInit(t *testing.T) {
+
+	t.Parallel()
+
+	const contract = `
+      pub contract Test {
+          pub let answer: Int
+
+          init(answer: Int) {
+              self.answer = answer
+          }
+      }
+    `
+
+	addTx := func(postInit string) []byte {
+		return []byte(
+			fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.contracts.addWithInit(
+                              name: "Test",
+                              code: "%s".decodeHex(),
+                              postInit: fun (_ contract: &AnyStruct) {
+                                  %s
+                              },
+                              answer: 42
+                          )
+                      }
+                   }
+                `,
+				hex.EncodeToString([]byte(contract)),
+				postInit,
+			),
+		)
+	}
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Test",
+	}
+
+	newRuntimeInterface := func(
+		accountCodes map[common.Location][]byte,
+		loggedMessages *[]string,
+	) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location] = code
+				return nil
+			},
+			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				code = accountCodes[location]
+				return code, nil
+			},
+			log: func(message string) {
+				*loggedMessages = append(*loggedMessages, message)
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("successful check", func(t *testing.T) {
+
+		t.Parallel()
+
+		accountCodes := map[common.Location][]byte{}
+		var loggedMessages []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := newRuntimeInterface(accountCodes, &loggedMessages)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: addTx(`log(contract.getType().identifier)`),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{`"A.0000000000000001.Test"`},
+			loggedMessages,
+		)
+		assert.Equal(t, []byte(contract), accountCodes[location])
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+
+		t.Parallel()
+
+		accountCodes := map[common.Location][]byte{}
+		var loggedMessages []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := newRuntimeInterface(accountCodes, &loggedMessages)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: addTx(`panic("post-init check failed")`),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "post-init check failed")
+
+		// No code was deployed
+
+		assert.NotContains(t, accountCodes, common.Location(location))
+	})
+
+	t.Run("contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		accountCodes := map[common.Location][]byte{}
+		var loggedMessages []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := newRuntimeInterface(accountCodes, &loggedMessages)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.contracts.addWithInit(
+                                  name: "Test",
+                                  code: "%s".decodeHex(),
+                                  postInit: fun (_ contract: &AnyStruct) {}
+                              )
+                          }
+                       }
+                    `,
+					hex.EncodeToString([]byte(`pub contract interface Test {}`)),
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "only contracts can be added with a post-init function")
+
+		assert.Empty(t, accountCodes)
+	})
+}
+
+func TestRuntimeContractRemovalResult(t *testing.T) {
+
+	t.Parallel()
+
+	const contract = `
+      pub contract Test {}
+    `
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	accountCodes := map[common.Location][]byte{
+		location: []byte(contract),
+	}
+
+	var loggedMessages []string
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			code = accountCodes[location]
+			return code, nil
+		},
+		removeAccountContractCode: func(address Address, name string) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			delete(accountCodes, location)
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	removeTx := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let result = signer.contracts.removeWithResult(name: "Test")
+              if let result = result {
+                  log(result.contract.name)
+                  log(String.encodeHex(result.contract.code))
+                  log(String.encodeHex(result.codeHash))
+              } else {
+                  log("nil")
+              }
+          }
+      }
+    `)
+
+	// Remove existing contract
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: removeTx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	codeHash := sha3.Sum256([]byte(contract))
+
+	assert.Equal(t,
+		[]string{
+			`"Test"`,
+			fmt.Sprintf(`"%s"`, hex.EncodeToString([]byte(contract))),
+			fmt.Sprintf(`"%s"`, hex.EncodeToString(codeHash[:])),
+		},
+		loggedMessages,
+	)
+
+	assert.NotContains(t, accountCodes, location)
+
+	// Remove non-existing contract
+
+	loggedMessages = nil
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: removeTx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`"nil"`}, loggedMessages)
+}
+
+func TestRuntimeContractEnumTypes(t *testing.T) {
+
+	t.Parallel()
+
+	const fooContract = `
+      pub contract Foo {
+
+          pub enum Color: UInt8 {
+              pub case red
+              pub case green
+          }
+
+          pub enum Size: UInt8 {
+              pub case small
+              pub case large
+          }
+
+          pub struct Bar {}
+      }
+    `
+
+	const barContract = `
+      pub contract Bar {}
+    `
+
+	signerAddress := common.MustBytesToAddress([]byte{0x1})
+
+	accountCodes := map[common.Location][]byte{}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAddress}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location], nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	for name, code := range map[string]string{
+		"Foo": fooContract,
+		"Bar": barContract,
+	} {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction(name, []byte(code)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              import Foo from 0x1
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      let fooEnumTypes = signer.contracts.enumTypes(name: "Foo")
+                      assert(fooEnumTypes.length == 2)
+                      assert(fooEnumTypes[0] == Type<Foo.Color>())
+                      assert(fooEnumTypes[1] == Type<Foo.Size>())
+
+                      assert(signer.contracts.enumTypes(name: "Bar").length == 0)
+                      assert(signer.contracts.enumTypes(name: "Baz").length == 0)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+}
+
+type testContractExistenceRuntimeInterface struct {
+	*testRuntimeInterface
+	accountContractExists func(address Address, name string) (bool, error)
+}
+
+var _ AccountContractExistenceProvider = &testContractExistenceRuntimeInterface{}
+
+func (i *testContractExistenceRuntimeInterface) AccountContractExists(address Address, name string) (bool, error) {
+	return i.accountContractExists(address, name)
+}
+
+func TestRuntimeContractsGetExistenceCheck(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	script := []byte(`
+      pub fun main(): [Bool] {
+          let contracts = getAccount(0x1).contracts
+          return [
+              contracts.get(name: "Foo") != nil,
+              contracts.get(name: "Bar") != nil
+          ]
+      }
+    `)
+
+	var codeRequests []string
+
+	runtimeInterface := &testContractExistenceRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				codeRequests = append(codeRequests, name)
+				if name == "Foo" {
+					return []byte("pub contract Foo {}"), nil
+				}
+				return nil, nil
+			},
+		},
+		accountContractExists: func(a Address, name string) (bool, error) {
+			assert.Equal(t, address, a)
+			return name == "Foo", nil
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewBool(true),
+			cadence.NewBool(false),
+		}).WithType(cadence.VariableSizedArrayType{
+			ElementType: cadence.BoolType{},
+		}),
+		result,
+	)
+
+	// The code of the non-existing contract is not requested,
+	// and the code of the existing contract is not accessed
+
+	assert.Empty(t, codeRequests)
+}
+
+func TestRuntimeContractsGetLazyCode(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "pub contract Foo {}"
+
+	script := []byte(`
+      pub fun main(): [UInt8] {
+          let contract = getAccount(0x1).contracts.get(name: "Foo")!
+          log(contract.name)
+          log(contract.address)
+          log("code")
+          return contract.code
+      }
+    `)
+
+	var trace []string
+
+	runtimeInterface := &testContractExistenceRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				trace = append(trace, "get "+name)
+				return []byte(code), nil
+			},
+			log: func(message string) {
+				trace = append(trace, message)
+			},
+		},
+		accountContractExists: func(_ Address, _ string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		newBytesValue([]byte(code)),
+		result,
+	)
+
+	// The code is only fetched when the code field is accessed
+
+	assert.Equal(t,
+		[]string{
+			`"Foo"`,
+			`0x0000000000000001`,
+			`"code"`,
+			"get Foo",
+		},
+		trace,
+	)
+}
+
+func TestRuntimeContractsGetCodeAfterChange(t *testing.T) {
+
+	t.Parallel()
+
+	const oldContract = "pub contract Foo {}"
+
+	const newContract = `
+      pub contract Foo {
+          pub fun test(): Int {
+              return 1
+          }
+      }
+    `
+
+	removeTx := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let contract = signer.contracts.get(name: "Foo")!
+              signer.contracts.remove(name: "Foo")
+              log(String.fromUTF8(contract.code)!)
+          }
+      }
+    `)
+
+	updateTx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let contract = signer.contracts.get(name: "Foo")!
+                  signer.contracts.update__experimental(name: "Foo", code: "%s".decodeHex())
+                  log(String.fromUTF8(contract.code)!)
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(newContract)),
+	))
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	newRuntimeInterface := func() (*testRuntimeInterface, *[]string) {
+		accountCodes := map[string][]byte{
+			"Foo": []byte(oldContract),
+		}
+
+		var logs []string
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			updateAccountContractCode: func(_ Address, name string, code []byte) error {
+				accountCodes[name] = code
+				return nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				return accountCodes[name], nil
+			},
+			removeAccountContractCode: func(_ Address, name string) error {
+				delete(accountCodes, name)
+				return nil
+			},
+			log: func(message string) {
+				logs = append(logs, message)
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+
+		return runtimeInterface, &logs
+	}
+
+	for name, tx := range map[string][]byte{
+		"remove": removeTx,
+		"update": updateTx,
+	} {
+		tx := tx
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			runtimeInterface, logs := newRuntimeInterface()
+
+			runtime := newTestInterpreterRuntime()
+
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.TransactionLocation{},
+				},
+			)
+			require.NoError(t, err)
+
+			// The deployed contract has the code at the time it was retrieved
+
+			assert.Equal(t, []string{`"pub contract Foo {}"`}, *logs)
+		})
+
+		t.Run(name+", existence check", func(t *testing.T) {
+
+			t.Parallel()
+
+			runtimeInterface, logs := newRuntimeInterface()
+
+			runtime := newTestInterpreterRuntime()
+
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: &testContractExistenceRuntimeInterface{
+						testRuntimeInterface: runtimeInterface,
+						accountContractExists: func(_ Address, _ string) (bool, error) {
+							return true, nil
+						},
+					},
+					Location: common.TransactionLocation{},
+				},
+			)
+			require.Error(t, err)
+
+			// The code is fetched lazily, so the code at the time
+			// the deployed contract was retrieved is not available anymore
+
+			require.ErrorContains(t, err, "the contract was updated or removed after it was retrieved")
+			assert.Empty(t, *logs)
+		})
+	}
+}
+
+func TestRuntimeContractCodeCache(t *testing.T) {
+
+	t.Parallel()
+
+	const newContract = `
+      pub contract Foo {
+          pub fun test(): Int {
+              return 1
+          }
+      }
+    `
+
+	tx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.get(name: "Foo")
+                  signer.contracts.get(name: "Foo")
+                  log("update")
+                  signer.contracts.update__experimental(name: "Foo", code: "%s".decodeHex())
+                  log("updated")
+                  signer.contracts.get(name: "Foo")
+                  signer.contracts.get(name: "Foo")
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(newContract)),
+	))
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Foo",
+	}
+
+	accountCodes := map[common.Location][]byte{
+		location: []byte("pub contract Foo {}"),
+	}
+
+	var trace []string
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{location.Address}, nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			trace = append(trace, "get "+name)
+			code = accountCodes[location]
+			return code, nil
+		},
+		log: func(message string) {
+			trace = append(trace, message)
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: tx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	// The host is only asked for the code once before the update,
+	// and once again after the update invalidated the cached code
+
+	assert.Equal(t,
+		[]string{
+			"get Foo",
+			`"update"`,
+			`"updated"`,
+			"get Foo",
+		},
+		trace,
+	)
+}
+
+func TestRuntimeContractNameValidation(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, name string) error {
+
+		tx := []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.contracts.add(name: %q, code: "%s".decodeHex())
+                  }
+              }
+            `,
+			name,
+			hex.EncodeToString([]byte("pub contract Foo {}")),
+		))
+
+		var updatedCodes []string
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+				return nil, nil
+			},
+			updateAccountContractCode: func(_ Address, name string, _ []byte) error {
+				updatedCodes = append(updatedCodes, name)
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		if err == nil {
+			assert.Equal(t, []string{name}, updatedCodes)
+		} else {
+			assert.Empty(t, updatedCodes)
+		}
+		return err
+	}
+
+	t.Run("valid name", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, "Foo")
+		require.NoError(t, err)
+	})
+
+	t.Run("name with space", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, "Foo Bar")
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid contract name argument `Foo Bar`")
+	})
+
+	t.Run("name starting with digit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, "1Foo")
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid contract name argument `1Foo`")
+	})
+}
+
+func TestRuntimeContractDeploymentUnresolvedImport(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const barContract = `
+      pub contract Bar {}
+    `
+
+	const fooContract = `
+      import Bar from 0x1
+      import Baz from 0x1
+
+      pub contract Foo {}
+    `
+
+	tx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "Foo", code: "%s".decodeHex())
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(fooContract)),
+	))
+
+	var updatedCodes []string
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+			if name == "Bar" {
+				return []byte(barContract), nil
+			}
+			return nil, nil
+		},
+		updateAccountContractCode: func(_ Address, name string, _ []byte) error {
+			updatedCodes = append(updatedCodes, name)
+			return nil
+		},
+		emitEvent: func(_ cadence.Event) error {
+			return nil
+		},
+	}
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: tx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.Error(t, err)
+
+	assert.Empty(t, updatedCodes)
+
+	var deploymentErr *stdlib.InvalidContractDeploymentError
+	require.ErrorAs(t, err, &deploymentErr)
+
+	var checkerErr *sema.CheckerError
+	require.ErrorAs(t, err, &checkerErr)
+
+	// Only the import of the contract which is not deployed is reported
+
+	errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+	var unresolvedImportErr *sema.UnresolvedImportError
+	require.ErrorAs(t, errs[0], &unresolvedImportErr)
+
+	assert.Equal(t,
+		common.AddressLocation{
+			Address: address,
+			Name:    "Baz",
+		},
+		unresolvedImportErr.ImportLocation,
+	)
+}
+
+func TestRuntimeContractDeploymentCircularImport(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const barContract = `
+      import Foo from 0x1
+
+      pub contract Bar {}
+    `
+
+	const fooContract = `
+      import Bar from 0x1
+
+      pub contract Foo {}
+    `
+
+	tx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "Foo", code: "%s".decodeHex())
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(fooContract)),
+	))
+
+	var updatedCodes []string
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+			if name == "Bar" {
+				return []byte(barContract), nil
+			}
+			return nil, nil
+		},
+		updateAccountContractCode: func(_ Address, name string, _ []byte) error {
+			updatedCodes = append(updatedCodes, name)
+			return nil
+		},
+		emitEvent: func(_ cadence.Event) error {
+			return nil
+		},
+	}
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: tx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.Error(t, err)
+
+	assert.Empty(t, updatedCodes)
+
+	// Foo
+
+	var checkerErr *sema.CheckerError
+	require.ErrorAs(t, err, &checkerErr)
+
+	errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+	var importedProgramErr *sema.ImportedProgramError
+	require.ErrorAs(t, errs[0], &importedProgramErr)
+
+	// Bar
+
+	var checkerErr2 *sema.CheckerError
+	require.ErrorAs(t, importedProgramErr.Err, &checkerErr2)
+
+	errs = checker.ExpectCheckerErrors(t, checkerErr2, 1)
+
+	// The import of Foo closes the cycle
+
+	var importedProgramErr2 *sema.ImportedProgramError
+	require.ErrorAs(t, errs[0], &importedProgramErr2)
+
+	var circularImportErr *CircularImportError
+	require.ErrorAs(t, importedProgramErr2.Err, &circularImportErr)
+
+	fooLocation := common.AddressLocation{
+		Address: address,
+		Name:    "Foo",
+	}
+	barLocation := common.AddressLocation{
+		Address: address,
+		Name:    "Bar",
+	}
+
+	assert.Equal(t,
+		[]common.Location{
+			fooLocation,
+			barLocation,
+			fooLocation,
+		},
+		circularImportErr.Cycle,
+	)
+
+	require.ErrorContains(t, err, "circular import: 0000000000000001.Foo -> 0000000000000001.Bar -> 0000000000000001.Foo")
+}
+
+func TestRuntimeContractCodeUTF8Validation(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code []byte) error {
+
+		tx := []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.contracts.add(name: "Foo", code: "%s".decodeHex())
+                  }
+              }
+            `,
+			hex.EncodeToString(code),
+		))
+
+		runtime := newTestInterpreterRuntime()
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+				return nil, nil
+			},
+			updateAccountContractCode: func(_ Address, _ string, _ []byte) error {
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+		}
+
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, []byte(`pub contract Foo { pub let s: String; init() { self.s = "ä€😀" } }`))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid byte sequence", func(t *testing.T) {
+
+		t.Parallel()
+
+		code := []byte("pub contract Foo {}")
+		code = append(code, 0xff, 0xfe)
+
+		err := test(t, code)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid UTF-8 byte sequence at offset 19")
+	})
+}
+
+func BenchmarkRuntimeContractsGetNonExistent(b *testing.B) {
+
+	script := Script{
+		Source: []byte(`
+          pub fun main() {
+              let contracts = getAccount(0x1).contracts
+              var i = 0
+              while i < 100 {
+                  contracts.get(name: "Foo")
+                  i = i + 1
+              }
+          }
+        `),
+	}
+
+	runtimeInterface := &testContractExistenceRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+				return nil, nil
+			},
+		},
+		accountContractExists: func(_ Address, _ string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	environment := NewScriptInterpreterEnvironment(Config{})
+
+	context := Context{
+		Interface:   runtimeInterface,
+		Location:    common.ScriptLocation{},
+		Environment: environment,
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := runtime.ExecuteScript(script, context)
+		require.NoError(b, err)
+	}
+}
+
+func TestRuntimeContractsGetContractType(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	codes := map[string][]byte{
+		"Foo": []byte(`pub contract Foo {}`),
+		"Bar": []byte(`pub contract interface Bar {}`),
+	}
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+			return codes[name], nil
+		},
+	}
+
+	script := []byte(`
+      pub fun main(): [String?] {
+          let publicContracts = getAccount(0x1).contracts
+          let authContracts = getAuthAccount(0x1).contracts
+          return [
+              publicContracts.getContractType(name: "Foo")?.identifier,
+              publicContracts.getContractType(name: "Bar")?.identifier,
+              publicContracts.getContractType(name: "Baz")?.identifier,
+              authContracts.getContractType(name: "Foo")?.identifier,
+              authContracts.getContractType(name: "Bar")?.identifier,
+              authContracts.getContractType(name: "Baz")?.identifier
+          ]
+      }
+    `)
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	fooTypeID := string(common.NewAddressLocation(nil, address, "Foo").TypeID(nil, "Foo"))
+	barTypeID := string(common.NewAddressLocation(nil, address, "Bar").TypeID(nil, "Bar"))
+
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.NewOptional(cadence.String(fooTypeID)),
+			cadence.NewOptional(cadence.String(barTypeID)),
+			cadence.NewOptional(nil),
+			cadence.NewOptional(cadence.String(fooTypeID)),
+			cadence.NewOptional(cadence.String(barTypeID)),
+			cadence.NewOptional(nil),
+		},
+		result.(cadence.Array).Values,
+	)
+}
+
+type testContractDeploymentObserverRuntimeInterface struct {
+	*testRuntimeInterface
+	onContractDeployed func(address Address, name string, isUpdate bool, code []byte)
+}
+
+var _ ContractDeploymentObserver = testContractDeploymentObserverRuntimeInterface{}
+
+func (i testContractDeploymentObserverRuntimeInterface) OnContractDeployed(
+	address Address,
+	name string,
+	isUpdate bool,
+	code []byte,
+) {
+	i.onContractDeployed(address, name, isUpdate, code)
+}
+
+func TestRuntimeContractDeploymentObserver(t *testing.T) {
+
+	t.Parallel()
+
+	type deployment struct {
+		address  Address
+		name     string
+		isUpdate bool
+		code     string
+	}
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const code = `pub contract Foo {}`
+	const updatedCode = `pub contract Foo { pub fun foo() {} }`
+
+	codes := map[string][]byte{}
+	var deployments []deployment
+	var events []cadence.Event
+
+	runtime := newTestInterpreterRuntime()
+	runtimeInterface := testContractDeploymentObserverRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				return codes[name], nil
+			},
+			updateAccountContractCode: func(_ Address, name string, code []byte) error {
+				codes[name] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+		},
+		onContractDeployed: func(address Address, name string, isUpdate bool, code []byte) {
+			// The hook must only be called after the code was written and the event was emitted
+			require.Equal(t, string(code), string(codes[name]))
+			require.NotEmpty(t, events)
+
+			deployments = append(
+				deployments,
+				deployment{
+					address:  address,
+					name:     name,
+					isUpdate: isUpdate,
+					code:     string(code),
+				},
+			)
+		},
+	}
+
+	executeTransaction := func(function string, code string) error {
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.contracts.%s(name: "Foo", code: "%s".decodeHex())
+                          }
+                      }
+                    `,
+					function,
+					hex.EncodeToString([]byte(code)),
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	err := executeTransaction("add", code)
+	require.NoError(t, err)
+
+	err = executeTransaction("update__experimental", updatedCode)
+	require.NoError(t, err)
+
+	// A failing deployment does not call the hook
+
+	err = executeTransaction("add", code)
+	require.Error(t, err)
+
+	assert.Equal(t,
+		[]deployment{
+			{
+				address:  address,
+				name:     "Foo",
+				isUpdate: false,
+				code:     code,
+			},
+			{
+				address:  address,
+				name:     "Foo",
+				isUpdate: true,
+				code:     updatedCode,
+			},
+		},
+		deployments,
+	)
+}
+
+type testDeploymentFeeRuntimeInterface struct {
+	*testRuntimeInterface
+	getContractDeploymentFee func(address Address, name string, codeSize int) (uint64, error)
+}
+
+var _ DeploymentFeeProvider = testDeploymentFeeRuntimeInterface{}
+
+func (i testDeploymentFeeRuntimeInterface) GetContractDeploymentFee(
+	address Address,
+	name string,
+	codeSize int,
+) (uint64, error) {
+	return i.getContractDeploymentFee(address, name, codeSize)
+}
+
+func TestRuntimeContractDeploymentFee(t *testing.T) {
+
+	t.Parallel()
+
+	const feePerByte = 10
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	newRuntimeInterface := func(events *[]cadence.Event) *testRuntimeInterface {
+		codes := map[string][]byte{}
+
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				return codes[name], nil
+			},
+			updateAccountContractCode: func(_ Address, name string, code []byte) error {
+				codes[name] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				*events = append(*events, event)
+				return nil
+			},
+		}
+	}
+
+	executeTransaction := func(runtimeInterface Interface, name string, code string) error {
+		return newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.contracts.add(name: "%s", code: "%s".decodeHex())
+                          }
+                      }
+                    `,
+					name,
+					hex.EncodeToString([]byte(code)),
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	t.Run("fee", func(t *testing.T) {
+		t.Parallel()
+
+		var events []cadence.Event
+
+		runtimeInterface := testDeploymentFeeRuntimeInterface{
+			testRuntimeInterface: newRuntimeInterface(&events),
+			getContractDeploymentFee: func(_ Address, _ string, codeSize int) (uint64, error) {
+				return uint64(codeSize) * feePerByte, nil
+			},
+		}
+
+		const smallCode = `pub contract Foo {}`
+		const largeCode = `pub contract Bar { pub fun bar(): Int { return 42 } }`
+
+		err := executeTransaction(runtimeInterface, "Foo", smallCode)
+		require.NoError(t, err)
+
+		err = executeTransaction(runtimeInterface, "Bar", largeCode)
+		require.NoError(t, err)
+
+		var feeEvents []cadence.Event
+		for _, event := range events {
+			if event.EventType.ID() == string(stdlib.ContractDeploymentFeeDeductedEventType.ID()) {
+				feeEvents = append(feeEvents, event)
+			}
+		}
+
+		require.Len(t, feeEvents, 2)
+
+		assert.Equal(t,
+			[][]cadence.Value{
+				{
+					cadence.Address(address),
+					cadence.String("Foo"),
+					cadence.UInt64(len(smallCode) * feePerByte),
+				},
+				{
+					cadence.Address(address),
+					cadence.String("Bar"),
+					cadence.UInt64(len(largeCode) * feePerByte),
+				},
+			},
+			[][]cadence.Value{
+				feeEvents[0].Fields,
+				feeEvents[1].Fields,
+			},
+		)
+	})
+
+	t.Run("no fee provider", func(t *testing.T) {
+		t.Parallel()
+
+		var events []cadence.Event
+
+		err := executeTransaction(newRuntimeInterface(&events), "Foo", `pub contract Foo {}`)
+		require.NoError(t, err)
+
+		require.Len(t, events, 1)
+		assert.Equal(t,
+			string(stdlib.AccountContractAddedEventType.ID()),
+			events[0].EventType.ID(),
+		)
+	})
+}
+
+func TestRuntimeContractMaxContractsPerAccount(t *testing.T) {
+
+	t.Parallel()
+
+	executeTransaction := newContractDeploymentTransactorWithConfig(
+		t,
+		Config{
+			AtreeValidationEnabled: true,
+			MaxContractsPerAccount: 2,
+		},
+	)
+
+	newContract := func(name string) string {
+		return fmt.Sprintf(
+			`
+              pub contract %s {}
+            `,
+			name,
+		)
+	}
+
+	// Adding contracts up to the limit succeeds
+
+	err := executeTransaction(newContractAddTransaction("A", newContract("A")))
+	require.NoError(t, err)
+
+	err = executeTransaction(newContractAddTransaction("B", newContract("B")))
+	require.NoError(t, err)
+
+	// Updating a contract is not limited
+
+	err = executeTransaction(newContractUpdateTransaction("B", newContract("B")))
+	require.NoError(t, err)
+
+	// Adding a contract beyond the limit fails
+
+	err = executeTransaction(newContractAddTransaction("C", newContract("C")))
+	require.Error(t, err)
+
+	var maxContractsExceededErr *stdlib.MaxContractsExceededError
+	require.ErrorAs(t, err, &maxContractsExceededErr)
+
+	assert.Equal(t, common.MustBytesToAddress([]byte{0x42}), maxContractsExceededErr.Address)
+	assert.Equal(t, uint64(2), maxContractsExceededErr.Limit)
+	assert.Equal(t, uint64(3), maxContractsExceededErr.Count)
+}
+
+func TestRuntimeContractTopLevelDeclarations(t *testing.T) {
+
+	t.Parallel()
+
+	executeTransaction := newContractDeploymentTransactorWithConfig(
+		t,
+		Config{
+			AtreeValidationEnabled: true,
+		},
+	)
+
+	for _, testCase := range []struct {
+		name            string
+		code            string
+		declarationKind common.DeclarationKind
+	}{
+		{
+			name:            "transaction",
+			code:            `transaction {}`,
+			declarationKind: common.DeclarationKindTransaction,
+		},
+		{
+			name: "contract and transaction",
+			code: `
+              pub contract A {}
+
+              transaction {}
+            `,
+			declarationKind: common.DeclarationKindTransaction,
+		},
+		{
+			name:            "function",
+			code:            `pub fun foo() {}`,
+			declarationKind: common.DeclarationKindFunction,
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			err := executeTransaction(newContractAddTransaction("A", testCase.code))
+			require.Error(t, err)
+
+			var checkerErr *sema.CheckerError
+			require.ErrorAs(t, err, &checkerErr)
+
+			errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+			var declarationErr *sema.InvalidTopLevelDeclarationError
+			require.ErrorAs(t, errs[0], &declarationErr)
+
+			assert.Equal(t, testCase.declarationKind, declarationErr.DeclarationKind)
+			assert.ErrorContains(t,
+				err,
+				fmt.Sprintf(
+					"%s declarations are not valid at the top-level",
+					testCase.declarationKind.Name(),
+				),
+			)
+		})
+	}
+}
+
+func TestRuntimeContractDeploymentPreparedProgram(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x42})
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	const code = `
+      pub contract Test {
+
+          pub event Deployed(x: Int)
+
+          pub let x: Int
+
+          init() {
+              self.x = 42
+              emit Deployed(x: self.x)
+          }
+      }
+    `
+
+	type deployment struct {
+		executeTransaction func(code string, preparedPrograms *PreparedContractPrograms) error
+		accountCodes       map[Location][]byte
+		events             *[]cadence.Event
+		parseCount         *int
+	}
+
+	newDeployment := func() deployment {
+		accountCodes := map[Location][]byte{}
+		var events []cadence.Event
+		var parseCount int
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getCode: func(location Location) ([]byte, error) {
+				return accountCodes[location], nil
+			},
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location], nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+			programParsed: func(parsedLocation Location, _ time.Duration) {
+				if parsedLocation == location {
+					parseCount++
+				}
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		return deployment{
+			executeTransaction: func(code string, preparedPrograms *PreparedContractPrograms) error {
+				rt := NewInterpreterRuntime(Config{
+					AtreeValidationEnabled:   true,
+					PreparedContractPrograms: preparedPrograms,
+				})
+
+				return rt.ExecuteTransaction(
+					Script{
+						Source: []byte(code),
+					},
+					Context{
+						Interface: runtimeInterface,
+						Location:  nextTransactionLocation(),
+					},
+				)
+			},
+			accountCodes: accountCodes,
+			events:       &events,
+			parseCount:   &parseCount,
+		}
+	}
+
+	prepareProgram := func(code string) *PreparedContractPrograms {
+		rt := newTestInterpreterRuntime()
+
+		program, err := rt.ParseAndCheckProgram(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  location,
+			},
+		)
+		require.NoError(t, err)
+
+		preparedPrograms := NewPreparedContractPrograms()
+		preparedPrograms.Add(location, []byte(code), program)
+		return preparedPrograms
+	}
+
+	t.Run("same result as parsing", func(t *testing.T) {
+
+		t.Parallel()
+
+		parsed := newDeployment()
+		err := parsed.executeTransaction(newContractAddTransaction("Test", code), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, *parsed.parseCount)
+
+		prepared := newDeployment()
+		err = prepared.executeTransaction(
+			newContractAddTransaction("Test", code),
+			prepareProgram(code),
+		)
+		require.NoError(t, err)
+
+		// The prepared program is used, the code is not parsed again
+		assert.Equal(t, 0, *prepared.parseCount)
+
+		assert.Equal(t, parsed.accountCodes, prepared.accountCodes)
+		assert.Equal(t, *parsed.events, *prepared.events)
+	})
+
+	t.Run("different code", func(t *testing.T) {
+
+		t.Parallel()
+
+		preparedPrograms := prepareProgram(`pub contract Test {}`)
+
+		deployment := newDeployment()
+		err := deployment.executeTransaction(
+			newContractAddTransaction("Test", code),
+			preparedPrograms,
+		)
+		require.NoError(t, err)
+
+		// The prepared program was prepared for other code, so the code is parsed
+		assert.Equal(t, 1, *deployment.parseCount)
+
+		require.Len(t, *deployment.events, 2)
+		assert.Equal(t,
+			string(location.TypeID(nil, "Test.Deployed")),
+			(*deployment.events)[0].EventType.ID(),
+		)
+	})
+
+	t.Run("update is validated", func(t *testing.T) {
+
+		t.Parallel()
+
+		deployment := newDeployment()
+		err := deployment.executeTransaction(newContractAddTransaction("Test", code), nil)
+		require.NoError(t, err)
+
+		const newCode = `
+          pub contract Test {
+
+              pub let x: String
+
+              init() {
+                  self.x = "42"
+              }
+          }
+        `
+
+		err = deployment.executeTransaction(
+			newContractUpdateTransaction("Test", newCode),
+			prepareProgram(newCode),
+		)
+		require.Error(t, err)
+
+		var updateErr *stdlib.ContractUpdateError
+		require.ErrorAs(t, err, &updateErr)
+	})
+}
+
+func TestRuntimeContractDeploymentPreparedProgramImports(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	fooLocation := common.AddressLocation{
+		Address: address,
+		Name:    "Foo",
+	}
+
+	const fooContract = `
+      import Bar from 0x1
+
+      pub contract Foo {}
+    `
+
+	const circularBarContract = `
+      import Foo from 0x1
+
+      pub contract Bar {}
+    `
+
+	// deploy deploys Foo, with the given code of Bar,
+	// and returns the error of the deployment
+	deploy := func(barContract string, preparedPrograms *PreparedContractPrograms) error {
+		rt := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled:   true,
+			PreparedContractPrograms: preparedPrograms,
+		})
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				if name == "Bar" {
+					return []byte(barContract), nil
+				}
+				return nil, nil
+			},
+			updateAccountContractCode: func(_ Address, _ string, _ []byte) error {
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+		}
+
+		return rt.ExecuteTransaction(
+			Script{
+				Source: []byte(newContractAddTransaction("Foo", fooContract)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	// The program of Foo is prepared while Bar is deployed and does not import Foo
+	prepareProgram := func() *PreparedContractPrograms {
+		rt := newTestInterpreterRuntime()
+
+		program, err := rt.ParseAndCheckProgram(
+			[]byte(fooContract),
+			Context{
+				Interface: &testRuntimeInterface{
+					resolveLocation: singleIdentifierLocationResolver(t),
+					getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+						return []byte(`pub contract Bar {}`), nil
+					},
+				},
+				Location: fooLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		preparedPrograms := NewPreparedContractPrograms()
+		preparedPrograms.Add(fooLocation, []byte(fooContract), program)
+		return preparedPrograms
+	}
+
+	t.Run("undeployed import", func(t *testing.T) {
+
+		t.Parallel()
+
+		parsedErr := deploy("", nil)
+		require.Error(t, parsedErr)
+
+		preparedErr := deploy("", prepareProgram())
+		require.Error(t, preparedErr)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, preparedErr, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		var unresolvedImportErr *sema.UnresolvedImportError
+		require.ErrorAs(t, errs[0], &unresolvedImportErr)
+
+		assert.Equal(t, parsedErr.Error(), preparedErr.Error())
+	})
+
+	t.Run("circular import", func(t *testing.T) {
+
+		t.Parallel()
+
+		parsedErr := deploy(circularBarContract, nil)
+		require.Error(t, parsedErr)
+
+		preparedErr := deploy(circularBarContract, prepareProgram())
+		require.Error(t, preparedErr)
+
+		// Foo
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, preparedErr, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		var importedProgramErr *sema.ImportedProgramError
+		require.ErrorAs(t, errs[0], &importedProgramErr)
+
+		// Bar
+
+		var checkerErr2 *sema.CheckerError
+		require.ErrorAs(t, importedProgramErr.Err, &checkerErr2)
+
+		errs = checker.ExpectCheckerErrors(t, checkerErr2, 1)
+
+		// The import of Foo closes the cycle
+
+		var importedProgramErr2 *sema.ImportedProgramError
+		require.ErrorAs(t, errs[0], &importedProgramErr2)
+
+		var circularImportErr *CircularImportError
+		require.ErrorAs(t, importedProgramErr2.Err, &circularImportErr)
+
+		assert.Equal(t, parsedErr.Error(), preparedErr.Error())
+	})
+}
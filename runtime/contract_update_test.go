@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence"
@@ -218,3 +219,315 @@ func TestContractUpdateWithDependencies(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestContractUpdateWithMigration(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+	accountCodes := map[common.Location][]byte{}
+	signerAccount := common.MustBytesToAddress([]byte{0x1})
+	fooLocation := common.AddressLocation{
+		Address: signerAccount,
+		Name:    "Foo",
+	}
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location], nil
+		},
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAccount}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	const fooContractV1 = `
+        pub contract Foo {
+            pub var answer: Int
+
+            init() {
+                self.answer = 42
+            }
+
+            pub fun setAnswer(_ answer: Int) {
+                self.answer = answer
+            }
+        }
+    `
+
+	const fooContractV2 = `
+        pub contract Foo {
+            pub var answer: Int
+
+            init() {
+                self.answer = 42
+            }
+
+            pub fun setAnswer(_ answer: Int) {
+                self.answer = answer
+            }
+
+            pub fun doubleAnswer(): Int {
+                return self.answer * 2
+            }
+        }
+    `
+
+	// Deploy 'Foo' contract
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction(
+				"Foo",
+				[]byte(fooContractV1),
+			),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	updateWithMigration := func(migration string) error {
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      import Foo from 0x01
+
+                      transaction {
+                          prepare(signer: AuthAccount) {
+                              signer.contracts.updateWithMigration(
+                                  name: "Foo",
+                                  code: "%s".decodeHex(),
+                                  migrate: fun (_ contract: auth &AnyStruct) {
+                                      %s
+                                  }
+                              )
+                          }
+                      }
+                    `,
+					hex.EncodeToString([]byte(fooContractV2)),
+					migration,
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+	}
+
+	getAnswer := func() cadence.Value {
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  import Foo from 0x01
+
+                  pub fun main(): Int {
+                      return Foo.answer
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("failing migration", func(t *testing.T) {
+
+		err := updateWithMigration(`
+            (contract as! &Foo).setAnswer(0)
+            panic("migration failed")
+        `)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "migration failed")
+
+		// Neither the code nor the stored value were changed
+
+		assert.Equal(t, []byte(fooContractV1), accountCodes[fooLocation])
+		assert.Equal(t, cadence.NewInt(42), getAnswer())
+	})
+
+	t.Run("successful migration", func(t *testing.T) {
+
+		err := updateWithMigration(`
+            (contract as! &Foo).setAnswer(43)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte(fooContractV2), accountCodes[fooLocation])
+		assert.Equal(t, cadence.NewInt(43), getAnswer())
+	})
+}
+
+func TestContractUpdateKeepsStoredValue(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+	accountCodes := map[common.Location][]byte{}
+	signerAccount := common.MustBytesToAddress([]byte{0x1})
+	fooLocation := common.AddressLocation{
+		Address: signerAccount,
+		Name:    "Foo",
+	}
+
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location], nil
+		},
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAccount}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	const fooContractV1 = `
+        pub contract Foo {
+            pub var answer: Int
+
+            init() {
+                self.answer = 42
+                log("init")
+            }
+
+            pub fun setAnswer(_ answer: Int) {
+                self.answer = answer
+            }
+        }
+    `
+
+	const fooContractV2 = `
+        pub contract Foo {
+            pub var answer: Int
+
+            init() {
+                self.answer = 0
+                log("init")
+            }
+
+            pub fun setAnswer(_ answer: Int) {
+                self.answer = answer
+            }
+
+            pub fun doubleAnswer(): Int {
+                return self.answer * 2
+            }
+        }
+    `
+
+	executeTransaction := func(tx []byte) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	getAnswer := func() cadence.Value {
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  import Foo from 0x01
+
+                  pub fun main(): Int {
+                      return Foo.answer
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Adding the contract runs the initializer
+
+	executeTransaction(utils.DeploymentTransaction("Foo", []byte(fooContractV1)))
+
+	assert.Equal(t, []string{`"init"`}, loggedMessages)
+	assert.Equal(t, cadence.NewInt(42), getAnswer())
+
+	executeTransaction([]byte(`
+      import Foo from 0x01
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              Foo.setAnswer(43)
+          }
+      }
+    `))
+
+	assert.Equal(t, cadence.NewInt(43), getAnswer())
+
+	// Updating the contract only swaps the code:
+	// the initializer is not run again and the stored value is left untouched
+
+	executeTransaction(utils.UpdateTransaction("Foo", []byte(fooContractV2)))
+
+	assert.Equal(t, []byte(fooContractV2), accountCodes[fooLocation])
+	assert.Equal(t, []string{`"init"`}, loggedMessages)
+	assert.Equal(t, cadence.NewInt(43), getAnswer())
+}
@@ -79,7 +79,16 @@ func newContractRemovalTransaction(contractName string) string {
 }
 
 func newContractDeploymentTransactor(t *testing.T) func(code string) error {
-	rt := newTestInterpreterRuntime()
+	return newContractDeploymentTransactorWithConfig(
+		t,
+		Config{
+			AtreeValidationEnabled: true,
+		},
+	)
+}
+
+func newContractDeploymentTransactorWithConfig(t *testing.T, config Config) func(code string) error {
+	rt := NewInterpreterRuntime(config)
 
 	accountCodes := map[Location][]byte{}
 	var events []cadence.Event
@@ -115,6 +124,15 @@ func newContractDeploymentTransactor(t *testing.T) func(code string) error {
 			delete(accountCodes, location)
 			return nil
 		},
+		getAccountContractNames: func(address Address) (names []string, err error) {
+			for location := range accountCodes {
+				addressLocation, ok := location.(common.AddressLocation)
+				if ok && addressLocation.Address == address {
+					names = append(names, addressLocation.Name)
+				}
+			}
+			return names, nil
+		},
 		emitEvent: func(event cadence.Event) error {
 			events = append(events, event)
 			return nil
@@ -2461,3 +2479,60 @@ func TestRuntimeContractUpdateProgramCaching(t *testing.T) {
 		)
 	})
 }
+
+func TestRuntimeContractUpdateValidationDisabled(t *testing.T) {
+
+	t.Parallel()
+
+	const oldCode = `
+        pub contract Test {
+            pub var a: String
+            init() {
+                self.a = "hello"
+            }
+        }
+    `
+
+	const newCode = `
+        pub contract Test {
+            pub var a: Int
+            init() {
+                self.a = 0
+            }
+        }
+    `
+
+	test := func(t *testing.T, validationDisabled bool) error {
+		executeTransaction := newContractDeploymentTransactorWithConfig(
+			t,
+			Config{
+				AtreeValidationEnabled:          true,
+				DisableContractUpdateValidation: validationDisabled,
+			},
+		)
+
+		err := executeTransaction(newContractAddTransaction("Test", oldCode))
+		require.NoError(t, err)
+
+		return executeTransaction(newContractUpdateTransaction("Test", newCode))
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, false)
+		require.Error(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertFieldTypeMismatchError(t, cause, "Test", "a", "String", "Int")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := test(t, true)
+		require.NoError(t, err)
+	})
+}
@@ -161,6 +161,10 @@ func ExportType(
 			return cadence.NewMeteredPublicAccountContractsType(gauge)
 		case sema.AuthAccountContractsType:
 			return cadence.NewMeteredAuthAccountContractsType(gauge)
+		case sema.AuthAccountCapabilitiesType:
+			return cadence.NewMeteredAuthAccountCapabilitiesType(gauge)
+		case sema.AuthAccountInboxType:
+			return cadence.NewMeteredAuthAccountInboxType(gauge)
 		case sema.PublicAccountKeysType:
 			return cadence.NewMeteredPublicAccountKeysType(gauge)
 		case sema.AuthAccountKeysType:
@@ -313,6 +317,10 @@ func ExportMeteredType(
 			return cadence.NewMeteredPublicAccountContractsType(gauge)
 		case sema.AuthAccountContractsType:
 			return cadence.NewMeteredAuthAccountContractsType(gauge)
+		case sema.AuthAccountCapabilitiesType:
+			return cadence.NewMeteredAuthAccountCapabilitiesType(gauge)
+		case sema.AuthAccountInboxType:
+			return cadence.NewMeteredAuthAccountInboxType(gauge)
 		case sema.PublicAccountKeysType:
 			return cadence.NewMeteredPublicAccountKeysType(gauge)
 		case sema.AuthAccountKeysType:
@@ -775,6 +783,10 @@ func ImportType(memoryGauge common.MemoryGauge, t cadence.Type) interpreter.Stat
 		return interpreter.NewPrimitiveStaticType(memoryGauge, interpreter.PrimitiveStaticTypeAccountKey)
 	case cadence.AuthAccountContractsType:
 		return interpreter.NewPrimitiveStaticType(memoryGauge, interpreter.PrimitiveStaticTypeAuthAccountContracts)
+	case cadence.AuthAccountCapabilitiesType:
+		return interpreter.NewPrimitiveStaticType(memoryGauge, interpreter.PrimitiveStaticTypeAuthAccountCapabilities)
+	case cadence.AuthAccountInboxType:
+		return interpreter.NewPrimitiveStaticType(memoryGauge, interpreter.PrimitiveStaticTypeAuthAccountInbox)
 	case cadence.AuthAccountKeysType:
 		return interpreter.NewPrimitiveStaticType(memoryGauge, interpreter.PrimitiveStaticTypeAuthAccountKeys)
 	case cadence.AuthAccountType:
@@ -399,6 +399,7 @@ func TestExportValue(t *testing.T) {
 							PublicKey: []byte{1, 2, 3},
 							SignAlgo:  2,
 						},
+						sema.HashAlgorithmUnknown,
 						func(
 							_ *interpreter.Interpreter,
 							_ func() interpreter.LocationRange,
@@ -407,9 +408,10 @@ func TestExportValue(t *testing.T) {
 							return nil
 						},
 					),
-					stdlib.NewHashAlgorithmCase(1),
+					stdlib.NewHashAlgorithmCase(nil, 1),
 					interpreter.NewUnmeteredUFix64ValueWithInteger(10),
 					false,
+					true,
 				)
 			},
 			expected: cadence.Struct{
@@ -436,6 +438,10 @@ func TestExportValue(t *testing.T) {
 							Identifier: "isRevoked",
 							Type:       cadence.BoolType{},
 						},
+						{
+							Identifier: "isActive",
+							Type:       cadence.BoolType{},
+						},
 					},
 				},
 				Fields: []cadence.Value{
@@ -466,6 +472,7 @@ func TestExportValue(t *testing.T) {
 					},
 					cadence.UFix64(10_00000000),
 					cadence.Bool(false),
+					cadence.Bool(true),
 				},
 			},
 		},
@@ -1075,6 +1082,16 @@ func TestImportRuntimeType(t *testing.T) {
 			actual:   cadence.AuthAccountContractsType{},
 			expected: interpreter.PrimitiveStaticTypeAuthAccountContracts,
 		},
+		{
+			label:    "AuthAccount.Capabilities",
+			actual:   cadence.AuthAccountCapabilitiesType{},
+			expected: interpreter.PrimitiveStaticTypeAuthAccountCapabilities,
+		},
+		{
+			label:    "AuthAccount.Inbox",
+			actual:   cadence.AuthAccountInboxType{},
+			expected: interpreter.PrimitiveStaticTypeAuthAccountInbox,
+		},
 		{
 			label:    "PublicAccount.Contracts",
 			actual:   cadence.PublicAccountContractsType{},
@@ -19,9 +19,16 @@
 package runtime
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -117,6 +124,221 @@ func TestRuntimeCrypto_verify(t *testing.T) {
 	assert.True(t, called)
 }
 
+func TestRuntimeVerifySignatures(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      pub fun main(): UFix64 {
+          let publicKeys = [
+              PublicKey(
+                  publicKey: "01".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              ),
+              PublicKey(
+                  publicKey: "02".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              ),
+              PublicKey(
+                  publicKey: "03".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+          ]
+
+          return verifySignatures(
+              ["f1".decodeHex(), "00".decodeHex(), "f3".decodeHex()],
+              publicKeys: publicKeys,
+              weights: [0.5, 0.25, 0.125],
+              signedData: "0506".decodeHex(),
+              domainSeparationTag: "FLOW-V0.0-user",
+              hashAlgorithm: HashAlgorithm.SHA3_256
+          )
+      }
+    `)
+
+	var verified int
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		verifySignature: func(
+			signature []byte,
+			tag string,
+			signedData []byte,
+			publicKey []byte,
+			signatureAlgorithm SignatureAlgorithm,
+			hashAlgorithm HashAlgorithm,
+		) (bool, error) {
+			verified++
+			assert.Equal(t, "FLOW-V0.0-user", tag)
+			assert.Equal(t, []byte{5, 6}, signedData)
+			assert.Equal(t, SignatureAlgorithmECDSA_P256, signatureAlgorithm)
+			assert.Equal(t, HashAlgorithmSHA3_256, hashAlgorithm)
+			// A signature is valid if it "signs" its public key
+			return signature[0] == 0xf0|publicKey[0], nil
+		},
+	}
+	addPublicKeyValidation(runtimeInterface, nil)
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.UFix64(62_500_000),
+		result,
+	)
+
+	assert.Equal(t, 3, verified)
+}
+
+func TestRuntimeDomainTags(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signedData := []byte{5, 6}
+
+	// The test signature is the tag followed by the signed data
+	sign := func(tag string, data []byte) []byte {
+		return append([]byte(tag), data...)
+	}
+
+	script := []byte(fmt.Sprintf(
+		`
+          pub fun main(): Bool {
+              let publicKey = PublicKey(
+                  publicKey: "0102".decodeHex(),
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+
+              return publicKey.verify(
+                  signature: "%s".decodeHex(),
+                  signedData: "%s".decodeHex(),
+                  domainSeparationTag: DomainTags.user,
+                  hashAlgorithm: HashAlgorithm.SHA3_256
+              )
+          }
+        `,
+		hex.EncodeToString(sign(stdlib.UserDomainSeparationTag, signedData)),
+		hex.EncodeToString(signedData),
+	))
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		verifySignature: func(
+			signature []byte,
+			tag string,
+			signedData []byte,
+			_ []byte,
+			_ SignatureAlgorithm,
+			_ HashAlgorithm,
+		) (bool, error) {
+			return string(signature) == string(sign(tag, signedData)), nil
+		},
+	}
+	addPublicKeyValidation(runtimeInterface, nil)
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewBool(true), result)
+}
+
+func TestRuntimePublicKeyFromPEM(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(t *testing.T, pemKey string) (cadence.Value, error) {
+		script := []byte(fmt.Sprintf(
+			`
+              pub fun main(): [UInt8]? {
+                  let publicKey = PublicKey.fromPEM(
+                      String.fromUTF8("%s".decodeHex())!,
+                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                  )
+                  return publicKey?.publicKey
+              }
+            `,
+			hex.EncodeToString([]byte(pemKey)),
+		))
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+		addPublicKeyValidation(runtimeInterface, nil)
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		require.NoError(t, err)
+
+		pemKey := pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: der,
+		})
+
+		result, err := executeScript(t, string(pemKey))
+		require.NoError(t, err)
+
+		expected := make([]byte, 64)
+		privateKey.X.FillBytes(expected[:32])
+		privateKey.Y.FillBytes(expected[32:])
+
+		assert.Equal(t,
+			cadence.NewOptional(
+				newBytesValue(expected),
+			),
+			result,
+		)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(t, "-----BEGIN PUBLIC KEY-----\nnot a key\n-----END PUBLIC KEY-----\n")
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewOptional(nil), result)
+	})
+}
+
 func TestRuntimeHashAlgorithm_hash(t *testing.T) {
 
 	t.Parallel()
@@ -238,6 +460,160 @@ func TestRuntimeHashAlgorithm_hash(t *testing.T) {
 	})
 }
 
+func TestRuntimeHashAlgorithm_hashWithSalt(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(code string, inter Interface) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: inter,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	const saltA = "000102030405060708090a0b0c0d0e0f"
+	const saltB = "0f0e0d0c0b0a09080706050403020100"
+
+	newRuntimeInterface := func(hashedData *[][]byte) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			hash: func(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+				assert.Empty(t, tag)
+				assert.Equal(t, HashAlgorithmSHA3_256, hashAlgorithm)
+
+				*hashedData = append(*hashedData, data)
+
+				digest := sha256.Sum256(data)
+				return digest[:], nil
+			},
+		}
+	}
+
+	t.Run("salts", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := fmt.Sprintf(
+			`
+              pub fun main(): [String] {
+                  let data = "01020304".decodeHex()
+                  return [
+                      String.encodeHex(HashAlgorithm.SHA3_256.hashWithSalt(data, salt: "%[1]s".decodeHex())),
+                      String.encodeHex(HashAlgorithm.SHA3_256.hashWithSalt(data, salt: "%[1]s".decodeHex())),
+                      String.encodeHex(HashAlgorithm.SHA3_256.hashWithSalt(data, salt: "%[2]s".decodeHex()))
+                  ]
+              }
+            `,
+			saltA,
+			saltB,
+		)
+
+		var hashedData [][]byte
+
+		value, err := executeScript(script, newRuntimeInterface(&hashedData))
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Array{}, value)
+		digests := value.(cadence.Array).Values
+		require.Len(t, digests, 3)
+
+		// The same salt is reproducible, different salts produce different digests
+
+		assert.Equal(t, digests[0], digests[1])
+		assert.NotEqual(t, digests[0], digests[2])
+
+		// The hashed message is the salt, followed by the data
+
+		data := []byte{1, 2, 3, 4}
+
+		saltedDataA, err := hex.DecodeString(saltA)
+		require.NoError(t, err)
+		saltedDataA = append(saltedDataA, data...)
+
+		saltedDataB, err := hex.DecodeString(saltB)
+		require.NoError(t, err)
+		saltedDataB = append(saltedDataB, data...)
+
+		assert.Equal(t,
+			[][]byte{
+				saltedDataA,
+				saltedDataA,
+				saltedDataB,
+			},
+			hashedData,
+		)
+	})
+
+	t.Run("computation", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := fmt.Sprintf(
+			`
+              pub fun main() {
+                  HashAlgorithm.SHA3_256.hashWithSalt("01020304".decodeHex(), salt: "%s".decodeHex())
+              }
+            `,
+			saltA,
+		)
+
+		var hashedData [][]byte
+		var intensities []uint
+
+		runtimeInterface := newRuntimeInterface(&hashedData)
+		runtimeInterface.meterComputation = func(kind common.ComputationKind, intensity uint) error {
+			if kind == common.ComputationKindSTDLIBHashWithSalt {
+				intensities = append(intensities, intensity)
+			}
+			return nil
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		// 20 = 16 salt bytes and 4 data bytes
+		assert.Equal(t, []uint{20}, intensities)
+	})
+
+	for _, salt := range []string{
+		// too short
+		"000102030405060708090a0b0c0d0e",
+		// too long
+		strings.Repeat("00", 65),
+	} {
+		salt := salt
+
+		t.Run(fmt.Sprintf("invalid salt length %d", len(salt)/2), func(t *testing.T) {
+
+			t.Parallel()
+
+			script := fmt.Sprintf(
+				`
+                  pub fun main() {
+                      HashAlgorithm.SHA3_256.hashWithSalt("01020304".decodeHex(), salt: "%s".decodeHex())
+                  }
+                `,
+				salt,
+			)
+
+			var hashedData [][]byte
+
+			_, err := executeScript(script, newRuntimeInterface(&hashedData))
+			require.Error(t, err)
+
+			assert.ErrorContains(t, err, "invalid byte array length")
+			assert.Empty(t, hashedData)
+		})
+	}
+}
+
 func TestRuntimeHashingAlgorithmExport(t *testing.T) {
 
 	t.Parallel()
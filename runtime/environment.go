@@ -19,12 +19,15 @@
 package runtime
 
 import (
+	"math"
+	"sync"
 	"time"
 
 	"github.com/onflow/cadence/runtime/activations"
 
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -60,10 +63,18 @@ type Environment interface {
 		error,
 	)
 	CommitStorage(inter *interpreter.Interpreter) error
+	// FlushStorage commits the pending storage writes, except contract updates,
+	// so the host environment can calculate the storage used by accounts.
+	// Until there are new writes, reading the storage used or the storage capacity of an account
+	// does not commit the storage again.
+	FlushStorage(inter *interpreter.Interpreter, getLocationRange func() interpreter.LocationRange) error
+	ReportMemoryUsage()
 	NewAuthAccountValue(address interpreter.AddressValue) interpreter.Value
 	NewPublicAccountValue(address interpreter.AddressValue) interpreter.Value
 }
 
+const defaultImportDepthLimit = 64
+
 type interpreterEnvironment struct {
 	config Config
 
@@ -74,14 +85,76 @@ type interpreterEnvironment struct {
 	CheckerConfig     *sema.Config
 
 	deployedContractConstructorInvocation *stdlib.DeployedContractConstructorInvocation
+	initializedContractProgram            *initializedContractProgram
 	stackDepthLimiter                     *stackDepthLimiter
 	checkedImports                        importResolutionResults
+	importDepthLimit                      uint64
+
+	// importChain is the chain of imports currently being resolved, in order.
+	// While a contract deployment is checked, it starts with the deployed contract
+	importChain []common.Location
+
+	// scriptEnvironment is set if the environment executes scripts.
+	// Script results are not persisted, so temporary commits can be skipped
+	// if there are no pending writes
+	scriptEnvironment bool
+
+	// checkingContractDeployment is set while the code of a contract deployment is checked.
+	// Imports of contracts which are not deployed are then reported as unresolved imports
+	checkingContractDeployment bool
+
+	// storageFlushed is set when the storage was committed during the execution,
+	// explicitly (see FlushStorage) or temporarily (see CommitStorageTemporarily).
+	// Further temporary commits are then only needed if the storage has new writes
+	storageFlushed bool
+
+	// hostCallLock serializes the calls of the account value getters into the host environment,
+	// see stdlib.HostCallLocker
+	hostCallLock sync.Mutex
 
 	// the following fields are re-configurable, see Configure
 	runtimeInterface Interface
 	storage          *Storage
 	coverageReport   *CoverageReport
 	codesAndPrograms codesAndPrograms
+
+	// contractCodeCache caches the account contract code fetched during one execution.
+	// Entries are invalidated when the contract is updated or removed
+	contractCodeCache map[common.AddressLocation][]byte
+
+	// contractChangeCounts counts how often each account contract was updated or removed during one execution
+	contractChangeCounts map[common.AddressLocation]uint64
+
+	// storageCapacities are the storage capacities of the accessed accounts
+	// before they were accessed, in order of access.
+	// Only recorded if storage capacity changed events are enabled
+	storageCapacities []accountStorageCapacity
+
+	// memoryUsage is the metered memory usage per memory kind.
+	// Only recorded if a memory usage reporter is configured
+	memoryUsage map[common.MemoryKind]uint64
+
+	// accountEventIndex is the sequence index of the next event emitted by the account functions.
+	// Only used if account event indices are enabled
+	accountEventIndex uint64
+
+	// accessObserver is the runtime interface, if it observes account accesses
+	accessObserver AccessObserver
+
+	// eventMetricsObserver is the runtime interface, if it observes emitted events
+	eventMetricsObserver EventMetricsObserver
+}
+
+// initializedContractProgram is the program of a contract
+// which was initialized, but is not deployed yet
+type initializedContractProgram struct {
+	location common.AddressLocation
+	program  *interpreter.Program
+}
+
+type accountStorageCapacity struct {
+	address  common.Address
+	capacity uint64
 }
 
 var _ Environment = &interpreterEnvironment{}
@@ -93,6 +166,19 @@ var _ stdlib.PublicAccountHandler = &interpreterEnvironment{}
 var _ stdlib.AccountCreator = &interpreterEnvironment{}
 var _ stdlib.EventEmitter = &interpreterEnvironment{}
 var _ stdlib.AuthAccountHandler = &interpreterEnvironment{}
+var _ stdlib.AddressValidator = &interpreterEnvironment{}
+var _ stdlib.ContractDeploymentObserver = &interpreterEnvironment{}
+var _ stdlib.DeploymentFeeProvider = &interpreterEnvironment{}
+var _ stdlib.HostErrorMapper = &interpreterEnvironment{}
+var _ stdlib.MultiTokenBalanceProvider = &interpreterEnvironment{}
+var _ stdlib.DefaultTokenAddressResolver = &interpreterEnvironment{}
+var _ stdlib.InitializedContractProgramProvider = &interpreterEnvironment{}
+var _ stdlib.AccountAccessObserver = &interpreterEnvironment{}
+var _ stdlib.ContractCountLimitProvider = &interpreterEnvironment{}
+var _ stdlib.ContractHistoryProvider = &interpreterEnvironment{}
+var _ stdlib.ComputationBudgetProvider = &interpreterEnvironment{}
+var _ stdlib.HostCallLocker = &interpreterEnvironment{}
+var _ stdlib.AccountContractExistenceProvider = &interpreterEnvironment{}
 var _ common.MemoryGauge = &interpreterEnvironment{}
 
 func newInterpreterEnvironment(config Config) *interpreterEnvironment {
@@ -104,6 +190,10 @@ func newInterpreterEnvironment(config Config) *interpreterEnvironment {
 		baseActivation:      baseActivation,
 		baseValueActivation: baseValueActivation,
 		stackDepthLimiter:   newStackDepthLimiter(config.StackDepthLimit),
+		importDepthLimit:    config.ImportDepthLimit,
+	}
+	if env.importDepthLimit == 0 {
+		env.importDepthLimit = defaultImportDepthLimit
 	}
 	env.InterpreterConfig = env.newInterpreterConfig()
 	env.CheckerConfig = env.newCheckerConfig()
@@ -131,6 +221,7 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		HashHandler:                          e.newHashHandler(),
 		OnRecordTrace:                        e.newOnRecordTraceHandler(),
 		OnResourceOwnerChange:                e.newResourceOwnerChangedHandler(),
+		OnStorageAccess:                      e.newStorageAccessHandler(),
 		TracingEnabled:                       e.config.TracingEnabled,
 		AtreeValueValidationEnabled:          e.config.AtreeValidationEnabled,
 		// NOTE: ignore e.config.AtreeValidationEnabled here,
@@ -164,16 +255,23 @@ func NewBaseInterpreterEnvironment(config Config) *interpreterEnvironment {
 	}
 	env.Declare(stdlib.NewLogFunction(env))
 	env.Declare(stdlib.NewUnsafeRandomFunction(env))
+	env.Declare(stdlib.NewGetRemainingComputationFunction(env))
 	env.Declare(stdlib.NewGetBlockFunction(env))
 	env.Declare(stdlib.NewGetCurrentBlockFunction(env))
 	env.Declare(stdlib.NewGetAccountFunction(env))
 	env.Declare(stdlib.NewAuthAccountConstructor(env))
+	env.Declare(stdlib.NewVerifySignaturesFunction(env))
+	env.Declare(stdlib.NewGetAddressForPublicKeyFunction(env))
+	env.Declare(stdlib.NewGetContractNamesForFunction(env))
 	return env
 }
 
 func NewScriptInterpreterEnvironment(config Config) Environment {
 	env := NewBaseInterpreterEnvironment(config)
+	env.scriptEnvironment = true
 	env.Declare(stdlib.NewGetAuthAccountFunction(env))
+	env.Declare(stdlib.NewGetAuthAccountsFunction(env))
+	env.Declare(stdlib.NewVerifyAccountKeyStateFunction(env))
 	return env
 }
 
@@ -189,6 +287,20 @@ func (e *interpreterEnvironment) Configure(
 	e.InterpreterConfig.Storage = storage
 	e.coverageReport = coverageReport
 	e.stackDepthLimiter.depth = 0
+	e.storageFlushed = false
+	e.contractCodeCache = map[common.AddressLocation][]byte{}
+	e.contractChangeCounts = nil
+	e.storageCapacities = nil
+	if storage != nil && e.config.StorageCapacityChangedEventsEnabled {
+		storage.onAccountAccessed = e.recordStorageCapacity
+	}
+	e.accountEventIndex = 0
+	e.accessObserver, _ = runtimeInterface.(AccessObserver)
+	e.eventMetricsObserver, _ = runtimeInterface.(EventMetricsObserver)
+	e.memoryUsage = nil
+	if e.config.MemoryUsageReporter != nil {
+		e.memoryUsage = map[common.MemoryKind]uint64{}
+	}
 }
 
 func (e *interpreterEnvironment) Declare(valueDeclaration stdlib.StandardLibraryValue) {
@@ -204,10 +316,27 @@ func (e *interpreterEnvironment) NewPublicAccountValue(address interpreter.Addre
 	return stdlib.NewPublicAccountValue(e, e, address)
 }
 
+func (e *interpreterEnvironment) HostCallLock() sync.Locker {
+	return &e.hostCallLock
+}
+
 func (e *interpreterEnvironment) MeterMemory(usage common.MemoryUsage) error {
+	if e.memoryUsage != nil {
+		e.memoryUsage[usage.Kind] += usage.Amount
+	}
 	return e.runtimeInterface.MeterMemory(usage)
 }
 
+// ReportMemoryUsage reports the memory usage recorded since the environment was configured,
+// if a memory usage reporter is configured
+func (e *interpreterEnvironment) ReportMemoryUsage() {
+	reporter := e.config.MemoryUsageReporter
+	if reporter == nil || e.memoryUsage == nil {
+		return
+	}
+	reporter(e.memoryUsage)
+}
+
 func (e *interpreterEnvironment) ProgramLog(message string) error {
 	return e.runtimeInterface.ProgramLog(message)
 }
@@ -216,6 +345,49 @@ func (e *interpreterEnvironment) UnsafeRandom() (uint64, error) {
 	return e.runtimeInterface.UnsafeRandom()
 }
 
+func (e *interpreterEnvironment) GetComputationLimit() (uint64, error) {
+	provider, ok := e.runtimeInterface.(ComputationBudgetProvider)
+	if !ok {
+		return 0, NotImplementedError{Function: "GetComputationLimit"}
+	}
+	return provider.GetComputationLimit()
+}
+
+func (e *interpreterEnvironment) GetComputationUsed() (uint64, error) {
+	provider, ok := e.runtimeInterface.(ComputationBudgetProvider)
+	if !ok {
+		return 0, NotImplementedError{Function: "GetComputationUsed"}
+	}
+	return provider.GetComputationUsed()
+}
+
+func (e *interpreterEnvironment) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm sema.SignatureAlgorithm,
+	hashAlgorithm sema.HashAlgorithm,
+) (bool, error) {
+	return e.runtimeInterface.VerifySignature(
+		signature,
+		tag,
+		signedData,
+		publicKey,
+		signatureAlgorithm,
+		hashAlgorithm,
+	)
+}
+
+func (e *interpreterEnvironment) GetAddressForPublicKey(publicKey *stdlib.PublicKey) (common.Address, bool, error) {
+	provider, ok := e.runtimeInterface.(AddressFromPublicKeyProvider)
+	if !ok {
+		// Deriving addresses from public keys is optional
+		return common.Address{}, false, nil
+	}
+	return provider.GetAddressForPublicKey(publicKey)
+}
+
 func (e *interpreterEnvironment) GetBlockAtHeight(height uint64) (block stdlib.Block, exists bool, err error) {
 	return e.runtimeInterface.GetBlockAtHeight(height)
 }
@@ -228,19 +400,130 @@ func (e *interpreterEnvironment) GetAccountBalance(address common.Address) (uint
 	return e.runtimeInterface.GetAccountBalance(address)
 }
 
+func (e *interpreterEnvironment) MultiTokenBalancesSupported() bool {
+	_, ok := e.runtimeInterface.(MultiTokenBalanceProvider)
+	return ok
+}
+
+func (e *interpreterEnvironment) GetAccountBalanceForType(address common.Address, vaultType sema.Type) (uint64, error) {
+	provider, ok := e.runtimeInterface.(MultiTokenBalanceProvider)
+	if !ok {
+		// Only called if multi-token balances are supported,
+		// see MultiTokenBalancesSupported
+		return 0, errors.NewUnreachableError()
+	}
+	return provider.GetAccountBalanceForType(address, vaultType)
+}
+
+func (e *interpreterEnvironment) ResolveDefaultTokenAddress() (common.Address, bool, error) {
+	provider, ok := e.runtimeInterface.(DefaultTokenAddressProvider)
+	if !ok {
+		return common.Address{}, false, nil
+	}
+	address, err := provider.GetDefaultTokenAddress()
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	return address, true, nil
+}
+
 func (e *interpreterEnvironment) GetAccountAvailableBalance(address common.Address) (uint64, error) {
 	return e.runtimeInterface.GetAccountAvailableBalance(address)
 }
 
-func (e *interpreterEnvironment) CommitStorageTemporarily(inter *interpreter.Interpreter) error {
+func (e *interpreterEnvironment) CommitStorageTemporarily(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) error {
+	// A script which has not written anything has nothing to commit,
+	// so the storage used can be read from the host environment directly.
+	// Transactions always commit, unless the storage was already committed
+	// and nothing was written since
+	if (e.scriptEnvironment || e.storageFlushed) && !e.storage.hasPendingWrites() {
+		return nil
+	}
+
+	const commitContractUpdates = false
+	err := e.storage.Commit(inter, commitContractUpdates)
+	if err != nil {
+		return err
+	}
+
+	e.storageFlushed = true
+
+	return e.checkStorageGrowthLimit(getLocationRange)
+}
+
+func (e *interpreterEnvironment) FlushStorage(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) error {
 	const commitContractUpdates = false
-	return e.storage.Commit(inter, commitContractUpdates)
+	err := e.storage.Commit(inter, commitContractUpdates)
+	if err != nil {
+		return err
+	}
+
+	e.storageFlushed = true
+
+	return e.checkStorageGrowthLimit(getLocationRange)
+}
+
+// checkStorageGrowthLimit ensures that the storage used by each account
+// which was written since the last check does not exceed the storage growth limit, if any.
+// It must be called after the storage was committed,
+// as the host environment calculates the storage used based on the committed storage
+func (e *interpreterEnvironment) checkStorageGrowthLimit(
+	getLocationRange func() interpreter.LocationRange,
+) error {
+	writtenAccounts := e.storage.takeWrittenAccounts()
+
+	limit := e.config.StorageGrowthLimit
+	if limit == 0 {
+		return nil
+	}
+
+	for _, address := range writtenAccounts {
+		var used uint64
+		var err error
+		wrapPanic(func() {
+			used, err = e.runtimeInterface.GetStorageUsed(address)
+		})
+		if err != nil {
+			return err
+		}
+
+		if used > limit {
+			return &stdlib.StorageGrowthLimitError{
+				Address:       address,
+				Used:          used,
+				Limit:         limit,
+				LocationRange: getLocationRange(),
+			}
+		}
+	}
+
+	return nil
 }
 
 func (e *interpreterEnvironment) GetStorageUsed(address common.Address) (uint64, error) {
 	return e.runtimeInterface.GetStorageUsed(address)
 }
 
+func (e *interpreterEnvironment) StorageBreakdownSupported() bool {
+	_, ok := e.runtimeInterface.(StorageBreakdownProvider)
+	return ok
+}
+
+func (e *interpreterEnvironment) GetStorageUsedByDomain(address common.Address) (map[string]uint64, error) {
+	provider, ok := e.runtimeInterface.(StorageBreakdownProvider)
+	if !ok {
+		// The breakdown is optional
+		return nil, nil
+	}
+	return provider.GetStorageUsedByDomain(address)
+}
+
 func (e *interpreterEnvironment) GetStorageCapacity(address common.Address) (uint64, error) {
 	return e.runtimeInterface.GetStorageCapacity(address)
 }
@@ -249,12 +532,177 @@ func (e *interpreterEnvironment) GetAccountKey(address common.Address, index int
 	return e.runtimeInterface.GetAccountKey(address, index)
 }
 
+func (e *interpreterEnvironment) SetAccountKeyActive(address common.Address, index int, active bool) error {
+	activator, ok := e.runtimeInterface.(AccountKeyActivator)
+	if !ok {
+		return NotImplementedError{Function: "SetAccountKeyActive"}
+	}
+	return activator.SetAccountKeyActive(address, index, active)
+}
+
+func (e *interpreterEnvironment) GetAccountKeysTotalWeight(address common.Address) (uint64, error) {
+	provider, ok := e.runtimeInterface.(AccountKeysTotalWeightProvider)
+	if ok {
+		return provider.GetAccountKeysTotalWeight(address)
+	}
+
+	// The total weight is optional.
+	// Fall back to summing the weights of the unrevoked keys.
+	// Keys are never removed from an account, only revoked,
+	// so the keys are iterated until the first index without a key
+
+	var totalWeight uint64
+	for index := 0; ; index++ {
+		accountKey, err := e.runtimeInterface.GetAccountKey(address, index)
+		if err != nil {
+			return 0, err
+		}
+
+		if accountKey == nil {
+			break
+		}
+
+		if accountKey.IsRevoked {
+			continue
+		}
+
+		totalWeight += uint64(accountKey.Weight)
+	}
+	return totalWeight, nil
+}
+
 func (e *interpreterEnvironment) GetAccountContractNames(address common.Address) ([]string, error) {
 	return e.runtimeInterface.GetAccountContractNames(address)
 }
 
+func (e *interpreterEnvironment) GetAccountContractNamesBatch(
+	addresses []common.Address,
+) (map[common.Address][]string, error) {
+	provider, ok := e.runtimeInterface.(BulkAccountContractNamesProvider)
+	if ok {
+		return provider.GetAccountContractNamesBatch(addresses)
+	}
+
+	// The batch lookup is optional.
+	// Fall back to getting the contract names of each account individually
+	namesByAddress := make(map[common.Address][]string, len(addresses))
+	for _, address := range addresses {
+		names, err := e.runtimeInterface.GetAccountContractNames(address)
+		if err != nil {
+			return nil, err
+		}
+		namesByAddress[address] = names
+	}
+	return namesByAddress, nil
+}
+
+func (e *interpreterEnvironment) IssueStorageCapabilityController(
+	address common.Address,
+	targetPath interpreter.PathValue,
+) (uint64, error) {
+	issuer, ok := e.runtimeInterface.(StorageCapabilityControllerIssuer)
+	if !ok {
+		return 0, NotImplementedError{Function: "IssueStorageCapabilityController"}
+	}
+	return issuer.IssueStorageCapabilityController(
+		address,
+		cadence.NewPath(
+			targetPath.Domain.Identifier(),
+			targetPath.Identifier,
+		),
+	)
+}
+
+func (e *interpreterEnvironment) GetStorageCapabilityControllerIDs(
+	address common.Address,
+	targetPath interpreter.PathValue,
+) ([]uint64, error) {
+	enumerator, ok := e.runtimeInterface.(StorageCapabilityControllerEnumerator)
+	if !ok {
+		return nil, NotImplementedError{Function: "GetStorageCapabilityControllerIDs"}
+	}
+	return enumerator.GetStorageCapabilityControllerIDs(
+		address,
+		cadence.NewPath(
+			targetPath.Domain.Identifier(),
+			targetPath.Identifier,
+		),
+	)
+}
+
+func (e *interpreterEnvironment) ValidateAddress(address common.Address) error {
+	if e.config.AddressValidator == nil {
+		return nil
+	}
+	return e.config.AddressValidator(address)
+}
+
 func (e *interpreterEnvironment) GetAccountContractCode(address common.Address, name string) ([]byte, error) {
-	return e.runtimeInterface.GetAccountContractCode(address, name)
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+
+	if code, ok := e.contractCodeCache[location]; ok {
+		return code, nil
+	}
+
+	code, err := e.runtimeInterface.GetAccountContractCode(address, name)
+	if err != nil {
+		return nil, err
+	}
+
+	e.contractCodeCache[location] = code
+
+	return code, nil
+}
+
+func (e *interpreterEnvironment) invalidateContractCode(address common.Address, name string) {
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+
+	delete(e.contractCodeCache, location)
+
+	if e.contractChangeCounts == nil {
+		e.contractChangeCounts = map[common.AddressLocation]uint64{}
+	}
+	e.contractChangeCounts[location]++
+}
+
+func (e *interpreterEnvironment) AccountContractChangeCount(address common.Address, name string) uint64 {
+	return e.contractChangeCounts[common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}]
+}
+
+func (e *interpreterEnvironment) GetAccountContractValue(
+	inter *interpreter.Interpreter,
+	address common.Address,
+	name string,
+) (*interpreter.CompositeValue, error) {
+	storageMap := e.storage.GetStorageMap(
+		address,
+		StorageDomainContract,
+		false,
+	)
+	if storageMap == nil {
+		return nil, nil
+	}
+
+	storedValue := storageMap.ReadValue(inter, name)
+	if storedValue == nil {
+		return nil, nil
+	}
+
+	contractValue, ok := storedValue.(*interpreter.CompositeValue)
+	if !ok {
+		return nil, errors.NewUnexpectedError("invalid contract value for %s: %T", name, storedValue)
+	}
+
+	return contractValue, nil
 }
 
 func (e *interpreterEnvironment) CreateAccount(payer common.Address) (address common.Address, err error) {
@@ -267,6 +715,18 @@ func (e *interpreterEnvironment) EmitEvent(
 	values []interpreter.Value,
 	getLocationRange func() interpreter.LocationRange,
 ) {
+	err := e.TryEmitEvent(inter, eventType, values, getLocationRange)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (e *interpreterEnvironment) TryEmitEvent(
+	inter *interpreter.Interpreter,
+	eventType *sema.CompositeType,
+	values []interpreter.Value,
+	getLocationRange func() interpreter.LocationRange,
+) (err error) {
 	eventFields := make([]exportableValue, 0, len(values))
 
 	for _, value := range values {
@@ -278,8 +738,92 @@ func (e *interpreterEnvironment) EmitEvent(
 		getLocationRange,
 		eventType,
 		eventFields,
-		e.runtimeInterface.EmitEvent,
+		func(event cadence.Event) error {
+			// Return the error of the host environment to the caller,
+			// instead of panicking in emitEventFields
+			err = e.emitAccountEvent(event)
+			return nil
+		},
 	)
+
+	return err
+}
+
+func (e *interpreterEnvironment) MapHostError(err error) error {
+	// Errors reported by the environment itself, e.g. for unsupported optional functions,
+	// are not errors of the host
+	if _, ok := err.(NotImplementedError); ok {
+		return err
+	}
+
+	if mapper := e.config.HostErrorMapper; mapper != nil {
+		err = mapper(err)
+	}
+
+	switch err.(type) {
+	case errors.UserError, errors.InternalError, errors.ExternalError:
+		return err
+	default:
+		return errors.NewExternalError(err)
+	}
+}
+
+// emitAccountEvent passes an event emitted by the account functions to the host environment
+func (e *interpreterEnvironment) emitAccountEvent(event cadence.Event) error {
+	err := e.emitIndexedAccountEvent(event)
+	if err != nil {
+		return err
+	}
+
+	e.observeEventMetrics(event)
+
+	return nil
+}
+
+// emitEvent passes an event emitted by a program to the host environment
+func (e *interpreterEnvironment) emitEvent(event cadence.Event) error {
+	err := e.runtimeInterface.EmitEvent(event)
+	if err != nil {
+		return err
+	}
+
+	e.observeEventMetrics(event)
+
+	return nil
+}
+
+// observeEventMetrics reports the type and the payload size of the given emitted event
+// to the event metrics observer, if any
+func (e *interpreterEnvironment) observeEventMetrics(event cadence.Event) {
+	observer := e.eventMetricsObserver
+	if observer == nil {
+		return
+	}
+
+	payloadSize := 0
+	for _, field := range event.Fields {
+		payloadSize += eventPayloadSize(field)
+	}
+
+	observer.OnEventEmitted(common.TypeID(event.EventType.ID()), payloadSize)
+}
+
+// emitIndexedAccountEvent passes an event emitted by the account functions to the host environment,
+// together with the next sequence index, if account event indices are enabled
+func (e *interpreterEnvironment) emitIndexedAccountEvent(event cadence.Event) error {
+	if !e.config.AccountEventIndicesEnabled {
+		return e.runtimeInterface.EmitEvent(event)
+	}
+
+	emitter, ok := e.runtimeInterface.(IndexedEventEmitter)
+	if !ok {
+		return NotImplementedError{Function: "EmitIndexedEvent"}
+	}
+
+	index := e.accountEventIndex
+	e.accountEventIndex++
+
+	return emitter.EmitIndexedEvent(event, index)
 }
 
 func (e *interpreterEnvironment) AddEncodedAccountKey(address common.Address, key []byte) error {
@@ -299,15 +843,97 @@ func (e *interpreterEnvironment) AddAccountKey(
 	return e.runtimeInterface.AddAccountKey(address, key, algo, weight)
 }
 
+func (e *interpreterEnvironment) AccountKeyEventsDisabled() bool {
+	return e.config.AccountKeyEventsDisabled
+}
+
+func (e *interpreterEnvironment) DuplicateAccountKeysRejected() bool {
+	return e.config.RejectDuplicateAccountKeys
+}
+
+func (e *interpreterEnvironment) GetAccountKeyByPublicKey(
+	address common.Address,
+	publicKey *stdlib.PublicKey,
+) (*stdlib.AccountKey, error) {
+	provider, ok := e.runtimeInterface.(AccountKeyByPublicKeyProvider)
+	if !ok {
+		return nil, NotImplementedError{Function: "GetAccountKeyByPublicKey"}
+	}
+	return provider.GetAccountKeyByPublicKey(address, publicKey)
+}
+
+func (e *interpreterEnvironment) ContractUpdateValidationDisabled() bool {
+	return e.config.DisableContractUpdateValidation
+}
+
+func (e *interpreterEnvironment) MaxContractsPerAccount() uint64 {
+	return e.config.MaxContractsPerAccount
+}
+
 func (e *interpreterEnvironment) RevokeAccountKey(address common.Address, index int) (*stdlib.AccountKey, error) {
 	return e.runtimeInterface.RevokeAccountKey(address, index)
 }
 
+func (e *interpreterEnvironment) AccountContractExistenceSupported() bool {
+	_, ok := e.runtimeInterface.(AccountContractExistenceProvider)
+	return ok
+}
+
+func (e *interpreterEnvironment) AccountContractExists(address common.Address, name string) (bool, error) {
+	provider, ok := e.runtimeInterface.(AccountContractExistenceProvider)
+	if !ok {
+		// The existence check is optional.
+		// Fall back to checking if the contract has code.
+		// The code is cached, so it is not fetched again when it is accessed
+		code, err := e.GetAccountContractCode(address, name)
+		if err != nil {
+			return false, err
+		}
+		return len(code) > 0, nil
+	}
+	return provider.AccountContractExists(address, name)
+}
+
+func (e *interpreterEnvironment) GetAccountContractUpdateHistory(
+	address common.Address,
+	name string,
+) ([]stdlib.ContractVersion, error) {
+	provider, ok := e.runtimeInterface.(ContractHistoryProvider)
+	if !ok {
+		// The update history is optional, no versions are recorded
+		return nil, nil
+	}
+	return provider.GetAccountContractUpdateHistory(address, name)
+}
+
 func (e *interpreterEnvironment) UpdateAccountContractCode(address common.Address, name string, code []byte) error {
+	e.invalidateContractCode(address, name)
 	return e.runtimeInterface.UpdateAccountContractCode(address, name, code)
 }
 
+func (e *interpreterEnvironment) OnContractDeployed(address common.Address, name string, isUpdate bool, code []byte) {
+	observer, ok := e.runtimeInterface.(ContractDeploymentObserver)
+	if !ok {
+		return
+	}
+	observer.OnContractDeployed(address, name, isUpdate, code)
+}
+
+func (e *interpreterEnvironment) GetContractDeploymentFee(
+	address common.Address,
+	name string,
+	codeSize int,
+) (uint64, error) {
+	provider, ok := e.runtimeInterface.(DeploymentFeeProvider)
+	if !ok {
+		// Deployment fees are optional, no fee is deducted
+		return 0, nil
+	}
+	return provider.GetContractDeploymentFee(address, name, codeSize)
+}
+
 func (e *interpreterEnvironment) RemoveAccountContractCode(address common.Address, name string) error {
+	e.invalidateContractCode(address, name)
 	return e.runtimeInterface.RemoveAccountContractCode(address, name)
 }
 
@@ -343,6 +969,102 @@ func (e *interpreterEnvironment) ParseAndCheckProgram(
 	)
 }
 
+func (e *interpreterEnvironment) ParseAndCheckContractDeployment(
+	code []byte,
+	location common.AddressLocation,
+) (
+	*interpreter.Program,
+	error,
+) {
+	e.checkingContractDeployment = true
+	e.importChain = []common.Location{location}
+	defer func() {
+		e.checkingContractDeployment = false
+		e.importChain = nil
+	}()
+
+	// Use the program prepared by the caller, if any,
+	// instead of parsing and checking the code again
+
+	if program := e.config.PreparedContractPrograms.get(location, code); program != nil {
+		err := e.checkPreparedContractImports(program, location)
+		if err != nil {
+			return nil, err
+		}
+		return program, nil
+	}
+
+	// NOTE: *DO NOT* store the program – the new or updated program
+	// should not be effective during the execution
+
+	return e.parseAndCheckProgram(
+		code,
+		location,
+		false,
+		importResolutionResults{},
+	)
+}
+
+// checkPreparedContractImports resolves the imports of a program prepared for a contract deployment again.
+// The program was already checked, but not necessarily as a deployment,
+// and the imported contracts may have changed since the program was prepared.
+// Resolving the imports performs the checks which are only performed for deployments,
+// i.e. imports of contracts which are not deployed are unresolved, and circular imports are rejected.
+// The errors are reported like the checker would report them
+func (e *interpreterEnvironment) checkPreparedContractImports(
+	program *interpreter.Program,
+	location common.AddressLocation,
+) error {
+	e.checkedImports = importResolutionResults{}
+
+	var errs []error
+
+	for _, declaration := range program.Program.ImportDeclarations() {
+		importRange := ast.NewRange(
+			e,
+			declaration.LocationPos,
+			declaration.LocationPos,
+		)
+
+		resolvedLocations := program.Elaboration.ImportDeclarationsResolvedLocations[declaration]
+		for _, resolvedLocation := range resolvedLocations {
+			importedLocation := resolvedLocation.Location
+
+			imp, err := e.resolveImport(nil, importedLocation, importRange)
+			if err != nil {
+				if _, ok := err.(*sema.CyclicImportsError); !ok {
+					err = &sema.ImportedProgramError{
+						Err:      err,
+						Location: importedLocation,
+						Range:    importRange,
+					}
+				}
+				errs = append(errs, err)
+				continue
+			}
+
+			if imp == nil {
+				errs = append(errs, &sema.UnresolvedImportError{
+					ImportLocation: importedLocation,
+					Range:          importRange,
+				})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ParsingCheckingError{
+			Err: &sema.CheckerError{
+				Location: location,
+				Errors:   errs,
+			},
+			Location: location,
+		}
+	}
+
+	return nil
+}
+
 func (e *interpreterEnvironment) parseAndCheckProgram(
 	code []byte,
 	location common.Location,
@@ -365,6 +1087,8 @@ func (e *interpreterEnvironment) parseAndCheckProgram(
 
 	// Parse
 
+	e.config.ProgramCacheMetrics.recordParse()
+
 	var parse *ast.Program
 	reportMetric(
 		func() {
@@ -473,17 +1197,62 @@ func (e *interpreterEnvironment) resolveImport(
 
 	default:
 
-		// Check for cyclic imports
+		// Check for cyclic imports.
+		// When checking a contract deployment, report all members of the cycle,
+		// which may also lead back to the deployed contract itself
+
+		if e.checkingContractDeployment {
+			if cycle := e.importCycle(importedLocation); cycle != nil {
+				return nil, &CircularImportError{
+					Cycle: cycle,
+					Range: importRange,
+				}
+			}
+		}
+
 		if e.checkedImports[importedLocation] {
 			return nil, &sema.CyclicImportsError{
 				Location: importedLocation,
 				Range:    importRange,
 			}
-		} else {
-			e.checkedImports[importedLocation] = true
-			defer delete(e.checkedImports, importedLocation)
 		}
 
+		// The checked imports are the chain of imports currently being resolved,
+		// so their number is the current import depth
+		if uint64(len(e.checkedImports)) >= e.importDepthLimit {
+			return nil, &ImportDepthExceededError{
+				Location: importedLocation,
+				Limit:    e.importDepthLimit,
+				Range:    importRange,
+			}
+		}
+
+		// When checking a contract deployment, an imported contract which is not deployed
+		// is reported as an unresolved import by the checker,
+		// instead of being checked as an empty program
+
+		if addressLocation, ok := importedLocation.(common.AddressLocation); ok && e.checkingContractDeployment {
+			var exists bool
+			var err error
+			wrapPanic(func() {
+				exists, err = e.AccountContractExists(addressLocation.Address, addressLocation.Name)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, nil
+			}
+		}
+
+		e.checkedImports[importedLocation] = true
+		defer delete(e.checkedImports, importedLocation)
+
+		e.importChain = append(e.importChain, importedLocation)
+		defer func() {
+			e.importChain = e.importChain[:len(e.importChain)-1]
+		}()
+
 		program, err := e.getProgram(importedLocation, e.checkedImports)
 		if err != nil {
 			return nil, err
@@ -497,6 +1266,23 @@ func (e *interpreterEnvironment) resolveImport(
 	}, nil
 }
 
+// importCycle returns the cycle of imports which is closed by importing the given location,
+// starting and ending with the given location,
+// or nil if the given location is not part of the current import chain.
+func (e *interpreterEnvironment) importCycle(location common.Location) []common.Location {
+	for i, importedLocation := range e.importChain {
+		if importedLocation != location {
+			continue
+		}
+
+		cycle := make([]common.Location, 0, len(e.importChain)-i+1)
+		cycle = append(cycle, e.importChain[i:]...)
+		return append(cycle, location)
+	}
+
+	return nil
+}
+
 func (e *interpreterEnvironment) GetProgram(location Location) (*interpreter.Program, error) {
 	return e.getProgram(location, importResolutionResults{})
 }
@@ -510,6 +1296,14 @@ func (e *interpreterEnvironment) getProgram(
 	program *interpreter.Program,
 	err error,
 ) {
+	// The program of a contract which is initialized, but not deployed yet,
+	// is not available from the host environment
+
+	initialized := e.initializedContractProgram
+	if initialized != nil && initialized.location == location {
+		return initialized.program, nil
+	}
+
 	wrapPanic(func() {
 		program, err = e.runtimeInterface.GetProgram(location)
 	})
@@ -517,6 +1311,8 @@ func (e *interpreterEnvironment) getProgram(
 		return nil, err
 	}
 
+	e.config.ProgramCacheMetrics.recordGet(program != nil)
+
 	if program == nil {
 		var code []byte
 		code, err = e.getCode(location)
@@ -543,7 +1339,7 @@ func (e *interpreterEnvironment) getProgram(
 func (e *interpreterEnvironment) getCode(location common.Location) (code []byte, err error) {
 	if addressLocation, ok := location.(common.AddressLocation); ok {
 		wrapPanic(func() {
-			code, err = e.runtimeInterface.GetAccountContractCode(
+			code, err = e.GetAccountContractCode(
 				addressLocation.Address,
 				addressLocation.Name,
 			)
@@ -695,7 +1491,16 @@ func (e *interpreterEnvironment) newPublicKeyValidationHandler() interpreter.Pub
 			err = e.runtimeInterface.ValidatePublicKey(publicKey)
 		})
 
-		return err
+		if err == nil {
+			return nil
+		}
+
+		// Panics of the host environment are internal errors, not validation failures
+		if _, ok := errors.GetExternalError(err); ok {
+			return err
+		}
+
+		return stdlib.NewPublicKeyValidationError(publicKey, err)
 	}
 }
 
@@ -758,12 +1563,24 @@ func (e *interpreterEnvironment) newOnEventEmittedHandler() interpreter.OnEventE
 		eventValue *interpreter.CompositeValue,
 		eventType *sema.CompositeType,
 	) error {
+		if emitter, ok := e.runtimeInterface.(StreamingEventEmitter); ok {
+			emitEventValueStream(
+				inter,
+				getLocationRange,
+				eventType,
+				eventValue,
+				emitter,
+				e.eventMetricsObserver,
+			)
+			return nil
+		}
+
 		emitEventValue(
 			inter,
 			getLocationRange,
 			eventType,
 			eventValue,
-			e.runtimeInterface.EmitEvent,
+			e.emitEvent,
 		)
 
 		return nil
@@ -864,26 +1681,27 @@ func (e *interpreterEnvironment) loadContract(
 
 	default:
 
-		var storedValue interpreter.Value
+		var contractValue *interpreter.CompositeValue
 
 		switch location := compositeType.Location.(type) {
 
 		case common.AddressLocation:
-			storageMap := e.storage.GetStorageMap(
+			var err error
+			contractValue, err = e.GetAccountContractValue(
+				inter,
 				location.Address,
-				StorageDomainContract,
-				false,
+				location.Name,
 			)
-			if storageMap != nil {
-				storedValue = storageMap.ReadValue(inter, location.Name)
+			if err != nil {
+				panic(err)
 			}
 		}
 
-		if storedValue == nil {
+		if contractValue == nil {
 			panic(errors.NewDefaultUserError("failed to load contract: %s", compositeType.Location))
 		}
 
-		return storedValue.(*interpreter.CompositeValue)
+		return contractValue
 	}
 }
 
@@ -901,6 +1719,10 @@ func (e *interpreterEnvironment) newOnInvokedFunctionReturnHandler() func(_ *int
 
 func (e *interpreterEnvironment) newOnMeterComputation() interpreter.OnMeterComputationFunc {
 	return func(compKind common.ComputationKind, intensity uint) {
+		if weight, ok := e.config.ComputationWeights[compKind]; ok {
+			intensity = weightedComputationIntensity(intensity, weight)
+		}
+
 		var err error
 		wrapPanic(func() {
 			err = e.runtimeInterface.MeterComputation(compKind, intensity)
@@ -911,6 +1733,19 @@ func (e *interpreterEnvironment) newOnMeterComputation() interpreter.OnMeterComp
 	}
 }
 
+// weightedComputationIntensity returns the given intensity multiplied by the given weight.
+// The result saturates at the maximum intensity instead of overflowing.
+func weightedComputationIntensity(intensity uint, weight uint64) uint {
+	if weight != 0 && uint64(intensity) > math.MaxUint64/weight {
+		return math.MaxUint
+	}
+	weighted := uint64(intensity) * weight
+	if weighted > math.MaxUint {
+		return math.MaxUint
+	}
+	return uint(weighted)
+}
+
 func (e *interpreterEnvironment) InterpretContract(
 	location common.AddressLocation,
 	program *interpreter.Program,
@@ -943,6 +1778,22 @@ func (e *interpreterEnvironment) InterpretContract(
 	return
 }
 
+func (e *interpreterEnvironment) WithInitializedContractProgram(
+	location common.AddressLocation,
+	program *interpreter.Program,
+	f func(),
+) {
+	e.initializedContractProgram = &initializedContractProgram{
+		location: location,
+		program:  program,
+	}
+	defer func() {
+		e.initializedContractProgram = nil
+	}()
+
+	f()
+}
+
 func (e *interpreterEnvironment) Interpret(
 	location common.Location,
 	program *interpreter.Program,
@@ -979,6 +1830,32 @@ func (e *interpreterEnvironment) Interpret(
 	return result, inter, nil
 }
 
+func (e *interpreterEnvironment) OnAccountAccess(address common.Address, kind stdlib.AccountAccessKind) {
+	if e.accessObserver == nil {
+		return
+	}
+
+	wrapPanic(func() {
+		e.accessObserver.OnAccountAccess(address, kind)
+	})
+}
+
+func (e *interpreterEnvironment) newStorageAccessHandler() interpreter.OnStorageAccessFunc {
+	return func(
+		_ *interpreter.Interpreter,
+		address common.Address,
+		_ string,
+		_ string,
+		write bool,
+	) {
+		kind := stdlib.AccountAccessKindStorageRead
+		if write {
+			kind = stdlib.AccountAccessKindStorageWrite
+		}
+		e.OnAccountAccess(address, kind)
+	}
+}
+
 func (e *interpreterEnvironment) newResourceOwnerChangedHandler() interpreter.OnResourceOwnerChangeFunc {
 	if !e.config.ResourceOwnerChangeHandlerEnabled {
 		return nil
@@ -1116,6 +1993,7 @@ func (e *interpreterEnvironment) newBLSAggregatePublicKeysFunction(
 			inter,
 			getLocationRange,
 			aggregatedPublicKey,
+			sema.HashAlgorithmUnknown,
 			publicKeyValidationHandler,
 		)
 
@@ -1133,6 +2011,18 @@ func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) e
 		return err
 	}
 
+	err = e.checkStorageGrowthLimit(interpreter.ReturnEmptyLocationRange)
+	if err != nil {
+		return err
+	}
+
+	if e.config.StorageCapacityChangedEventsEnabled {
+		err = e.emitStorageCapacityChangedEvents(inter)
+		if err != nil {
+			return err
+		}
+	}
+
 	if e.config.AtreeValidationEnabled {
 		err = e.storage.CheckHealth()
 		if err != nil {
@@ -1142,3 +2032,64 @@ func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) e
 
 	return nil
 }
+
+func (e *interpreterEnvironment) recordStorageCapacity(address common.Address) {
+	var capacity uint64
+	var err error
+	wrapPanic(func() {
+		capacity, err = e.runtimeInterface.GetStorageCapacity(address)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	e.storageCapacities = append(
+		e.storageCapacities,
+		accountStorageCapacity{
+			address:  address,
+			capacity: capacity,
+		},
+	)
+}
+
+// emitStorageCapacityChangedEvents emits an AccountStorageCapacityChanged event
+// for each accessed account whose storage capacity changed since it was first accessed.
+// The capacities are compared after the storage was committed,
+// as the host may determine the capacity based on the committed storage
+func (e *interpreterEnvironment) emitStorageCapacityChangedEvents(inter *interpreter.Interpreter) error {
+	for _, previous := range e.storageCapacities {
+
+		var capacity uint64
+		var err error
+		wrapPanic(func() {
+			capacity, err = e.runtimeInterface.GetStorageCapacity(previous.address)
+		})
+		if err != nil {
+			return err
+		}
+
+		if capacity == previous.capacity {
+			continue
+		}
+
+		err = e.TryEmitEvent(
+			inter,
+			stdlib.AccountStorageCapacityChangedEventType,
+			[]interpreter.Value{
+				interpreter.NewAddressValue(inter, previous.address),
+				interpreter.NewUInt64Value(inter, func() uint64 {
+					return previous.capacity
+				}),
+				interpreter.NewUInt64Value(inter, func() uint64 {
+					return capacity
+				}),
+			},
+			interpreter.ReturnEmptyLocationRange,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
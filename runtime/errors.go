@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/pretty"
@@ -78,6 +79,56 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// ImportDepthExceededError
+
+type ImportDepthExceededError struct {
+	Location common.Location
+	Limit    uint64
+	ast.Range
+}
+
+var _ errors.UserError = &ImportDepthExceededError{}
+
+func (*ImportDepthExceededError) IsUserError() {}
+
+func (e *ImportDepthExceededError) Error() string {
+	return fmt.Sprintf(
+		"cannot import `%s`: import depth limit exceeded: %d",
+		e.Location,
+		e.Limit,
+	)
+}
+
+// CircularImportError
+
+// CircularImportError is reported when the code of a contract deployment
+// (transitively) imports a contract which is already being imported,
+// e.g. the deployed contract itself.
+// The cycle starts and ends with the same location.
+type CircularImportError struct {
+	Cycle []common.Location
+	ast.Range
+}
+
+var _ errors.UserError = &CircularImportError{}
+
+func (*CircularImportError) IsUserError() {}
+
+func (e *CircularImportError) Error() string {
+	var builder strings.Builder
+	for i, location := range e.Cycle {
+		if i > 0 {
+			builder.WriteString(" -> ")
+		}
+		builder.WriteString(location.String())
+	}
+
+	return fmt.Sprintf(
+		"circular import: %s",
+		builder.String(),
+	)
+}
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -304,3 +355,16 @@ func (e *ParsingCheckingError) Unwrap() error {
 func (e *ParsingCheckingError) ImportLocation() Location {
 	return e.Location
 }
+
+// NotImplementedError is returned by the default implementations of BaseInterface
+//
+type NotImplementedError struct {
+	Function string
+}
+
+func (e NotImplementedError) Error() string {
+	return fmt.Sprintf(
+		"runtime interface function not implemented: %s",
+		e.Function,
+	)
+}
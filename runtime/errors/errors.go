@@ -48,10 +48,12 @@ type UserError interface {
 }
 
 // ExternalError is an error that occurred externally.
-// It contains the recovered value.
+// It contains the recovered value,
+// and the stack of the failure, if it was unexpected.
 //
 type ExternalError struct {
 	Recovered any
+	Stack     []byte
 }
 
 func NewExternalError(recovered any) ExternalError {
@@ -212,3 +214,32 @@ func GetExternalError(err error) (ExternalError, bool) {
 		return ExternalError{}, false
 	}
 }
+
+// RecoverToError executes f and converts any panic into a returned error,
+// so that embedders can guarantee a failure never crashes the process.
+//
+// Errors which are already classified (internal, user, or external errors)
+// are returned as-is. Any other error is wrapped in an UnexpectedError,
+// which preserves it as the cause, and any other value is converted
+// into an UnexpectedError.
+//
+func RecoverToError(f func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		switch r := r.(type) {
+		case InternalError, UserError, ExternalError:
+			err = r.(error)
+		case error:
+			err = NewUnexpectedErrorFromCause(r)
+		default:
+			err = NewUnexpectedError("%s", r)
+		}
+	}()
+
+	f()
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	goErrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverToError(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no panic", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := RecoverToError(func() {})
+		require.NoError(t, err)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+
+		t.Parallel()
+
+		unreachableErr := NewUnreachableError()
+
+		err := RecoverToError(func() {
+			panic(unreachableErr)
+		})
+		require.Error(t, err)
+
+		assert.Equal(t, unreachableErr, err)
+		assert.True(t, IsInternalError(err))
+	})
+
+	t.Run("user error", func(t *testing.T) {
+
+		t.Parallel()
+
+		userErr := NewDefaultUserError("invalid")
+
+		err := RecoverToError(func() {
+			panic(userErr)
+		})
+		require.Error(t, err)
+
+		assert.Equal(t, userErr, err)
+		assert.True(t, IsUserError(err))
+	})
+
+	t.Run("unclassified error", func(t *testing.T) {
+
+		t.Parallel()
+
+		cause := goErrors.New("failure")
+
+		err := RecoverToError(func() {
+			panic(cause)
+		})
+		require.Error(t, err)
+
+		assert.True(t, IsInternalError(err))
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("non-error value", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := RecoverToError(func() {
+			panic("failure")
+		})
+		require.Error(t, err)
+
+		assert.True(t, IsInternalError(err))
+		assert.Contains(t, err.Error(), "failure")
+	})
+}
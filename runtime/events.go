@@ -19,6 +19,8 @@
 package runtime
 
 import (
+	"math/big"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -43,6 +45,209 @@ func emitEventValue(
 	emitEventFields(inter, getLocationRange, eventType, fields, emitEvent)
 }
 
+// emitEventValueStream emits the given event value to the given streaming event emitter.
+// The field values are exported lazily, when requested by the emitter.
+// The event is reported to the given metrics observer, if any
+func emitEventValueStream(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	eventType *sema.CompositeType,
+	event *interpreter.CompositeValue,
+	emitter StreamingEventEmitter,
+	metricsObserver EventMetricsObserver,
+) {
+	// Meter the same memory usage as for a materialized event,
+	// so metering does not depend on the emitter
+	baseUsage, sizeUsage := common.NewCadenceEventMemoryUsages(len(eventType.ConstructorParameters))
+	common.UseMemory(inter, baseUsage)
+	common.UseMemory(inter, sizeUsage)
+
+	exportedEventType := ExportMeteredType(inter, eventType, map[sema.TypeID]cadence.Type{}).(*cadence.EventType)
+
+	iterator := &eventFieldIterator{
+		inter:            inter,
+		getLocationRange: getLocationRange,
+		eventType:        eventType,
+		event:            event,
+		seenReferences:   seenReferences{},
+	}
+
+	var err error
+	wrapPanic(func() {
+		err = emitter.EmitEventStream(exportedEventType, iterator)
+	})
+
+	// Prefer a failure of the export over the error returned by the emitter,
+	// as the emitter may have returned it as-is
+	if iterator.err != nil {
+		panic(iterator.err)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	if metricsObserver != nil {
+		metricsObserver.OnEventEmitted(
+			common.TypeID(exportedEventType.ID()),
+			iterator.payloadSize,
+		)
+	}
+}
+
+// eventFieldIterator exports the field values of an event value one at a time
+type eventFieldIterator struct {
+	inter            *interpreter.Interpreter
+	getLocationRange func() interpreter.LocationRange
+	eventType        *sema.CompositeType
+	event            *interpreter.CompositeValue
+	seenReferences   seenReferences
+	index            int
+	err              error
+	// payloadSize is the payload size of the exported field values, see eventPayloadSize
+	payloadSize int
+}
+
+var _ EventFieldIterator = &eventFieldIterator{}
+
+func (i *eventFieldIterator) Next() (value cadence.Value, err error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	parameters := i.eventType.ConstructorParameters
+	if i.index >= len(parameters) {
+		return nil, nil
+	}
+
+	parameter := parameters[i.index]
+	i.index++
+
+	// The iterator is called by the host environment,
+	// so recover failures of the export here and return them as an error,
+	// instead of panicking through the host environment
+
+	defer func() {
+		if r := recover(); r != nil {
+			recoveredErr, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			i.err = recoveredErr
+			value = nil
+			err = recoveredErr
+		}
+	}()
+
+	fieldValue := i.event.GetField(i.inter, i.getLocationRange, parameter.Identifier)
+
+	value, err = exportValueWithInterpreter(
+		fieldValue,
+		i.inter,
+		i.getLocationRange,
+		i.seenReferences,
+	)
+	if err != nil {
+		i.err = err
+		return nil, err
+	}
+
+	i.payloadSize += eventPayloadSize(value)
+
+	return value, nil
+}
+
+// eventPayloadSize returns the size of the data of the given exported value in bytes,
+// e.g. the length of strings and the width of integers, without any encoding overhead.
+// It is used to report the size of emitted events to an EventMetricsObserver,
+// without having to encode them
+func eventPayloadSize(value cadence.Value) int {
+	switch value := value.(type) {
+	case cadence.Optional:
+		return eventPayloadSize(value.Value)
+	case cadence.Bool, cadence.Int8, cadence.UInt8, cadence.Word8:
+		return 1
+	case cadence.Int16, cadence.UInt16, cadence.Word16:
+		return 2
+	case cadence.Int32, cadence.UInt32, cadence.Word32:
+		return 4
+	case cadence.Int64, cadence.UInt64, cadence.Word64, cadence.Fix64, cadence.UFix64:
+		return 8
+	case cadence.Int128, cadence.UInt128:
+		return 16
+	case cadence.Int256, cadence.UInt256:
+		return 32
+	case cadence.Int:
+		return bigIntPayloadSize(value.Value)
+	case cadence.UInt:
+		return bigIntPayloadSize(value.Value)
+	case cadence.String:
+		return len(value)
+	case cadence.Character:
+		return len(value)
+	case cadence.Bytes:
+		return len(value)
+	case cadence.Address:
+		return len(value)
+	case cadence.Path:
+		return len(value.Domain) + len(value.Identifier)
+	case cadence.Link:
+		return eventPayloadSize(value.TargetPath) + len(value.BorrowType)
+	case cadence.TypeValue:
+		return typePayloadSize(value.StaticType)
+	case cadence.Capability:
+		return eventPayloadSize(value.Path) +
+			len(value.Address) +
+			typePayloadSize(value.BorrowType)
+	case cadence.Array:
+		return valuesPayloadSize(value.Values)
+	case cadence.Dictionary:
+		size := 0
+		for _, pair := range value.Pairs {
+			size += eventPayloadSize(pair.Key) + eventPayloadSize(pair.Value)
+		}
+		return size
+	case cadence.Struct:
+		return valuesPayloadSize(value.Fields)
+	case cadence.Resource:
+		return valuesPayloadSize(value.Fields)
+	case cadence.Event:
+		return valuesPayloadSize(value.Fields)
+	case cadence.Contract:
+		return valuesPayloadSize(value.Fields)
+	case cadence.Enum:
+		return valuesPayloadSize(value.Fields)
+	default:
+		return 0
+	}
+}
+
+func valuesPayloadSize(values []cadence.Value) int {
+	size := 0
+	for _, value := range values {
+		size += eventPayloadSize(value)
+	}
+	return size
+}
+
+func bigIntPayloadSize(value *big.Int) int {
+	if value == nil {
+		return 0
+	}
+	size := (value.BitLen() + 7) / 8
+	if size == 0 {
+		// Zero still takes a byte
+		return 1
+	}
+	return size
+}
+
+func typePayloadSize(ty cadence.Type) int {
+	if ty == nil {
+		return 0
+	}
+	return len(ty.ID())
+}
+
 func emitEventFields(
 	gauge common.MemoryGauge,
 	getLocationRange func() interpreter.LocationRange,
@@ -0,0 +1,516 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testStreamingEventRuntimeInterface struct {
+	*testRuntimeInterface
+	emitEventStream func(eventType *cadence.EventType, fields EventFieldIterator) error
+}
+
+var _ StreamingEventEmitter = &testStreamingEventRuntimeInterface{}
+
+func (i *testStreamingEventRuntimeInterface) EmitEventStream(
+	eventType *cadence.EventType,
+	fields EventFieldIterator,
+) error {
+	return i.emitEventStream(eventType, fields)
+}
+
+type testIndexedEventRuntimeInterface struct {
+	*testRuntimeInterface
+	emitIndexedEvent func(event cadence.Event, index uint64) error
+}
+
+var _ IndexedEventEmitter = &testIndexedEventRuntimeInterface{}
+
+func (i *testIndexedEventRuntimeInterface) EmitIndexedEvent(event cadence.Event, index uint64) error {
+	return i.emitIndexedEvent(event, index)
+}
+
+const testEventEmitterContract = `
+  pub contract Test {
+
+      pub event Emitted(index: Int, message: String)
+
+      pub fun emitMany(_ count: Int) {
+          var i = 0
+          while i < count {
+              emit Emitted(index: i, message: "hello")
+              i = i + 1
+          }
+      }
+  }
+`
+
+func newEventEmissionTransaction(count int) []byte {
+	return []byte(fmt.Sprintf(
+		`
+          import Test from 0x1
+
+          transaction {
+              prepare(signer: AuthAccount) {
+                  Test.emitMany(%d)
+              }
+          }
+        `,
+		count,
+	))
+}
+
+// newEventEmissionTestRuntimeInterface returns a runtime interface
+// with the test event emitter contract deployed.
+// All events are passed to the given function
+func newEventEmissionTestRuntimeInterface(
+	tb testing.TB,
+	emitEvent func(cadence.Event) error,
+) *testRuntimeInterface {
+
+	accountCodes := map[common.Location][]byte{}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(tb),
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location], nil
+		},
+		emitEvent: emitEvent,
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction("Test", []byte(testEventEmitterContract)),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(tb, err)
+
+	return runtimeInterface
+}
+
+func TestRuntimeStreamingEventEmitter(t *testing.T) {
+
+	t.Parallel()
+
+	var events []cadence.Event
+
+	runtimeInterface := newEventEmissionTestRuntimeInterface(
+		t,
+		func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	)
+
+	// Ignore the events of the deployment
+
+	events = nil
+
+	// Emit the events through the materialized path
+
+	runtime := newTestInterpreterRuntime()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: newEventEmissionTransaction(2),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	materializedEvents := events
+	events = nil
+
+	// Emit the events through the streaming path
+
+	streamingRuntimeInterface := &testStreamingEventRuntimeInterface{
+		testRuntimeInterface: runtimeInterface,
+		emitEventStream: func(eventType *cadence.EventType, fields EventFieldIterator) error {
+			var values []cadence.Value
+			for {
+				value, err := fields.Next()
+				if err != nil {
+					return err
+				}
+				if value == nil {
+					break
+				}
+				values = append(values, value)
+			}
+
+			events = append(events, cadence.NewEvent(values).WithType(eventType))
+			return nil
+		},
+	}
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: newEventEmissionTransaction(2),
+		},
+		Context{
+			Interface: streamingRuntimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	// Both paths produce the same events
+
+	require.Len(t, materializedEvents, 2)
+	assert.Equal(t, materializedEvents, events)
+}
+
+type testStreamingEventMetricsRuntimeInterface struct {
+	*testStreamingEventRuntimeInterface
+	onEventEmitted func(eventTypeID common.TypeID, payloadSize int)
+}
+
+var _ EventMetricsObserver = &testStreamingEventMetricsRuntimeInterface{}
+
+func (i *testStreamingEventMetricsRuntimeInterface) OnEventEmitted(eventTypeID common.TypeID, payloadSize int) {
+	i.onEventEmitted(eventTypeID, payloadSize)
+}
+
+func TestRuntimeStreamingEventEmitterMetrics(t *testing.T) {
+
+	t.Parallel()
+
+	runtimeInterface := newEventEmissionTestRuntimeInterface(
+		t,
+		func(_ cadence.Event) error {
+			return nil
+		},
+	)
+
+	type eventMetrics struct {
+		eventTypeID common.TypeID
+		payloadSize int
+	}
+
+	var observed []eventMetrics
+
+	streamingRuntimeInterface := &testStreamingEventMetricsRuntimeInterface{
+		testStreamingEventRuntimeInterface: &testStreamingEventRuntimeInterface{
+			testRuntimeInterface: runtimeInterface,
+			emitEventStream: func(_ *cadence.EventType, fields EventFieldIterator) error {
+				for {
+					value, err := fields.Next()
+					if err != nil {
+						return err
+					}
+					if value == nil {
+						return nil
+					}
+				}
+			},
+		},
+		onEventEmitted: func(eventTypeID common.TypeID, payloadSize int) {
+			observed = append(observed, eventMetrics{
+				eventTypeID: eventTypeID,
+				payloadSize: payloadSize,
+			})
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: newEventEmissionTransaction(2),
+		},
+		Context{
+			Interface: streamingRuntimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	// Each event has an Int field with a small value (1 byte),
+	// and the String field "hello" (5 bytes)
+
+	const eventTypeID = common.TypeID("A.0000000000000001.Test.Emitted")
+
+	assert.Equal(t,
+		[]eventMetrics{
+			{eventTypeID: eventTypeID, payloadSize: 6},
+			{eventTypeID: eventTypeID, payloadSize: 6},
+		},
+		observed,
+	)
+}
+
+func TestEventPayloadSize(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, value cadence.Value, expected int) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, expected, eventPayloadSize(value))
+		})
+	}
+
+	test("Bool", cadence.NewBool(true), 1)
+	test("UInt16", cadence.NewUInt16(1), 2)
+	test("UFix64", cadence.UFix64(1), 8)
+	test("Int zero", cadence.NewInt(0), 1)
+	test("Int", cadence.NewInt(256), 2)
+	test("String", cadence.String("hello"), 5)
+	test("Address", cadence.NewAddress([8]byte{1}), 8)
+	test("nil", cadence.NewOptional(nil), 0)
+	test("some", cadence.NewOptional(cadence.String("hi")), 2)
+	test(
+		"Path",
+		cadence.Path{
+			Domain:     "storage",
+			Identifier: "foo",
+		},
+		10,
+	)
+	test(
+		"Array",
+		cadence.NewArray([]cadence.Value{
+			cadence.NewUInt8(1),
+			cadence.NewUInt8(2),
+		}),
+		2,
+	)
+	test(
+		"Dictionary",
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key:   cadence.String("a"),
+				Value: cadence.NewUInt32(1),
+			},
+		}),
+		5,
+	)
+	test(
+		"Struct",
+		cadence.NewStruct([]cadence.Value{
+			cadence.String("abc"),
+			cadence.NewInt64(1),
+		}),
+		11,
+	)
+}
+
+func BenchmarkRuntimeEventEmission(b *testing.B) {
+
+	const eventCount = 1000
+
+	tx := newEventEmissionTransaction(eventCount)
+
+	runtimeInterface := newEventEmissionTestRuntimeInterface(
+		b,
+		func(_ cadence.Event) error {
+			return nil
+		},
+	)
+
+	runtime := newTestInterpreterRuntime()
+
+	b.Run("materialized", func(b *testing.B) {
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.TransactionLocation{},
+				},
+			)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("streaming", func(b *testing.B) {
+
+		streamingRuntimeInterface := &testStreamingEventRuntimeInterface{
+			testRuntimeInterface: runtimeInterface,
+			emitEventStream: func(_ *cadence.EventType, fields EventFieldIterator) error {
+				for {
+					value, err := fields.Next()
+					if err != nil {
+						return err
+					}
+					if value == nil {
+						return nil
+					}
+				}
+			},
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: streamingRuntimeInterface,
+					Location:  common.TransactionLocation{},
+				},
+			)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func TestRuntimeIndexedAccountEvents(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled:     true,
+		AccountEventIndicesEnabled: true,
+	})
+
+	accountCodes := map[common.Location][]byte{}
+
+	var eventNames []string
+	var indices []uint64
+
+	runtimeInterface := &testIndexedEventRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location] = code
+				return nil
+			},
+			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location], nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				require.FailNow(t, "unexpected unindexed event")
+				return nil
+			},
+		},
+		emitIndexedEvent: func(event cadence.Event, index uint64) error {
+			eventNames = append(eventNames, event.Fields[2].(cadence.String).ToGoValue().(string))
+			indices = append(indices, index)
+			return nil
+		},
+	}
+
+	addContracts := func(names ...string) {
+		var adds strings.Builder
+		for _, name := range names {
+			code := fmt.Sprintf("pub contract %s {}", name)
+			fmt.Fprintf(
+				&adds,
+				"signer.contracts.add(name: %q, code: %q.decodeHex())\n",
+				name,
+				hex.EncodeToString([]byte(code)),
+			)
+		}
+
+		tx := []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      %s
+                  }
+              }
+            `,
+			adds.String(),
+		))
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	addContracts("A", "B", "C")
+
+	assert.Equal(t, []string{"A", "B", "C"}, eventNames)
+	assert.Equal(t, []uint64{0, 1, 2}, indices)
+
+	// The index is reset for each transaction
+
+	eventNames = nil
+	indices = nil
+
+	addContracts("D", "E")
+
+	assert.Equal(t, []string{"D", "E"}, eventNames)
+	assert.Equal(t, []uint64{0, 1}, indices)
+}
@@ -114,6 +114,96 @@ func TestRuntimeCyclicImport(t *testing.T) {
 	require.IsType(t, &sema.CyclicImportsError{}, errs[0])
 }
 
+func TestRuntimeImportDepthLimit(t *testing.T) {
+
+	t.Parallel()
+
+	const importDepthLimit = 3
+
+	// p1 imports p2, p2 imports p3, and so on.
+	// The last program in the chain imports nothing
+
+	newRuntimeInterface := func(chainLength int) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			getCode: func(location Location) (bytes []byte, err error) {
+				var index int
+				_, err = fmt.Sscanf(string(location.(common.IdentifierLocation)), "p%d", &index)
+				if err != nil {
+					return nil, err
+				}
+
+				if index >= chainLength {
+					return []byte(`pub fun answer(): Int { return 42 }`), nil
+				}
+
+				return []byte(fmt.Sprintf(`import p%d`, index+1)), nil
+			},
+		}
+	}
+
+	script := []byte(`
+      import p1
+
+      pub fun main() {}
+    `)
+
+	runtime := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled: true,
+		ImportDepthLimit:       importDepthLimit,
+	})
+
+	executeScript := func(chainLength int) error {
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(chainLength),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return err
+	}
+
+	t.Run("at limit", func(t *testing.T) {
+		t.Parallel()
+
+		err := executeScript(importDepthLimit)
+		require.NoError(t, err)
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		t.Parallel()
+
+		err := executeScript(importDepthLimit + 1)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		// Each imported program fails because of its import,
+		// until the import which exceeds the limit
+
+		for depth := 0; depth <= importDepthLimit; depth++ {
+			errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+			var importedProgramErr *sema.ImportedProgramError
+			require.ErrorAs(t, errs[0], &importedProgramErr)
+
+			if depth == importDepthLimit {
+				var importDepthErr *ImportDepthExceededError
+				require.ErrorAs(t, importedProgramErr.Err, &importDepthErr)
+
+				require.Equal(t, common.IdentifierLocation("p4"), importDepthErr.Location)
+				require.Equal(t, uint64(importDepthLimit), importDepthErr.Limit)
+				break
+			}
+
+			require.ErrorAs(t, importedProgramErr.Err, &checkerErr)
+		}
+	})
+}
+
 func TestRuntimeExport(t *testing.T) {
 
 	t.Parallel()
@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 type Interface interface {
@@ -155,6 +156,180 @@ type Interface interface {
 	MeterMemory(usage common.MemoryUsage) error
 }
 
+// AccountKeysTotalWeightProvider is an optional interface an Interface can implement,
+// to provide the total weight of the keys of an account without iterating over them.
+// If it is not implemented, the weights of the keys returned by GetAccountKey are summed.
+type AccountKeysTotalWeightProvider interface {
+	// GetAccountKeysTotalWeight returns the sum of the weights of all unrevoked keys of an account.
+	GetAccountKeysTotalWeight(address Address) (uint64, error)
+}
+
+// StorageCapabilityControllerIssuer is an optional interface an Interface can implement,
+// to support issuing capabilities with capability controllers.
+type StorageCapabilityControllerIssuer interface {
+	// IssueStorageCapabilityController issues a new capability controller for the given storage path,
+	// and returns the ID of the issued capability.
+	IssueStorageCapabilityController(address Address, targetPath cadence.Path) (capabilityID uint64, err error)
+}
+
+// StorageCapabilityControllerEnumerator is an optional interface an Interface can implement,
+// to support enumerating the capability controllers issued for a storage path.
+type StorageCapabilityControllerEnumerator interface {
+	// GetStorageCapabilityControllerIDs returns the IDs of all capabilities
+	// which were issued against the given storage path.
+	GetStorageCapabilityControllerIDs(address Address, targetPath cadence.Path) (capabilityIDs []uint64, err error)
+}
+
+// MultiTokenBalanceProvider is an optional interface an Interface can implement,
+// to provide the balances of vaults other than the default token vault.
+type MultiTokenBalanceProvider interface {
+	// GetAccountBalanceForType gets the balance of the account's vault of the given type.
+	GetAccountBalanceForType(address Address, vaultType sema.Type) (uint64, error)
+}
+
+// DefaultTokenAddressProvider is an optional interface an Interface can implement,
+// to provide the address of the contract which defines the default token of the network,
+// e.g. the Flow token contract, which is deployed at different addresses on different networks.
+// The balance of a vault defined at this address is the account's default token balance,
+// as returned by GetAccountBalance.
+type DefaultTokenAddressProvider interface {
+	// GetDefaultTokenAddress gets the address of the default token contract.
+	GetDefaultTokenAddress() (Address, error)
+}
+
+// StorageBreakdownProvider is an optional interface an Interface can implement,
+// to provide the storage used by an account broken down by path domain.
+type StorageBreakdownProvider interface {
+	// GetStorageUsedByDomain gets storage used in bytes by the address at the moment of the function call,
+	// keyed by path domain identifier (e.g. "storage", "public", "private").
+	GetStorageUsedByDomain(address Address) (map[string]uint64, error)
+}
+
+// AccountContractExistenceProvider is an optional interface an Interface can implement,
+// to provide a check if an account contract exists, which is cheaper than getting its code.
+type AccountContractExistenceProvider interface {
+	// AccountContractExists returns true if a contract with the given name exists in the account.
+	AccountContractExists(address Address, name string) (bool, error)
+}
+
+// ContractHistoryProvider is an optional interface an Interface can implement,
+// if it retains the update history of contracts.
+type ContractHistoryProvider interface {
+	// GetAccountContractUpdateHistory returns the recorded versions of the given contract,
+	// starting with the oldest version.
+	GetAccountContractUpdateHistory(address Address, name string) ([]ContractVersion, error)
+}
+
+// BulkAccountContractNamesProvider is an optional interface an Interface can implement,
+// to provide the contract names of many accounts in one call.
+type BulkAccountContractNamesProvider interface {
+	// GetAccountContractNamesBatch returns the names of all contracts deployed in the given accounts.
+	// Accounts without contracts may be omitted from the result.
+	GetAccountContractNamesBatch(addresses []Address) (map[Address][]string, error)
+}
+
+// AccountKeyByPublicKeyProvider is an optional interface an Interface can implement,
+// to look up a key of an account by its public key.
+// It is required if duplicate account keys are rejected, see Config.RejectDuplicateAccountKeys.
+type AccountKeyByPublicKeyProvider interface {
+	// GetAccountKeyByPublicKey returns the unrevoked key of the account with the given public key,
+	// or nil if the account has no such key.
+	GetAccountKeyByPublicKey(address Address, publicKey *PublicKey) (*AccountKey, error)
+}
+
+// ComputationBudgetProvider is an optional interface an Interface can implement,
+// to let programs query how much computation they can still use.
+// The computation used must include all computation metered through MeterComputation so far.
+type ComputationBudgetProvider interface {
+	// GetComputationLimit returns the computation limit of the current transaction or script.
+	GetComputationLimit() (uint64, error)
+	// GetComputationUsed returns the computation used so far by the current transaction or script.
+	GetComputationUsed() (uint64, error)
+}
+
+// AccountKeyActivator is an optional interface an Interface can implement,
+// to support deactivating and reactivating account keys.
+type AccountKeyActivator interface {
+	// SetAccountKeyActive activates or deactivates a key of an account by index.
+	SetAccountKeyActive(address Address, index int, active bool) error
+}
+
+// ContractDeploymentObserver is an optional interface an Interface can implement,
+// to be notified after a contract was successfully added or updated.
+type ContractDeploymentObserver interface {
+	// OnContractDeployed is called after the code of the contract was written
+	// and the AccountContractAdded or AccountContractUpdated event was emitted.
+	OnContractDeployed(address Address, name string, isUpdate bool, code []byte)
+}
+
+// DeploymentFeeProvider is an optional interface an Interface can implement,
+// if the chain charges a fee for deploying contracts.
+// When a contract is added or updated, a flow.ContractDeploymentFeeDeducted event is emitted
+// with the fee, unless the fee is zero.
+type DeploymentFeeProvider interface {
+	// GetContractDeploymentFee returns the fee deducted for deploying the given contract code.
+	GetContractDeploymentFee(address Address, name string, codeSize int) (uint64, error)
+}
+
+// AddressFromPublicKeyProvider is an optional interface an Interface can implement,
+// if the chain derives account addresses from public keys.
+type AddressFromPublicKeyProvider interface {
+	// GetAddressForPublicKey returns the address of the account derived from the given public key.
+	// The boolean result is false if no address can be derived from the key.
+	GetAddressForPublicKey(publicKey *PublicKey) (Address, bool, error)
+}
+
+// StreamingEventEmitter is an optional interface an Interface can implement,
+// to receive the fields of events emitted by programs one at a time,
+// instead of as a materialized cadence.Event.
+//
+// Events emitted by the account functions are still emitted using Interface.EmitEvent.
+type StreamingEventEmitter interface {
+	// EmitEventStream is called when an event is emitted by a program.
+	// The field values are only exported when requested from the iterator,
+	// and the iterator must not be used after this function returned.
+	EmitEventStream(eventType *cadence.EventType, fields EventFieldIterator) error
+}
+
+// EventFieldIterator iterates over the field values of an emitted event,
+// in the order of the event type's fields.
+type EventFieldIterator interface {
+	// Next returns the next field value, or nil if there are no more fields.
+	Next() (cadence.Value, error)
+}
+
+// IndexedEventEmitter is an optional interface an Interface can implement,
+// to receive the events emitted by the account functions together with a sequence index,
+// so consumers can deterministically order and deduplicate them.
+// It is required if account event indices are enabled, see Config.AccountEventIndicesEnabled.
+type IndexedEventEmitter interface {
+	// EmitIndexedEvent is called instead of Interface.EmitEvent when an account function emits an event.
+	EmitIndexedEvent(event cadence.Event, index uint64) error
+}
+
+// AccessObserver is an optional interface an Interface can implement,
+// to observe which accounts are accessed by a program, e.g. for fee and access auditing.
+// It is notified when an account value is constructed,
+// and when a storage path of an account is read or written.
+type AccessObserver interface {
+	// OnAccountAccess is called when the account with the given address is accessed.
+	OnAccountAccess(address Address, kind AccountAccessKind)
+}
+
+// EventMetricsObserver is an optional interface an Interface can implement,
+// to observe the volume of emitted events, e.g. for monitoring.
+//
+// The size of an event is the size of the data of its field values in bytes,
+// e.g. the length of strings and the width of integers, without any encoding overhead.
+// It is computed without encoding the event.
+// For events emitted using a StreamingEventEmitter,
+// only the field values requested by the emitter are included.
+type EventMetricsObserver interface {
+	// OnEventEmitted is called after an event was emitted,
+	// with the type ID of the event and the size of its payload in bytes.
+	OnEventEmitted(eventTypeID common.TypeID, payloadSize int)
+}
+
 type Metrics interface {
 	ProgramParsed(location Location, duration time.Duration)
 	ProgramChecked(location Location, duration time.Duration)
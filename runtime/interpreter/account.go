@@ -33,6 +33,8 @@ var authAccountFieldNames = []string{
 	sema.AuthAccountAddressField,
 	sema.AuthAccountContractsField,
 	sema.AuthAccountKeysField,
+	sema.AuthAccountCapabilitiesField,
+	sema.AuthAccountInboxField,
 }
 
 // NewAuthAccountValue constructs an auth account value.
@@ -41,18 +43,24 @@ func NewAuthAccountValue(
 	address AddressValue,
 	accountBalanceGet func() UFix64Value,
 	accountAvailableBalanceGet func() UFix64Value,
-	storageUsedGet func(interpreter *Interpreter) UInt64Value,
-	storageCapacityGet func(interpreter *Interpreter) UInt64Value,
+	getBalanceFunction FunctionValue,
+	storageUsedGet func(interpreter *Interpreter, getLocationRange func() LocationRange) UInt64Value,
+	storageUsedByDomainGet func(interpreter *Interpreter, getLocationRange func() LocationRange) Value,
+	storageCapacityGet func(interpreter *Interpreter, getLocationRange func() LocationRange) UInt64Value,
 	addPublicKeyFunction FunctionValue,
 	removePublicKeyFunction FunctionValue,
+	addEncodedKeyWithMetadataFunction FunctionValue,
 	contractsConstructor func() Value,
 	keysConstructor func() Value,
+	capabilitiesConstructor func() Value,
+	inboxConstructor func() Value,
 ) Value {
 
 	fields := map[string]Value{
-		sema.AuthAccountAddressField:         address,
-		sema.AuthAccountAddPublicKeyField:    addPublicKeyFunction,
-		sema.AuthAccountRemovePublicKeyField: removePublicKeyFunction,
+		sema.AuthAccountAddressField:                   address,
+		sema.AuthAccountAddPublicKeyField:              addPublicKeyFunction,
+		sema.AuthAccountRemovePublicKeyField:           removePublicKeyFunction,
+		sema.AuthAccountAddEncodedKeyWithMetadataField: addEncodedKeyWithMetadataFunction,
 		sema.AuthAccountGetCapabilityField: accountGetCapabilityFunction(
 			gauge,
 			address,
@@ -63,6 +71,8 @@ func NewAuthAccountValue(
 
 	var contracts Value
 	var keys Value
+	var capabilities Value
+	var inbox Value
 
 	computeField := func(name string, inter *Interpreter, getLocationRange func() LocationRange) Value {
 		switch name {
@@ -76,6 +86,16 @@ func NewAuthAccountValue(
 				keys = keysConstructor()
 			}
 			return keys
+		case sema.AuthAccountCapabilitiesField:
+			if capabilities == nil {
+				capabilities = capabilitiesConstructor()
+			}
+			return capabilities
+		case sema.AuthAccountInboxField:
+			if inbox == nil {
+				inbox = inboxConstructor()
+			}
+			return inbox
 		case sema.AuthAccountPublicPathsField:
 			return inter.publicAccountPaths(address, getLocationRange)
 		case sema.AuthAccountPrivatePathsField:
@@ -92,12 +112,22 @@ func NewAuthAccountValue(
 			return accountBalanceGet()
 		case sema.AuthAccountAvailableBalanceField:
 			return accountAvailableBalanceGet()
+		case sema.AuthAccountGetBalanceField:
+			return getBalanceFunction
 		case sema.AuthAccountStorageUsedField:
-			return storageUsedGet(inter)
+			return storageUsedGet(inter, getLocationRange)
+		case sema.AuthAccountStorageUsedByDomainField:
+			// The breakdown is optional, the field is nil if the handler does not provide it
+			if storageUsedByDomainGet == nil {
+				return NewNilValue(inter)
+			}
+			return storageUsedByDomainGet(inter, getLocationRange)
 		case sema.AuthAccountStorageCapacityField:
-			return storageCapacityGet(inter)
+			return storageCapacityGet(inter, getLocationRange)
 		case sema.AuthAccountTypeField:
 			return inter.authAccountTypeFunction(address)
+		case sema.AuthAccountStoragePathsExistField:
+			return inter.authAccountStoragePathsExistFunction(address)
 		case sema.AuthAccountLoadField:
 			return inter.authAccountLoadFunction(address)
 		case sema.AuthAccountCopyField:
@@ -112,6 +142,11 @@ func NewAuthAccountValue(
 			return inter.authAccountUnlinkFunction(address)
 		case sema.AuthAccountGetLinkTargetField:
 			return inter.accountGetLinkTargetFunction(address)
+		case sema.AuthAccountGetLinkTargetTypeField:
+			return inter.accountGetLinkTargetTypeFunction(
+				address,
+				sema.AuthAccountTypeGetLinkTargetTypeFunctionType,
+			)
 		}
 
 		return nil
@@ -155,8 +190,9 @@ func NewPublicAccountValue(
 	address AddressValue,
 	accountBalanceGet func() UFix64Value,
 	accountAvailableBalanceGet func() UFix64Value,
-	storageUsedGet func(interpreter *Interpreter) UInt64Value,
-	storageCapacityGet func(interpreter *Interpreter) UInt64Value,
+	getBalanceFunction FunctionValue,
+	storageUsedGet func(interpreter *Interpreter, getLocationRange func() LocationRange) UInt64Value,
+	storageCapacityGet func(interpreter *Interpreter, getLocationRange func() LocationRange) UInt64Value,
 	keysConstructor func() Value,
 	contractsConstructor func() Value,
 ) Value {
@@ -194,12 +230,19 @@ func NewPublicAccountValue(
 			return accountBalanceGet()
 		case sema.PublicAccountAvailableBalanceField:
 			return accountAvailableBalanceGet()
+		case sema.PublicAccountGetBalanceField:
+			return getBalanceFunction
 		case sema.PublicAccountStorageUsedField:
-			return storageUsedGet(inter)
+			return storageUsedGet(inter, getLocationRange)
 		case sema.PublicAccountStorageCapacityField:
-			return storageCapacityGet(inter)
+			return storageCapacityGet(inter, getLocationRange)
 		case sema.PublicAccountGetTargetLinkField:
 			return inter.accountGetLinkTargetFunction(address)
+		case sema.PublicAccountGetLinkTargetTypeField:
+			return inter.accountGetLinkTargetTypeFunction(
+				address,
+				sema.PublicAccountTypeGetLinkTargetTypeFunctionType,
+			)
 		}
 
 		return nil
@@ -0,0 +1,179 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// AuthAccountCapabilitiesValue
+
+var authAccountCapabilitiesTypeID = sema.AuthAccountCapabilitiesType.ID()
+var authAccountCapabilitiesStaticType StaticType = PrimitiveStaticTypeAuthAccountCapabilities // unmetered
+
+func NewAuthAccountCapabilitiesValue(
+	gauge common.MemoryGauge,
+	address AddressValue,
+	issueFunction FunctionValue,
+	getControllersFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.AuthAccountCapabilitiesTypeIssueFunctionName:          issueFunction,
+		sema.AuthAccountCapabilitiesTypeGetControllersFunctionName: getControllersFunction,
+	}
+
+	computeField := func(
+		name string,
+		interpreter *Interpreter,
+		_ func() LocationRange,
+	) Value {
+		switch name {
+		case sema.AuthAccountCapabilitiesTypePublishFunctionName:
+			return interpreter.authAccountCapabilitiesPublishFunction(address)
+		case sema.AuthAccountCapabilitiesTypeGetFunctionName:
+			return interpreter.authAccountCapabilitiesGetFunction(address)
+		}
+		return nil
+	}
+
+	var str string
+	stringer := func(memoryGauge common.MemoryGauge, _ SeenReferences) string {
+		if str == "" {
+			common.UseMemory(memoryGauge, common.AuthAccountCapabilitiesStringMemoryUsage)
+			addressStr := address.MeteredString(memoryGauge, SeenReferences{})
+			str = fmt.Sprintf("AuthAccount.Capabilities(%s)", addressStr)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		gauge,
+		authAccountCapabilitiesTypeID,
+		authAccountCapabilitiesStaticType,
+		nil,
+		fields,
+		computeField,
+		nil,
+		stringer,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesPublishFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		interpreter,
+		func(invocation Invocation) Value {
+
+			capability, ok := invocation.Arguments[0].(*CapabilityValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			if capability.Address != addressValue {
+				panic(CapabilityAddressPublishingError{
+					CapabilityAddress: capability.Address,
+					AccountAddress:    addressValue,
+					LocationRange:     invocation.GetLocationRange(),
+				})
+			}
+
+			domain := path.Domain.Identifier()
+			identifier := path.Identifier
+
+			if interpreter.storedValueExists(address, domain, identifier) {
+				panic(OverwriteError{
+					Address:       addressValue,
+					Path:          path,
+					LocationRange: invocation.GetLocationRange(),
+				})
+			}
+
+			interpreter.writeStored(address, domain, identifier, capability)
+
+			return NewVoidValue(invocation.Interpreter)
+		},
+		sema.AuthAccountCapabilitiesTypePublishFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesGetFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		interpreter,
+		func(invocation Invocation) Value {
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			wantedBorrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			value := interpreter.ReadStored(address, path.Domain.Identifier(), path.Identifier)
+
+			// Only published capabilities are considered, links are not
+
+			capability, ok := value.(*CapabilityValue)
+			if !ok || capability.BorrowType == nil {
+				return NewNilValue(invocation.Interpreter)
+			}
+
+			capabilityBorrowType := interpreter.MustConvertStaticToSemaType(capability.BorrowType)
+			if !sema.IsSubType(capabilityBorrowType, wantedBorrowType) {
+				return NewNilValue(invocation.Interpreter)
+			}
+
+			return NewSomeValueNonCopying(
+				interpreter,
+				NewIDCapabilityValue(
+					interpreter,
+					capability.ID,
+					capability.Address,
+					capability.Path,
+					ConvertSemaToStaticType(interpreter, wantedBorrowType),
+				),
+			)
+		},
+		sema.AuthAccountCapabilitiesTypeGetFunctionType,
+	)
+}
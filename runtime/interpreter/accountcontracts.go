@@ -37,17 +37,39 @@ func NewAuthAccountContractsValue(
 	gauge common.MemoryGauge,
 	address AddressValue,
 	addFunction FunctionValue,
+	addInferredFunction FunctionValue,
+	addWithResultFunction FunctionValue,
+	addWithInitFunction FunctionValue,
 	updateFunction FunctionValue,
+	updateWithMigrationFunction FunctionValue,
+	updateWithResultFunction FunctionValue,
 	getFunction FunctionValue,
 	removeFunction FunctionValue,
+	removeWithResultFunction FunctionValue,
+	enumTypesFunction FunctionValue,
+	getContractTypeFunction FunctionValue,
+	namesWithPrefixFunction FunctionValue,
+	manifestFunction FunctionValue,
+	updateHistoryFunction FunctionValue,
 	namesGetter ContractNamesGetter,
 ) Value {
 
 	fields := map[string]Value{
-		sema.AuthAccountContractsTypeAddFunctionName:                addFunction,
-		sema.AuthAccountContractsTypeGetFunctionName:                getFunction,
-		sema.AuthAccountContractsTypeRemoveFunctionName:             removeFunction,
-		sema.AuthAccountContractsTypeUpdateExperimentalFunctionName: updateFunction,
+		sema.AuthAccountContractsTypeAddFunctionName:                 addFunction,
+		sema.AuthAccountContractsTypeAddInferredFunctionName:         addInferredFunction,
+		sema.AuthAccountContractsTypeAddWithResultFunctionName:       addWithResultFunction,
+		sema.AuthAccountContractsTypeAddWithInitFunctionName:         addWithInitFunction,
+		sema.AuthAccountContractsTypeGetFunctionName:                 getFunction,
+		sema.AuthAccountContractsTypeRemoveFunctionName:              removeFunction,
+		sema.AuthAccountContractsTypeRemoveWithResultFunctionName:    removeWithResultFunction,
+		sema.AuthAccountContractsTypeEnumTypesFunctionName:           enumTypesFunction,
+		sema.AuthAccountContractsTypeGetContractTypeFunctionName:     getContractTypeFunction,
+		sema.AuthAccountContractsTypeNamesWithPrefixFunctionName:     namesWithPrefixFunction,
+		sema.AuthAccountContractsTypeManifestFunctionName:            manifestFunction,
+		sema.AuthAccountContractsTypeUpdateHistoryFunctionName:       updateHistoryFunction,
+		sema.AuthAccountContractsTypeUpdateExperimentalFunctionName:  updateFunction,
+		sema.AuthAccountContractsTypeUpdateWithMigrationFunctionName: updateWithMigrationFunction,
+		sema.AuthAccountContractsTypeUpdateWithResultFunctionName:    updateWithResultFunction,
 	}
 
 	computeField := func(
@@ -93,11 +115,21 @@ func NewPublicAccountContractsValue(
 	gauge common.MemoryGauge,
 	address AddressValue,
 	getFunction FunctionValue,
+	borrowContractFunction FunctionValue,
+	getContractTypeFunction FunctionValue,
+	namesWithPrefixFunction FunctionValue,
+	manifestFunction FunctionValue,
+	updateHistoryFunction FunctionValue,
 	namesGetter ContractNamesGetter,
 ) Value {
 
 	fields := map[string]Value{
-		sema.PublicAccountContractsTypeGetFunctionName: getFunction,
+		sema.PublicAccountContractsTypeGetFunctionName:             getFunction,
+		sema.PublicAccountContractsTypeBorrowContractFunctionName:  borrowContractFunction,
+		sema.PublicAccountContractsTypeGetContractTypeFunctionName: getContractTypeFunction,
+		sema.PublicAccountContractsTypeNamesWithPrefixFunctionName: namesWithPrefixFunction,
+		sema.PublicAccountContractsTypeManifestFunctionName:        manifestFunction,
+		sema.PublicAccountContractsTypeUpdateHistoryFunctionName:   updateHistoryFunction,
 	}
 
 	computeField := func(
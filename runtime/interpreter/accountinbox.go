@@ -0,0 +1,124 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// InboxStorageDomain is the storage domain of the values published in the inbox of an account.
+// The recipients of the published values are stored in the inboxRecipientStorageDomain, under the same name.
+const InboxStorageDomain = "inbox"
+const inboxRecipientStorageDomain = "inboxRecipient"
+
+// AuthAccountInboxValue
+
+var authAccountInboxTypeID = sema.AuthAccountInboxType.ID()
+var authAccountInboxStaticType StaticType = PrimitiveStaticTypeAuthAccountInbox // unmetered
+
+func NewAuthAccountInboxValue(
+	gauge common.MemoryGauge,
+	address AddressValue,
+	publishFunction FunctionValue,
+	unpublishFunction FunctionValue,
+	claimFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.AuthAccountInboxTypePublishFunctionName:   publishFunction,
+		sema.AuthAccountInboxTypeUnpublishFunctionName: unpublishFunction,
+		sema.AuthAccountInboxTypeClaimFunctionName:     claimFunction,
+	}
+
+	var str string
+	stringer := func(memoryGauge common.MemoryGauge, _ SeenReferences) string {
+		if str == "" {
+			common.UseMemory(memoryGauge, common.AuthAccountInboxStringMemoryUsage)
+			addressStr := address.MeteredString(memoryGauge, SeenReferences{})
+			str = fmt.Sprintf("AuthAccount.Inbox(%s)", addressStr)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		gauge,
+		authAccountInboxTypeID,
+		authAccountInboxStaticType,
+		nil,
+		fields,
+		nil,
+		nil,
+		stringer,
+	)
+}
+
+// ReadInboxValue returns the capability published under the given name in the inbox of the given provider account,
+// and its recipient, if any
+func (interpreter *Interpreter) ReadInboxValue(
+	provider common.Address,
+	name string,
+) (
+	capability *CapabilityValue,
+	recipient AddressValue,
+	ok bool,
+) {
+	value := interpreter.ReadStored(provider, InboxStorageDomain, name)
+	if value == nil {
+		return nil, AddressValue{}, false
+	}
+
+	capability, ok = value.(*CapabilityValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	recipient, ok = interpreter.ReadStored(provider, inboxRecipientStorageDomain, name).(AddressValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return capability, recipient, true
+}
+
+// WriteInboxValue publishes the given capability under the given name in the inbox of the given provider account,
+// for the given recipient.
+// The capability must already be transferred to the provider account
+func (interpreter *Interpreter) WriteInboxValue(
+	provider common.Address,
+	name string,
+	capability *CapabilityValue,
+	recipient AddressValue,
+) {
+	interpreter.writeStored(provider, InboxStorageDomain, name, capability)
+	interpreter.writeStored(provider, inboxRecipientStorageDomain, name, recipient)
+}
+
+// RemoveInboxValue removes the capability published under the given name
+// from the inbox of the given provider account
+func (interpreter *Interpreter) RemoveInboxValue(
+	provider common.Address,
+	name string,
+) {
+	interpreter.writeStored(provider, InboxStorageDomain, name, nil)
+	interpreter.writeStored(provider, inboxRecipientStorageDomain, name, nil)
+}
@@ -30,6 +30,7 @@ var accountKeyFieldNames = []string{
 	sema.AccountKeyHashAlgoField,
 	sema.AccountKeyWeightField,
 	sema.AccountKeyIsRevokedField,
+	sema.AccountKeyIsActiveField,
 }
 
 // NewAccountKeyValue constructs an AccountKey value.
@@ -40,6 +41,7 @@ func NewAccountKeyValue(
 	hashAlgo Value,
 	weight UFix64Value,
 	isRevoked BoolValue,
+	isActive BoolValue,
 ) *SimpleCompositeValue {
 	fields := map[string]Value{
 		sema.AccountKeyKeyIndexField:  keyIndex,
@@ -47,6 +49,7 @@ func NewAccountKeyValue(
 		sema.AccountKeyHashAlgoField:  hashAlgo,
 		sema.AccountKeyWeightField:    weight,
 		sema.AccountKeyIsRevokedField: isRevoked,
+		sema.AccountKeyIsActiveField:  isActive,
 	}
 
 	return NewSimpleCompositeValue(
@@ -36,13 +36,25 @@ func NewAuthAccountKeysValue(
 	address AddressValue,
 	addFunction FunctionValue,
 	getFunction FunctionValue,
+	findFunction FunctionValue,
 	revokeFunction FunctionValue,
+	rotateFunction FunctionValue,
+	setActiveFunction FunctionValue,
+	totalWeightFunction FunctionValue,
+	meetsThresholdFunction FunctionValue,
+	activeKeyIndicesFunction FunctionValue,
 ) Value {
 
 	fields := map[string]Value{
-		sema.AccountKeysAddFunctionName:    addFunction,
-		sema.AccountKeysGetFunctionName:    getFunction,
-		sema.AccountKeysRevokeFunctionName: revokeFunction,
+		sema.AccountKeysAddFunctionName:              addFunction,
+		sema.AccountKeysGetFunctionName:              getFunction,
+		sema.AccountKeysFindFunctionName:             findFunction,
+		sema.AccountKeysRevokeFunctionName:           revokeFunction,
+		sema.AccountKeysRotateFunctionName:           rotateFunction,
+		sema.AccountKeysSetActiveFunctionName:        setActiveFunction,
+		sema.AccountKeysTotalWeightFunctionName:      totalWeightFunction,
+		sema.AccountKeysMeetsThresholdFunctionName:   meetsThresholdFunction,
+		sema.AccountKeysActiveKeyIndicesFunctionName: activeKeyIndicesFunction,
 	}
 
 	var str string
@@ -77,10 +89,18 @@ func NewPublicAccountKeysValue(
 	gauge common.MemoryGauge,
 	address AddressValue,
 	getFunction FunctionValue,
+	findFunction FunctionValue,
+	totalWeightFunction FunctionValue,
+	meetsThresholdFunction FunctionValue,
+	activeKeyIndicesFunction FunctionValue,
 ) Value {
 
 	fields := map[string]Value{
-		sema.AccountKeysGetFunctionName: getFunction,
+		sema.AccountKeysGetFunctionName:              getFunction,
+		sema.AccountKeysFindFunctionName:             findFunction,
+		sema.AccountKeysTotalWeightFunctionName:      totalWeightFunction,
+		sema.AccountKeysMeetsThresholdFunctionName:   meetsThresholdFunction,
+		sema.AccountKeysActiveKeyIndicesFunctionName: activeKeyIndicesFunction,
 	}
 
 	var str string
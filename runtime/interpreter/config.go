@@ -35,6 +35,8 @@ type Config struct {
 	OnInvokedFunctionReturn OnInvokedFunctionReturnFunc
 	// OnRecordTrace is triggered when a trace is recorded.
 	OnRecordTrace OnRecordTraceFunc
+	// OnStorageAccess is triggered when a value is read from or written to account storage.
+	OnStorageAccess OnStorageAccessFunc
 	// OnResourceOwnerChange is triggered when the owner of a resource changes.
 	OnResourceOwnerChange OnResourceOwnerChangeFunc
 	// OnMeterComputation sets the function that is triggered when a computation is about to happen.
@@ -0,0 +1,59 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ContractInfoValue
+
+var contractInfoTypeID = sema.ContractInfoType.ID()
+var contractInfoStaticType StaticType = CompositeStaticType{
+	QualifiedIdentifier: sema.ContractInfoType.Identifier,
+	TypeID:              contractInfoTypeID,
+} // unmetered
+var contractInfoFieldNames = []string{
+	sema.ContractInfoTypeNameFieldName,
+	sema.ContractInfoTypeCodeHashFieldName,
+	sema.ContractInfoTypeCodeSizeFieldName,
+}
+
+// NewContractInfoValue constructs a ContractInfo value.
+func NewContractInfoValue(
+	inter *Interpreter,
+	name *StringValue,
+	codeHash *ArrayValue,
+	codeSize IntValue,
+) *SimpleCompositeValue {
+	return NewSimpleCompositeValue(
+		inter,
+		contractInfoTypeID,
+		contractInfoStaticType,
+		contractInfoFieldNames,
+		map[string]Value{
+			sema.ContractInfoTypeNameFieldName:     name,
+			sema.ContractInfoTypeCodeHashFieldName: codeHash,
+			sema.ContractInfoTypeCodeSizeFieldName: codeSize,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,59 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ContractVersionValue
+
+var contractVersionTypeID = sema.ContractVersionType.ID()
+var contractVersionStaticType StaticType = CompositeStaticType{
+	QualifiedIdentifier: sema.ContractVersionType.Identifier,
+	TypeID:              contractVersionTypeID,
+} // unmetered
+var contractVersionFieldNames = []string{
+	sema.ContractVersionTypeVersionFieldName,
+	sema.ContractVersionTypeCodeHashFieldName,
+	sema.ContractVersionTypeTimestampFieldName,
+}
+
+// NewContractVersionValue constructs a ContractVersion value.
+func NewContractVersionValue(
+	inter *Interpreter,
+	version UInt64Value,
+	codeHash *ArrayValue,
+	timestamp UFix64Value,
+) *SimpleCompositeValue {
+	return NewSimpleCompositeValue(
+		inter,
+		contractVersionTypeID,
+		contractVersionStaticType,
+		contractVersionFieldNames,
+		map[string]Value{
+			sema.ContractVersionTypeVersionFieldName:   version,
+			sema.ContractVersionTypeCodeHashFieldName:  codeHash,
+			sema.ContractVersionTypeTimestampFieldName: timestamp,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
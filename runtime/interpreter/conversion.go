@@ -34,14 +34,27 @@ func ByteArrayValueToByteSlice(memoryGauge common.MemoryGauge, value Value) ([]b
 	result := make([]byte, 0, array.Count())
 
 	var err error
+	index := 0
 	array.Iterate(memoryGauge, func(element Value) (resume bool) {
 		var b byte
 		b, err = ByteValueToByte(memoryGauge, element)
 		if err != nil {
+			// The type of the element can only be determined if an interpreter is available
+			var elementType StaticType
+			if inter, ok := memoryGauge.(*Interpreter); ok {
+				elementType = element.StaticType(inter)
+			}
+
+			err = InvalidByteArrayElementError{
+				Index:       index,
+				ElementType: elementType,
+				Err:         err,
+			}
 			return false
 		}
 
 		result = append(result, b)
+		index++
 
 		return true
 	})
@@ -70,6 +70,37 @@ func TestByteArrayValueToByteSlice(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid element", func(t *testing.T) {
+
+		inter := newTestInterpreter(t)
+
+		value := NewArrayValue(
+			inter,
+			ReturnEmptyLocationRange,
+			VariableSizedStaticType{
+				Type: PrimitiveStaticTypeAnyStruct,
+			},
+			common.Address{},
+			NewUnmeteredUInt8Value(1),
+			NewUnmeteredUInt8Value(2),
+			NewUnmeteredStringValue("test"),
+			NewUnmeteredUInt64Value(500),
+		)
+
+		_, err := ByteArrayValueToByteSlice(inter, value)
+		require.Error(t, err)
+
+		var elementErr InvalidByteArrayElementError
+		require.ErrorAs(t, err, &elementErr)
+
+		require.Equal(t, 2, elementErr.Index)
+		require.Equal(t, PrimitiveStaticTypeString, elementErr.ElementType)
+		require.EqualError(t,
+			err,
+			"invalid byte array element at index 2 of type `String`: value is not an integer",
+		)
+	})
+
 	t.Run("valid", func(t *testing.T) {
 
 		inter := newTestInterpreter(t)
@@ -873,7 +873,8 @@ func (d StorableDecoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// Path-based capabilities are encoded without an ID
+	if size != expectedLength && size != encodedPathCapabilityValueLength {
 		return nil, errors.NewUnexpectedError(
 			"invalid capability encoding: expected [%d]any, got [%d]any",
 			expectedLength,
@@ -940,7 +941,20 @@ func (d StorableDecoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, errors.NewUnexpectedError("invalid capability borrow type encoding: %w", err)
 	}
 
-	return NewCapabilityValue(d.memoryGauge, address, pathValue, borrowType), nil
+	if size == encodedPathCapabilityValueLength {
+		return NewCapabilityValue(d.memoryGauge, address, pathValue, borrowType), nil
+	}
+
+	// Decode ID at array index encodedCapabilityValueIDFieldKey
+	id, err := d.decoder.DecodeUint64()
+	if err != nil {
+		return nil, errors.NewUnexpectedError("invalid capability ID encoding: %w", err)
+	}
+	idValue := NewUInt64Value(d.memoryGauge, func() uint64 {
+		return id
+	})
+
+	return NewIDCapabilityValue(d.memoryGauge, idValue, address, pathValue, borrowType), nil
 }
 
 func (d StorableDecoder) decodeLink() (LinkValue, error) {
@@ -19,6 +19,7 @@
 package interpreter
 
 import (
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -31,6 +32,13 @@ var deployedContractFieldNames = []string{
 	sema.DeployedContractTypeCodeFieldName,
 }
 
+// lazyDeployedContractFieldNames are the field names of a DeployedContract value
+// whose code was not fetched yet. The code is a computed field until it is fetched
+var lazyDeployedContractFieldNames = []string{
+	sema.DeployedContractTypeAddressFieldName,
+	sema.DeployedContractTypeNameFieldName,
+}
+
 func NewDeployedContractValue(
 	inter *Interpreter,
 	address AddressValue,
@@ -52,3 +60,61 @@ func NewDeployedContractValue(
 		nil,
 	)
 }
+
+// NewLazyDeployedContractValue constructs a DeployedContract value,
+// whose code is only fetched using the given function when the code field is first accessed.
+func NewLazyDeployedContractValue(
+	inter *Interpreter,
+	address AddressValue,
+	name *StringValue,
+	getCode func() *ArrayValue,
+) *SimpleCompositeValue {
+
+	var value *SimpleCompositeValue
+
+	// loadCode fetches the code, if it was not fetched yet.
+	// Once the code is loaded, the value behaves like a value constructed with NewDeployedContractValue
+	loadCode := func() Value {
+		code, ok := value.Fields[sema.DeployedContractTypeCodeFieldName]
+		if ok {
+			return code
+		}
+
+		code = getCode()
+
+		value.Fields[sema.DeployedContractTypeCodeFieldName] = code
+		value.FieldNames = deployedContractFieldNames
+		value.stringer = nil
+
+		return code
+	}
+
+	computeField := func(name string, _ *Interpreter, _ func() LocationRange) Value {
+		if name == sema.DeployedContractTypeCodeFieldName {
+			return loadCode()
+		}
+		return nil
+	}
+
+	// The string representation includes the code
+	stringer := func(memoryGauge common.MemoryGauge, seenReferences SeenReferences) string {
+		loadCode()
+		return value.MeteredString(memoryGauge, seenReferences)
+	}
+
+	value = NewSimpleCompositeValue(
+		inter,
+		sema.DeployedContractType.TypeID,
+		deployedContractStaticType,
+		lazyDeployedContractFieldNames,
+		map[string]Value{
+			sema.DeployedContractTypeAddressFieldName: address,
+			sema.DeployedContractTypeNameFieldName:    name,
+		},
+		computeField,
+		nil,
+		stringer,
+	)
+
+	return value
+}
@@ -0,0 +1,62 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// DeploymentResultValue
+
+var deploymentResultTypeID = sema.DeploymentResultType.ID()
+var deploymentResultStaticType StaticType = CompositeStaticType{
+	QualifiedIdentifier: sema.DeploymentResultType.Identifier,
+	TypeID:              deploymentResultTypeID,
+} // unmetered
+var deploymentResultFieldNames = []string{
+	sema.DeploymentResultTypeContractFieldName,
+	sema.DeploymentResultTypeWasUpdateFieldName,
+	sema.DeploymentResultTypeOldCodeHashFieldName,
+	sema.DeploymentResultTypeCodeHashFieldName,
+}
+
+// NewDeploymentResultValue constructs a DeploymentResult value.
+func NewDeploymentResultValue(
+	inter *Interpreter,
+	contract *SimpleCompositeValue,
+	wasUpdate BoolValue,
+	oldCodeHash OptionalValue,
+	codeHash *ArrayValue,
+) *SimpleCompositeValue {
+	return NewSimpleCompositeValue(
+		inter,
+		deploymentResultTypeID,
+		deploymentResultStaticType,
+		deploymentResultFieldNames,
+		map[string]Value{
+			sema.DeploymentResultTypeContractFieldName:    contract,
+			sema.DeploymentResultTypeWasUpdateFieldName:   wasUpdate,
+			sema.DeploymentResultTypeOldCodeHashFieldName: oldCodeHash,
+			sema.DeploymentResultTypeCodeHashFieldName:    codeHash,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
@@ -710,12 +710,18 @@ const (
 	// encodedCapabilityValueAddressFieldKey    uint64 = 0
 	// encodedCapabilityValuePathFieldKey       uint64 = 1
 	// encodedCapabilityValueBorrowTypeFieldKey uint64 = 2
+	// encodedCapabilityValueIDFieldKey         uint64 = 3
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCapabilityValueLength MUST be updated when new element is added.
 	// It is used to verify encoded capability length during decoding.
-	encodedCapabilityValueLength = 3
+	encodedCapabilityValueLength = 4
+
+	// encodedPathCapabilityValueLength is the length of encoded path-based capabilities,
+	// which have no ID. They are encoded without the ID element,
+	// so the encoding of existing capabilities stays unchanged.
+	encodedPathCapabilityValueLength = 3
 )
 
 // Encode encodes CapabilityStorable as
@@ -725,15 +731,26 @@ const (
 //					encodedCapabilityValueAddressFieldKey:    AddressValue(v.Address),
 // 					encodedCapabilityValuePathFieldKey:       PathValue(v.Path),
 // 					encodedCapabilityValueBorrowTypeFieldKey: StaticType(v.BorrowType),
+// 					encodedCapabilityValueIDFieldKey:         uint64(v.ID), // only if non-zero
 // 				},
 // }
 func (v *CapabilityValue) Encode(e *atree.Encoder) error {
+	hasID := v.ID != 0
+
 	// Encode tag number and array head
+	var arrayHead byte
+	if hasID {
+		// array, 4 items follow
+		arrayHead = 0x84
+	} else {
+		// array, 3 items follow
+		arrayHead = 0x83
+	}
+
 	err := e.CBOR.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCapabilityValue,
-		// array, 3 items follow
-		0x83,
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -752,7 +769,17 @@ func (v *CapabilityValue) Encode(e *atree.Encoder) error {
 	}
 
 	// Encode borrow type at array index encodedCapabilityValueBorrowTypeFieldKey
-	return EncodeStaticType(e.CBOR, v.BorrowType)
+	err = EncodeStaticType(e.CBOR, v.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	if !hasID {
+		return nil
+	}
+
+	// Encode ID at array index encodedCapabilityValueIDFieldKey
+	return e.CBOR.EncodeUint64(uint64(v.ID))
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
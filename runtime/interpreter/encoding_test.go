@@ -3152,6 +3152,57 @@ func TestEncodeDecodeCapabilityValue(t *testing.T) {
 		)
 	})
 
+	t.Run("storage path, typed capability with ID", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := &CapabilityValue{
+			Address: NewUnmeteredAddressValueFromBytes([]byte{0x2}),
+			Path: PathValue{
+				Domain:     common.PathDomainStorage,
+				Identifier: "foo",
+			},
+			BorrowType: PrimitiveStaticTypeBool,
+			ID:         4,
+		}
+
+		encoded := []byte{
+			// tag
+			0xd8, CBORTagCapabilityValue,
+			// array, 4 items follow
+			0x84,
+			// tag for address
+			0xd8, CBORTagAddressValue,
+			// byte sequence, length 1
+			0x41,
+			// address
+			0x02,
+			// tag for address
+			0xd8, CBORTagPathValue,
+			// array, 2 items follow
+			0x82,
+			// positive integer 1
+			0x1,
+			// UTF-8 string, length 3
+			0x63,
+			// f, o, o
+			0x66, 0x6f, 0x6f,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// bool
+			0x6,
+			// positive integer 4
+			0x4,
+		}
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("larger than max inline size due to path", func(t *testing.T) {
 
 		t.Parallel()
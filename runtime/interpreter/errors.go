@@ -19,6 +19,7 @@
 package interpreter
 
 import (
+	goErrors "errors"
 	"fmt"
 	"strings"
 
@@ -424,6 +425,26 @@ func (e OverwriteError) Error() string {
 	)
 }
 
+// CapabilityAddressPublishingError
+//
+type CapabilityAddressPublishingError struct {
+	CapabilityAddress AddressValue
+	AccountAddress    AddressValue
+	LocationRange
+}
+
+var _ errors.UserError = CapabilityAddressPublishingError{}
+
+func (CapabilityAddressPublishingError) IsUserError() {}
+
+func (e CapabilityAddressPublishingError) Error() string {
+	return fmt.Sprintf(
+		"cannot publish capability of account %s in account %s",
+		e.CapabilityAddress.String(),
+		e.AccountAddress.String(),
+	)
+}
+
 // CyclicLinkError
 //
 type CyclicLinkError struct {
@@ -473,6 +494,41 @@ func (e ArrayIndexOutOfBoundsError) Error() string {
 	)
 }
 
+// InvalidByteArrayElementError is reported when an array is converted to bytes,
+// but one of its elements is not a byte
+//
+type InvalidByteArrayElementError struct {
+	Index int
+	// ElementType is the type of the element, if it is known
+	ElementType StaticType
+	Err         error
+}
+
+var _ errors.UserError = InvalidByteArrayElementError{}
+
+func (InvalidByteArrayElementError) IsUserError() {}
+
+func (e InvalidByteArrayElementError) Error() string {
+	if e.ElementType == nil {
+		return fmt.Sprintf(
+			"invalid byte array element at index %d: %s",
+			e.Index,
+			e.Err.Error(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"invalid byte array element at index %d of type `%s`: %s",
+		e.Index,
+		e.ElementType,
+		e.Err.Error(),
+	)
+}
+
+func (e InvalidByteArrayElementError) Unwrap() error {
+	return e.Err
+}
+
 // ArraySliceIndicesError
 //
 type ArraySliceIndicesError struct {
@@ -779,6 +835,14 @@ func (e InvalidOperandsError) Error() string {
 	)
 }
 
+// RedactedPublicKeyError is an error about a public key which does not include the key itself,
+// e.g. because the error may be logged.
+// An InvalidPublicKeyError caused by such an error does not include the key either.
+type RedactedPublicKeyError interface {
+	error
+	IsRedactedPublicKeyError()
+}
+
 // InvalidPublicKeyError is reported during PublicKey creation, if the PublicKey is invalid.
 type InvalidPublicKeyError struct {
 	PublicKey *ArrayValue
@@ -791,6 +855,12 @@ var _ errors.UserError = InvalidPublicKeyError{}
 func (InvalidPublicKeyError) IsUserError() {}
 
 func (e InvalidPublicKeyError) Error() string {
+	// The public key is not included if the cause redacts it
+	var redactedErr RedactedPublicKeyError
+	if goErrors.As(e.Err, &redactedErr) {
+		return fmt.Sprintf("invalid public key: %s", e.Err)
+	}
+
 	return fmt.Sprintf("invalid public key: %s, err: %s", e.PublicKey, e.Err)
 }
 
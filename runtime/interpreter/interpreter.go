@@ -23,6 +23,7 @@ import (
 	goErrors "errors"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 	"unicode/utf8"
 
@@ -94,6 +95,15 @@ type OnRecordTraceFunc func(
 	attrs []attribute.KeyValue,
 )
 
+// OnStorageAccessFunc is a function that is triggered when a value is read from or written to account storage.
+type OnStorageAccessFunc func(
+	inter *Interpreter,
+	address common.Address,
+	domain string,
+	identifier string,
+	write bool,
+)
+
 // OnResourceOwnerChangeFunc is a function that is triggered when a resource's owner changes.
 type OnResourceOwnerChangeFunc func(
 	inter *Interpreter,
@@ -2184,6 +2194,8 @@ func (interpreter *Interpreter) storedValueExists(
 	domain string,
 	identifier string,
 ) bool {
+	interpreter.recordStorageAccess(storageAddress, domain, identifier, false)
+
 	accountStorage := interpreter.Config.Storage.GetStorageMap(storageAddress, domain, false)
 	if accountStorage == nil {
 		return false
@@ -2196,6 +2208,8 @@ func (interpreter *Interpreter) ReadStored(
 	domain string,
 	identifier string,
 ) Value {
+	interpreter.recordStorageAccess(storageAddress, domain, identifier, false)
+
 	accountStorage := interpreter.Config.Storage.GetStorageMap(storageAddress, domain, false)
 	if accountStorage == nil {
 		return nil
@@ -2209,17 +2223,35 @@ func (interpreter *Interpreter) writeStored(
 	identifier string,
 	value Value,
 ) {
+	interpreter.recordStorageAccess(storageAddress, domain, identifier, true)
+
 	accountStorage := interpreter.Config.Storage.GetStorageMap(storageAddress, domain, true)
 	accountStorage.WriteValue(interpreter, identifier, value)
 	interpreter.recordStorageMutation()
 }
 
+func (interpreter *Interpreter) recordStorageAccess(
+	storageAddress common.Address,
+	domain string,
+	identifier string,
+	write bool,
+) {
+	onStorageAccess := interpreter.Config.OnStorageAccess
+	if onStorageAccess == nil {
+		return
+	}
+
+	onStorageAccess(interpreter, storageAddress, domain, identifier, write)
+}
+
 type ValueConverterDeclaration struct {
 	name         string
 	convert      func(*Interpreter, Value) Value
 	min          Value
 	max          Value
 	functionType *sema.FunctionType
+	// functions are the additional functions of the converter function, e.g. `Address.fromString`
+	functions map[string]*HostFunctionValue
 }
 
 // It would be nice if return types in Go's function types would be covariant
@@ -2407,6 +2439,9 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(interpreter *Interpreter, value Value) Value {
 			return ConvertAddress(interpreter, value)
 		},
+		functions: map[string]*HostFunctionValue{
+			sema.AddressTypeFromStringFunctionName: addressFromStringFunction,
+		},
 	},
 	{
 		name:         sema.PublicPathType.Name,
@@ -2425,6 +2460,31 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 	},
 }
 
+// addressFromStringFunction is the function `Address.fromString`.
+// It parses an address from a hexadecimal string, with or without the `0x` prefix,
+// and returns nil if the string is not a valid address
+var addressFromStringFunction = NewUnmeteredHostFunctionValue(
+	func(invocation Invocation) Value {
+		argument, ok := invocation.Arguments[0].(*StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+
+		address, ok := ParseAddress(argument.Str)
+		if !ok {
+			return NewNilValue(inter)
+		}
+
+		return NewSomeValueNonCopying(
+			inter,
+			NewAddressValue(inter, address),
+		)
+	},
+	sema.AddressTypeFromStringFunctionType,
+)
+
 func lookupInterface(interpreter *Interpreter, typeID string) (*sema.InterfaceType, error) {
 	location, qualifiedIdentifier, err := common.DecodeTypeID(interpreter, typeID)
 	// if the typeID is invalid, return nil
@@ -2755,6 +2815,10 @@ var converterFunctionValues = func() []converterFunction {
 			addMember(sema.NumberTypeMaxFieldName, declaration.max)
 		}
 
+		for name, function := range declaration.functions {
+			addMember(name, function)
+		}
+
 		converterFuncValues[index] = converterFunction{
 			name:      declaration.name,
 			converter: converterFunctionValue,
@@ -3110,14 +3174,27 @@ func (interpreter *Interpreter) domainPaths(address common.Address, domain commo
 	if storageMap == nil {
 		return []Value{}
 	}
-	iterator := storageMap.Iterator(interpreter)
-	values := make([]Value, 0, storageMap.Count())
-	for key := iterator.NextKey(); key != ""; key = iterator.NextKey() {
+	keys := interpreter.sortedStorageMapKeys(storageMap)
+	values := make([]Value, 0, len(keys))
+	for _, key := range keys {
 		values = append(values, NewPathValue(interpreter, domain, key))
 	}
 	return values
 }
 
+// sortedStorageMapKeys returns the keys of the given storage map, sorted.
+// The iteration order of the storage map depends on its internal layout,
+// so the keys are sorted to ensure that iteration is deterministic and reproducible
+func (interpreter *Interpreter) sortedStorageMapKeys(storageMap *StorageMap) []string {
+	iterator := storageMap.Iterator(interpreter)
+	keys := make([]string, 0, storageMap.Count())
+	for key := iterator.NextKey(); key != ""; key = iterator.NextKey() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (interpreter *Interpreter) accountPaths(addressValue AddressValue, getLocationRange func() LocationRange, domain common.PathDomain, pathType StaticType) *ArrayValue {
 	address := addressValue.ToAddress()
 	values := interpreter.domainPaths(address, domain)
@@ -3166,7 +3243,10 @@ func (interpreter *Interpreter) newStorageIterationFunction(addressValue Address
 				// if nothing is stored, no iteration is required
 				return NewVoidValue(inter)
 			}
-			storageIterator := storageMap.Iterator(interpreter)
+			// Iterate over the paths in sorted order.
+			// Only the keys are collected up front, the values are read one at a time,
+			// so values after the point where iteration is stopped are never loaded
+			keys := interpreter.sortedStorageMapKeys(storageMap)
 
 			invocationTypeParams := []sema.Type{pathType, sema.MetaType}
 
@@ -3176,7 +3256,12 @@ func (interpreter *Interpreter) newStorageIterationFunction(addressValue Address
 				inter.sharedState.inStorageIteration = inIteration
 			}()
 
-			for key, value := storageIterator.Next(); key != "" && value != nil; key, value = storageIterator.Next() {
+			for _, key := range keys {
+				value := storageMap.ReadValue(interpreter, key)
+				if value == nil {
+					continue
+				}
+
 				pathValue := NewPathValue(inter, domain, key)
 				runtimeType := NewTypeValue(inter, value.StaticType(inter))
 
@@ -3306,6 +3391,59 @@ func (interpreter *Interpreter) authAccountTypeFunction(addressValue AddressValu
 	)
 }
 
+func (interpreter *Interpreter) authAccountStoragePathsExistFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		interpreter,
+		func(invocation Invocation) Value {
+			inter := invocation.Interpreter
+
+			pathsValue, ok := invocation.Arguments[0].(*ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// All paths are in the same domain,
+			// so the storage map only needs to be loaded once
+
+			domain := common.PathDomainStorage.Identifier()
+			storageMap := inter.Config.Storage.GetStorageMap(address, domain, false)
+
+			count := pathsValue.Count()
+			index := 0
+
+			return NewArrayValueWithIterator(
+				inter,
+				NewVariableSizedStaticType(inter, PrimitiveStaticTypeBool),
+				common.Address{},
+				uint64(count),
+				func() Value {
+					if index >= count {
+						return nil
+					}
+
+					path, ok := pathsValue.Get(inter, invocation.GetLocationRange, index).(PathValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					index++
+
+					inter.recordStorageAccess(address, domain, path.Identifier, false)
+
+					exists := storageMap != nil &&
+						storageMap.ValueExists(path.Identifier)
+
+					return NewBoolValue(inter, exists)
+				},
+			)
+		},
+		sema.AuthAccountTypeStoragePathsExistFunctionType,
+	)
+}
+
 func (interpreter *Interpreter) authAccountLoadFunction(addressValue AddressValue) *HostFunctionValue {
 	return interpreter.authAccountReadFunction(addressValue, true)
 }
@@ -3535,6 +3673,42 @@ func (interpreter *Interpreter) accountGetLinkTargetFunction(addressValue Addres
 	)
 }
 
+func (interpreter *Interpreter) accountGetLinkTargetTypeFunction(
+	addressValue AddressValue,
+	functionType *sema.FunctionType,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		interpreter,
+		func(invocation Invocation) Value {
+
+			capabilityPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			domain := capabilityPath.Domain.Identifier()
+			identifier := capabilityPath.Identifier
+
+			value := interpreter.ReadStored(address, domain, identifier)
+
+			link, ok := value.(LinkValue)
+			if !ok {
+				return NewNilValue(invocation.Interpreter)
+			}
+
+			return NewSomeValueNonCopying(
+				invocation.Interpreter,
+				NewTypeValue(invocation.Interpreter, link.Type),
+			)
+		},
+		functionType,
+	)
+}
+
 func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressValue) *HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -199,6 +199,8 @@ const (
 	PrimitiveStaticTypeAuthAccountKeys
 	PrimitiveStaticTypePublicAccountKeys
 	PrimitiveStaticTypeAccountKey
+	PrimitiveStaticTypeAuthAccountCapabilities
+	PrimitiveStaticTypeAuthAccountInbox
 
 	// !!! *WARNING* !!!
 	// ADD NEW TYPES *BEFORE* THIS WARNING.
@@ -278,7 +280,9 @@ func (t PrimitiveStaticType) elementSize() uint {
 		PrimitiveStaticTypePublicAccountContracts,
 		PrimitiveStaticTypeAuthAccountKeys,
 		PrimitiveStaticTypePublicAccountKeys,
-		PrimitiveStaticTypeAccountKey:
+		PrimitiveStaticTypeAccountKey,
+		PrimitiveStaticTypeAuthAccountCapabilities,
+		PrimitiveStaticTypeAuthAccountInbox:
 		return UnknownElementSize
 	}
 	return UnknownElementSize
@@ -419,6 +423,10 @@ func (i PrimitiveStaticType) SemaType() sema.Type {
 		return sema.PublicAccountKeysType
 	case PrimitiveStaticTypeAccountKey:
 		return sema.AccountKeyType
+	case PrimitiveStaticTypeAuthAccountCapabilities:
+		return sema.AuthAccountCapabilitiesType
+	case PrimitiveStaticTypeAuthAccountInbox:
+		return sema.AuthAccountInboxType
 	default:
 		panic(errors.NewUnreachableError())
 	}
@@ -547,6 +555,10 @@ func ConvertSemaToPrimitiveStaticType(
 		typ = PrimitiveStaticTypePublicAccountKeys
 	case sema.AccountKeyType:
 		typ = PrimitiveStaticTypeAccountKey
+	case sema.AuthAccountCapabilitiesType:
+		typ = PrimitiveStaticTypeAuthAccountCapabilities
+	case sema.AuthAccountInboxType:
+		typ = PrimitiveStaticTypeAuthAccountInbox
 	case sema.StringType:
 		typ = PrimitiveStaticTypeString
 	}
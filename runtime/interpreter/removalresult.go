@@ -0,0 +1,56 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// RemovalResultValue
+
+var removalResultTypeID = sema.RemovalResultType.ID()
+var removalResultStaticType StaticType = CompositeStaticType{
+	QualifiedIdentifier: sema.RemovalResultType.Identifier,
+	TypeID:              removalResultTypeID,
+} // unmetered
+var removalResultFieldNames = []string{
+	sema.RemovalResultTypeContractFieldName,
+	sema.RemovalResultTypeCodeHashFieldName,
+}
+
+// NewRemovalResultValue constructs a RemovalResult value.
+func NewRemovalResultValue(
+	inter *Interpreter,
+	contract *SimpleCompositeValue,
+	codeHash *ArrayValue,
+) *SimpleCompositeValue {
+	return NewSimpleCompositeValue(
+		inter,
+		removalResultTypeID,
+		removalResultStaticType,
+		removalResultFieldNames,
+		map[string]Value{
+			sema.RemovalResultTypeContractFieldName: contract,
+			sema.RemovalResultTypeCodeHashFieldName: codeHash,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
@@ -44,6 +44,7 @@ type SimpleCompositeValue struct {
 
 var _ Value = &SimpleCompositeValue{}
 var _ MemberAccessibleValue = &SimpleCompositeValue{}
+var _ EquatableValue = &SimpleCompositeValue{}
 
 func NewSimpleCompositeValue(
 	gauge common.MemoryGauge,
@@ -219,6 +220,31 @@ func (v *SimpleCompositeValue) ConformsToStaticType(
 	return true
 }
 
+// Equal returns true if the other value is an equal simple composite value.
+// Only account values are equatable: they are equal if they have the same address.
+func (v *SimpleCompositeValue) Equal(interpreter *Interpreter, getLocationRange func() LocationRange, other Value) bool {
+	otherComposite, ok := other.(*SimpleCompositeValue)
+	if !ok || v.TypeID != otherComposite.TypeID {
+		return false
+	}
+
+	switch v.TypeID {
+	case authAccountTypeID, publicAccountTypeID:
+		address, ok := v.Fields[sema.AuthAccountAddressField].(AddressValue)
+		if !ok {
+			return false
+		}
+		return address.Equal(
+			interpreter,
+			getLocationRange,
+			otherComposite.Fields[sema.AuthAccountAddressField],
+		)
+
+	default:
+		return false
+	}
+}
+
 func (v *SimpleCompositeValue) Storable(_ atree.SlabStorage, _ atree.Address, _ uint64) (atree.Storable, error) {
 	return NonStorable{Value: v}, nil
 }
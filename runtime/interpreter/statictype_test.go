@@ -964,6 +964,6 @@ func TestPrimitiveStaticTypeCount(t *testing.T) {
 	t.Parallel()
 
 	t.Run("No new types added in between", func(t *testing.T) {
-		require.Equal(t, byte(98), byte(PrimitiveStaticType_Count))
+		require.Equal(t, byte(100), byte(PrimitiveStaticType_Count))
 	})
 }
@@ -0,0 +1,59 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// StorageCapabilityControllerValue
+
+var storageCapabilityControllerTypeID = sema.StorageCapabilityControllerType.ID()
+var storageCapabilityControllerStaticType StaticType = CompositeStaticType{
+	QualifiedIdentifier: sema.StorageCapabilityControllerType.Identifier,
+	TypeID:              storageCapabilityControllerTypeID,
+} // unmetered
+var storageCapabilityControllerFieldNames = []string{
+	sema.StorageCapabilityControllerTypeCapabilityIDFieldName,
+	sema.StorageCapabilityControllerTypeTargetFieldName,
+}
+
+// NewStorageCapabilityControllerValue constructs a StorageCapabilityController value.
+func NewStorageCapabilityControllerValue(
+	inter *Interpreter,
+	capabilityID UInt64Value,
+	targetPath PathValue,
+) *SimpleCompositeValue {
+	common.UseMemory(inter, common.StorageCapabilityControllerMemoryUsage)
+
+	return NewSimpleCompositeValue(
+		inter,
+		storageCapabilityControllerTypeID,
+		storageCapabilityControllerStaticType,
+		storageCapabilityControllerFieldNames,
+		map[string]Value{
+			sema.StorageCapabilityControllerTypeCapabilityIDFieldName: capabilityID,
+			sema.StorageCapabilityControllerTypeTargetFieldName:       targetPath,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
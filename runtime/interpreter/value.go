@@ -19,6 +19,7 @@
 package interpreter
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -1968,6 +1969,27 @@ func (v *ArrayValue) Contains(
 	return NewBoolValueFromConstructor(interpreter, valueGetter)
 }
 
+// ToHexString hex-encodes the bytes of this array,
+// which must be an array of UInt8 values
+//
+func (v *ArrayValue) ToHexString(interpreter *Interpreter) *StringValue {
+	memoryUsage := common.NewStringMemoryUsage(
+		safeMul(v.Count(), 2),
+	)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			bytes, err := ByteArrayValueToByteSlice(interpreter, v)
+			if err != nil {
+				panic(err)
+			}
+			return hex.EncodeToString(bytes)
+		},
+	)
+}
+
 func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func() LocationRange, name string) Value {
 
 	if interpreter.Config.InvalidatedResourceValidationEnabled {
@@ -2121,6 +2143,15 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 			),
 		)
 
+	case sema.ArrayTypeToHexStringFunctionName:
+		return NewHostFunctionValue(
+			interpreter,
+			func(invocation Invocation) Value {
+				return v.ToHexString(invocation.Interpreter)
+			},
+			sema.ArrayToHexStringFunctionType,
+		)
+
 	case "contains":
 		return NewHostFunctionValue(
 			interpreter,
@@ -14897,9 +14928,14 @@ func (v *CompositeValue) ConformsToStaticType(
 		return false
 	}
 
+	// Only count the computed fields which are declared fields.
+	// Other computed members, e.g. the fingerprint of a public key,
+	// are derived from the fields
 	fieldsLen := int(v.dictionary.Count())
-	if v.ComputedFields != nil {
-		fieldsLen += len(v.ComputedFields)
+	for _, fieldName := range compositeType.Fields {
+		if _, ok := v.ComputedFields[fieldName]; ok {
+			fieldsLen++
+		}
 	}
 
 	if fieldsLen != len(compositeType.Fields) {
@@ -17661,6 +17697,10 @@ func (*EphemeralReferenceValue) DeepRemove(_ *Interpreter) {
 
 // AddressValue
 //
+// AddressValue is an array, not a slice, so it has value semantics:
+// copies, e.g. the addresses cached by account values and their host functions,
+// are not affected by mutations of the original value.
+//
 type AddressValue common.Address
 
 func NewAddressValueFromBytes(memoryGauge common.MemoryGauge, constructor func() []byte) AddressValue {
@@ -17713,6 +17753,22 @@ func ConvertAddress(memoryGauge common.MemoryGauge, value Value) AddressValue {
 	return NewAddressValueFromConstructor(memoryGauge, converter)
 }
 
+// ParseAddress parses an address from the given hexadecimal string,
+// with or without the `0x` prefix. Leading zeros may be omitted.
+// The boolean result is false if the string is not a valid address.
+func ParseAddress(s string) (common.Address, bool) {
+	if strings.TrimPrefix(s, "0x") == "" {
+		return common.Address{}, false
+	}
+
+	address, err := common.HexToAddress(s)
+	if err != nil {
+		return common.Address{}, false
+	}
+
+	return address, true
+}
+
 var _ Value = AddressValue{}
 var _ atree.Storable = AddressValue{}
 var _ EquatableValue = AddressValue{}
@@ -18191,10 +18247,17 @@ type CapabilityValue struct {
 	Address    AddressValue
 	Path       PathValue
 	BorrowType StaticType
+	// ID is the ID of the capability controller which issued the capability.
+	// It is zero for path-based capabilities, i.e. capabilities created by linking.
+	ID UInt64Value
 }
 
 func NewUnmeteredCapabilityValue(address AddressValue, path PathValue, borrowType StaticType) *CapabilityValue {
-	return &CapabilityValue{address, path, borrowType}
+	return &CapabilityValue{
+		Address:    address,
+		Path:       path,
+		BorrowType: borrowType,
+	}
 }
 
 func NewCapabilityValue(
@@ -18208,6 +18271,32 @@ func NewCapabilityValue(
 	return NewUnmeteredCapabilityValue(address, path, borrowType)
 }
 
+func NewUnmeteredIDCapabilityValue(
+	id UInt64Value,
+	address AddressValue,
+	path PathValue,
+	borrowType StaticType,
+) *CapabilityValue {
+	return &CapabilityValue{
+		Address:    address,
+		Path:       path,
+		BorrowType: borrowType,
+		ID:         id,
+	}
+}
+
+func NewIDCapabilityValue(
+	memoryGauge common.MemoryGauge,
+	id UInt64Value,
+	address AddressValue,
+	path PathValue,
+	borrowType StaticType,
+) *CapabilityValue {
+	// Constant because its constituents are already metered.
+	common.UseMemory(memoryGauge, common.CapabilityValueMemoryUsage)
+	return NewUnmeteredIDCapabilityValue(id, address, path, borrowType)
+}
+
 var _ Value = &CapabilityValue{}
 var _ atree.Storable = &CapabilityValue{}
 var _ EquatableValue = &CapabilityValue{}
@@ -18325,7 +18414,8 @@ func (v *CapabilityValue) Equal(interpreter *Interpreter, getLocationRange func(
 		return false
 	}
 
-	return otherCapability.Address.Equal(interpreter, getLocationRange, v.Address) &&
+	return otherCapability.ID == v.ID &&
+		otherCapability.Address.Equal(interpreter, getLocationRange, v.Address) &&
 		otherCapability.Path.Equal(interpreter, getLocationRange, v.Path)
 }
 
@@ -18373,6 +18463,7 @@ func (v *CapabilityValue) Clone(interpreter *Interpreter) Value {
 		Address:    v.Address.Clone(interpreter).(AddressValue),
 		Path:       v.Path.Clone(interpreter).(PathValue),
 		BorrowType: v.BorrowType,
+		ID:         v.ID,
 	}
 }
 
@@ -18559,10 +18650,19 @@ func NewPublicKeyValue(
 		common.Address{},
 	)
 
+	// The fingerprint is only computed when it is first accessed
+	var fingerprint *StringValue
+
 	publicKeyValue.ComputedFields = map[string]ComputedField{
 		sema.PublicKeyPublicKeyField: func(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
 			return publicKey.Transfer(interpreter, getLocationRange, atree.Address{}, false, nil)
 		},
+		sema.PublicKeyFingerprintField: func(interpreter *Interpreter, _ func() LocationRange) Value {
+			if fingerprint == nil {
+				fingerprint = newPublicKeyFingerprintValue(interpreter, publicKey)
+			}
+			return fingerprint
+		},
 	}
 	publicKeyValue.Functions = map[string]FunctionValue{
 		sema.PublicKeyVerifyFunction:    publicKeyVerifyFunction,
@@ -18608,6 +18708,37 @@ func NewPublicKeyValue(
 	return publicKeyValue
 }
 
+// publicKeyFingerprintLength is the number of bytes of the public key hash
+// which are included in the fingerprint of a public key
+const publicKeyFingerprintLength = 8
+
+var publicKeyFingerprintMemoryUsage = common.NewStringMemoryUsage(
+	hex.EncodedLen(publicKeyFingerprintLength),
+)
+
+func newPublicKeyFingerprintValue(interpreter *Interpreter, publicKey *ArrayValue) *StringValue {
+	return NewStringValue(
+		interpreter,
+		publicKeyFingerprintMemoryUsage,
+		func() string {
+			key, err := ByteArrayValueToByteSlice(interpreter, publicKey)
+			if err != nil {
+				panic(err)
+			}
+			return PublicKeyFingerprint(key)
+		},
+	)
+}
+
+// PublicKeyFingerprint returns the fingerprint of the given public key bytes,
+// the hex-encoded first bytes of their SHA2-256 hash.
+// It is the value of the `fingerprint` field of a public key,
+// and is also used to identify public keys in errors
+func PublicKeyFingerprint(publicKey []byte) string {
+	hash := sha256.Sum256(publicKey)
+	return hex.EncodeToString(hash[:publicKeyFingerprintLength])
+}
+
 // publicKeyVerifyFunction is only created once for the interpreter.
 // Hence, no need to meter.
 var publicKeyVerifyFunction = NewUnmeteredHostFunctionValue(
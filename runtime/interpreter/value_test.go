@@ -3275,6 +3275,7 @@ func TestPublicKeyValue(t *testing.T) {
 		)
 
 		sigAlgo := stdlib.NewSignatureAlgorithmCase(
+			nil,
 			UInt8Value(sema.SignatureAlgorithmECDSA_secp256k1.RawValue()),
 		)
 
@@ -3327,6 +3328,7 @@ func TestPublicKeyValue(t *testing.T) {
 		)
 
 		sigAlgo := stdlib.NewSignatureAlgorithmCase(
+			nil,
 			UInt8Value(sema.SignatureAlgorithmECDSA_secp256k1.RawValue()),
 		)
 
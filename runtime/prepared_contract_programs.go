@@ -0,0 +1,94 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// PreparedContractPrograms is a set of contract programs
+// which were already parsed and checked by the caller,
+// e.g. by deployment tooling which deploys many contracts in a batch.
+//
+// The prepared programs can be provided in the Config.
+// When a contract is deployed, and a program was prepared for the deployed location and code,
+// the prepared program is used instead of parsing and checking the code again.
+// The checks of the imports which are only performed for deployments are still performed,
+// as the imported contracts may have changed since the program was prepared.
+// All other checks of the deployment, e.g. the validation of contract updates, are still performed.
+//
+// A prepared program is only used if the deployed code has the same hash
+// as the code the program was prepared for.
+type PreparedContractPrograms struct {
+	mutex    sync.RWMutex
+	programs map[common.AddressLocation]preparedContractProgram
+}
+
+type preparedContractProgram struct {
+	codeHash [32]byte
+	program  *interpreter.Program
+}
+
+func NewPreparedContractPrograms() *PreparedContractPrograms {
+	return &PreparedContractPrograms{
+		programs: map[common.AddressLocation]preparedContractProgram{},
+	}
+}
+
+// Add adds the given program, which must be the result of parsing and checking the given code
+// as a contract deployment at the given location, e.g. using Runtime.ParseAndCheckProgram.
+func (p *PreparedContractPrograms) Add(
+	location common.AddressLocation,
+	code []byte,
+	program *interpreter.Program,
+) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.programs[location] = preparedContractProgram{
+		codeHash: sha3.Sum256(code),
+		program:  program,
+	}
+}
+
+// get returns the program prepared for the given location,
+// if there is one and it was prepared for the given code.
+func (p *PreparedContractPrograms) get(
+	location common.AddressLocation,
+	code []byte,
+) *interpreter.Program {
+	if p == nil {
+		return nil
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	prepared, ok := p.programs[location]
+	if !ok || prepared.codeHash != sha3.Sum256(code) {
+		return nil
+	}
+
+	return prepared.program
+}
@@ -0,0 +1,77 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package runtime
+
+import (
+	"sync/atomic"
+)
+
+// ProgramCacheMetrics counts how effective the program cache of the host is,
+// i.e. how often Interface.GetProgram returns a program (hit) or nil (miss),
+// and how often programs are parsed.
+//
+// The counters are updated atomically, so they can be read while programs are loaded.
+// Metrics are only recorded if they are configured using Config.ProgramCacheMetrics.
+//
+type ProgramCacheMetrics struct {
+	hits   uint64
+	misses uint64
+	parses uint64
+}
+
+// Hits returns the number of times the host returned a cached program.
+func (m *ProgramCacheMetrics) Hits() uint64 {
+	return atomic.LoadUint64(&m.hits)
+}
+
+// Misses returns the number of times the host did not return a cached program.
+func (m *ProgramCacheMetrics) Misses() uint64 {
+	return atomic.LoadUint64(&m.misses)
+}
+
+// Parses returns the number of programs that were parsed.
+func (m *ProgramCacheMetrics) Parses() uint64 {
+	return atomic.LoadUint64(&m.parses)
+}
+
+// Reset sets all counters to zero.
+func (m *ProgramCacheMetrics) Reset() {
+	atomic.StoreUint64(&m.hits, 0)
+	atomic.StoreUint64(&m.misses, 0)
+	atomic.StoreUint64(&m.parses, 0)
+}
+
+func (m *ProgramCacheMetrics) recordGet(hit bool) {
+	if m == nil {
+		return
+	}
+	if hit {
+		atomic.AddUint64(&m.hits, 1)
+	} else {
+		atomic.AddUint64(&m.misses, 1)
+	}
+}
+
+func (m *ProgramCacheMetrics) recordParse() {
+	if m == nil {
+		return
+	}
+	atomic.AddUint64(&m.parses, 1)
+}
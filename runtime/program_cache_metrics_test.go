@@ -0,0 +1,108 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeProgramCacheMetrics(t *testing.T) {
+
+	t.Parallel()
+
+	metrics := &ProgramCacheMetrics{}
+
+	runtime := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled: true,
+		ProgramCacheMetrics:    metrics,
+	})
+
+	imported := []byte(`
+      pub fun answer(): Int {
+          return 42
+      }
+    `)
+
+	script := []byte(`
+      import "imported"
+
+      pub fun main(): Int {
+          return answer()
+      }
+    `)
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) ([]byte, error) {
+			switch location {
+			case common.StringLocation("imported"):
+				return imported, nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeScript := func() {
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	// The first execution parses the script and the imported program,
+	// which is not cached yet when it is checked,
+	// but is cached when it is interpreted
+
+	executeScript()
+
+	assert.Equal(t, uint64(1), metrics.Hits())
+	assert.Equal(t, uint64(1), metrics.Misses())
+	assert.Equal(t, uint64(2), metrics.Parses())
+
+	// Subsequent executions only parse the script,
+	// the imported program is cached when it is checked and interpreted
+
+	executeScript()
+	executeScript()
+
+	assert.Equal(t, uint64(5), metrics.Hits())
+	assert.Equal(t, uint64(1), metrics.Misses())
+	assert.Equal(t, uint64(4), metrics.Parses())
+
+	metrics.Reset()
+
+	assert.Equal(t, uint64(0), metrics.Hits())
+	assert.Equal(t, uint64(0), metrics.Misses())
+	assert.Equal(t, uint64(0), metrics.Parses())
+}
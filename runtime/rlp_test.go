@@ -341,3 +341,116 @@ func TestRLPDecodeList(t *testing.T) {
 		test(testCase)
 	}
 }
+
+func TestRLPEncodeRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(t *testing.T, script string) (cadence.Value, error) {
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	t.Run("string", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(t, `
+          pub fun main(): [[UInt8]] {
+              let inputs: [[UInt8]] = [
+                  [],
+                  [0x7f],
+                  [0x80],
+                  "dog".utf8,
+                  "Lorem ipsum dolor sit amet, consectetur adipisicing elit".utf8
+              ]
+              let encoded: [[UInt8]] = []
+              for input in inputs {
+                  let encodedInput = RLP.encodeString(input)
+                  assert(RLP.decodeString(encodedInput).length == input.length)
+                  encoded.append(encodedInput)
+              }
+              return encoded
+          }
+        `)
+		require.NoError(t, err)
+
+		lorem := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				newBytesValue([]byte{0x80}),
+				newBytesValue([]byte{0x7f}),
+				newBytesValue([]byte{0x81, 0x80}),
+				newBytesValue([]byte{0x83, 'd', 'o', 'g'}),
+				newBytesValue(append([]byte{0xb8, byte(len(lorem))}, lorem...)),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.VariableSizedArrayType{
+					ElementType: cadence.UInt8Type{},
+				},
+			}),
+			result,
+		)
+	})
+
+	t.Run("nested list", func(t *testing.T) {
+
+		t.Parallel()
+
+		// [ "cat", [ "dog", [] ] ]
+
+		result, err := executeScript(t, `
+          pub fun main(): [UInt8] {
+              let cat = RLP.encodeString("cat".utf8)
+              let dog = RLP.encodeString("dog".utf8)
+              let empty = RLP.encodeList([])
+              let inner = RLP.encodeList([dog, empty])
+              let encoded = RLP.encodeList([cat, inner])
+
+              let items = RLP.decodeList(encoded)
+              assert(items.length == 2)
+              assert(String.encodeHex(items[0]) == String.encodeHex(cat))
+              assert(String.encodeHex(items[1]) == String.encodeHex(inner))
+
+              let innerItems = RLP.decodeList(items[1])
+              assert(innerItems.length == 2)
+              assert(String.encodeHex(RLP.decodeString(innerItems[0])) == String.encodeHex("dog".utf8))
+              assert(RLP.decodeList(innerItems[1]).length == 0)
+
+              return encoded
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			newBytesValue([]byte{0xca, 0x83, 'c', 'a', 't', 0xc5, 0x83, 'd', 'o', 'g', 0xc0}),
+			result,
+		)
+	})
+
+	t.Run("invalid list item", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := executeScript(t, `
+          pub fun main(): [UInt8] {
+              return RLP.encodeList(["dog".utf8])
+          }
+        `)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to RLP-encode list: list item is not a single RLP-encoded item")
+	})
+}
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	goRuntime "runtime"
+	"runtime/debug"
 	"time"
 
 	"github.com/onflow/cadence"
@@ -28,6 +29,7 @@ import (
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
 )
 
 type Script struct {
@@ -167,12 +169,7 @@ var validTopLevelDeclarationsInTransaction = []common.DeclarationKind{
 	common.DeclarationKindTransaction,
 }
 
-var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
-	common.DeclarationKindPragma,
-	common.DeclarationKindImport,
-	common.DeclarationKindContract,
-	common.DeclarationKindContractInterface,
-}
+var validTopLevelDeclarationsInAccountCode = stdlib.ValidContractCodeTopLevelDeclarationKinds
 
 func validTopLevelDeclarations(location Location) []common.DeclarationKind {
 	switch location.(type) {
@@ -314,9 +311,17 @@ func wrapPanic(f func()) {
 			switch r := r.(type) {
 			case goRuntime.Error, errors.InternalError:
 				panic(r)
+			case errors.UserError:
+				panic(errors.ExternalError{
+					Recovered: r,
+				})
 			default:
+				// Unexpected failures of the host environment capture the stack,
+				// while it still includes the frames of the failed host call.
+				// User errors are expected and stay lightweight
 				panic(errors.ExternalError{
 					Recovered: r,
+					Stack:     debug.Stack(),
 				})
 			}
 
@@ -539,7 +544,7 @@ func (r *interpreterRuntime) Storage(context Context) (*Storage, *interpreter.In
 
 	codesAndPrograms := newCodesAndPrograms()
 
-	storage := NewStorage(context.Interface, context.Interface)
+	storage := NewStorage(context.storageLedger(), context.Interface)
 
 	environment := context.Environment
 	if environment == nil {
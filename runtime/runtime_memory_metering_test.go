@@ -973,6 +973,162 @@ func TestStorageCommitsMetering(t *testing.T) {
 	})
 }
 
+func TestStorageUsedTemporaryCommit(t *testing.T) {
+
+	t.Parallel()
+
+	// Each commit meters the encoded slabs, even if there are none,
+	// so the number of commits is the number of encoded slab meterings
+
+	test := func(t *testing.T, code string, isScript bool) (commitsBeforeStorageUsed int) {
+
+		var commits int
+		storageUsedInvoked := false
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				if usage.Kind == common.MemoryKindAtreeEncodedSlab {
+					commits++
+				}
+				return nil
+			},
+			getStorageUsed: func(_ Address) (uint64, error) {
+				commitsBeforeStorageUsed = commits
+				storageUsedInvoked = true
+				return 1, nil
+			},
+		}
+
+		runtime := newTestInterpreterRuntime()
+
+		script := Script{
+			Source: []byte(code),
+		}
+		context := Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		}
+
+		var err error
+		if isScript {
+			_, err = runtime.ExecuteScript(script, context)
+		} else {
+			err = runtime.ExecuteTransaction(script, context)
+		}
+		require.NoError(t, err)
+		require.True(t, storageUsedInvoked)
+
+		return commitsBeforeStorageUsed
+	}
+
+	t.Run("read-only script", func(t *testing.T) {
+		t.Parallel()
+
+		commits := test(
+			t,
+			`
+              pub fun main(): UInt64 {
+                  return getAccount(0x2a).storageUsed
+              }
+            `,
+			true,
+		)
+		assert.Equal(t, 0, commits)
+	})
+
+	t.Run("script with writes", func(t *testing.T) {
+		t.Parallel()
+
+		commits := test(
+			t,
+			`
+              pub fun main(): UInt64 {
+                  let account = getAuthAccount(0x2a)
+                  account.save(1, to: /storage/test)
+                  return account.storageUsed
+              }
+            `,
+			true,
+		)
+		assert.Equal(t, 1, commits)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		commits := test(
+			t,
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.storageUsed
+                  }
+              }
+            `,
+			false,
+		)
+		assert.Equal(t, 1, commits)
+	})
+}
+
+func TestCapabilityControllerMetering(t *testing.T) {
+
+	t.Parallel()
+
+	meter := newTestMemoryGauge()
+
+	var capabilityIDs []uint64
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{42}}, nil
+		},
+		meterMemory: func(usage common.MemoryUsage) error {
+			return meter.MeterMemory(usage)
+		},
+		issueStorageCapabilityController: func(_ Address, _ cadence.Path) (uint64, error) {
+			capabilityID := uint64(len(capabilityIDs) + 1)
+			capabilityIDs = append(capabilityIDs, capabilityID)
+			return capabilityID, nil
+		},
+		getStorageCapabilityControllerIDs: func(_ Address, _ cadence.Path) ([]uint64, error) {
+			return capabilityIDs, nil
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save("hello", to: /storage/greeting)
+
+                      signer.capabilities.issue<&String>(target: /storage/greeting)
+                      signer.capabilities.issue<&AnyStruct>(target: /storage/greeting)
+
+                      signer.capabilities.getControllers(forPath: /storage/greeting)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindStorageCapabilityController))
+	assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindAccountCapabilityController))
+}
+
 func TestMemoryMeteringErrors(t *testing.T) {
 
 	t.Parallel()
@@ -1074,3 +1230,64 @@ func TestMemoryMeteringErrors(t *testing.T) {
 		assert.ErrorIs(t, err, testMemoryError{})
 	})
 }
+
+func TestRuntimeMemoryUsageReporter(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub struct S {
+          pub let x: Int
+
+          init(x: Int) {
+              self.x = x
+          }
+      }
+
+      pub fun main(): Int {
+          let s = S(x: 42)
+          return s.x
+      }
+    `
+
+	meter := newTestMemoryGauge()
+
+	var reportedUsage map[common.MemoryKind]uint64
+	var reportCount int
+
+	runtime := NewInterpreterRuntime(Config{
+		AtreeValidationEnabled: true,
+		MemoryUsageReporter: func(usage map[common.MemoryKind]uint64) {
+			reportCount++
+			reportedUsage = usage
+		},
+	})
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		meterMemory: func(usage common.MemoryUsage) error {
+			return meter.MeterMemory(usage)
+		},
+	}
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(script),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, reportCount)
+
+	assert.NotZero(t, reportedUsage[common.MemoryKindCompositeValueBase])
+	assert.NotZero(t, reportedUsage[common.MemoryKindCompositeDeclaration])
+
+	// All reported usage was also metered through the host
+	for kind, amount := range reportedUsage {
+		assert.Equal(t, meter.getMemory(kind), amount, kind.String())
+	}
+}
@@ -170,6 +170,7 @@ type testRuntimeInterface struct {
 	programChecked     func(location Location, duration time.Duration)
 	programInterpreted func(location Location, duration time.Duration)
 	unsafeRandom       func() (uint64, error)
+	blockTimestamp     func(height uint64) time.Time
 	verifySignature    func(
 		signature []byte,
 		tag string,
@@ -178,21 +179,23 @@ type testRuntimeInterface struct {
 		signatureAlgorithm SignatureAlgorithm,
 		hashAlgorithm HashAlgorithm,
 	) (bool, error)
-	hash                       func(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error)
-	setCadenceValue            func(owner Address, key string, value cadence.Value) (err error)
-	getAccountBalance          func(_ Address) (uint64, error)
-	getAccountAvailableBalance func(_ Address) (uint64, error)
-	getStorageUsed             func(_ Address) (uint64, error)
-	getStorageCapacity         func(_ Address) (uint64, error)
-	programs                   map[Location]*interpreter.Program
-	implementationDebugLog     func(message string) error
-	validatePublicKey          func(publicKey *stdlib.PublicKey) error
-	bLSVerifyPOP               func(pk *stdlib.PublicKey, s []byte) (bool, error)
-	blsAggregateSignatures     func(sigs [][]byte) ([]byte, error)
-	blsAggregatePublicKeys     func(keys []*stdlib.PublicKey) (*stdlib.PublicKey, error)
-	getAccountContractNames    func(address Address) ([]string, error)
-	recordTrace                func(operation string, location Location, duration time.Duration, attrs []attribute.KeyValue)
-	meterMemory                func(usage common.MemoryUsage) error
+	hash                              func(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error)
+	setCadenceValue                   func(owner Address, key string, value cadence.Value) (err error)
+	getAccountBalance                 func(_ Address) (uint64, error)
+	getAccountAvailableBalance        func(_ Address) (uint64, error)
+	getStorageUsed                    func(_ Address) (uint64, error)
+	getStorageCapacity                func(_ Address) (uint64, error)
+	programs                          map[Location]*interpreter.Program
+	implementationDebugLog            func(message string) error
+	validatePublicKey                 func(publicKey *stdlib.PublicKey) error
+	bLSVerifyPOP                      func(pk *stdlib.PublicKey, s []byte) (bool, error)
+	blsAggregateSignatures            func(sigs [][]byte) ([]byte, error)
+	blsAggregatePublicKeys            func(keys []*stdlib.PublicKey) (*stdlib.PublicKey, error)
+	getAccountContractNames           func(address Address) ([]string, error)
+	issueStorageCapabilityController  func(address Address, targetPath cadence.Path) (uint64, error)
+	getStorageCapabilityControllerIDs func(address Address, targetPath cadence.Path) ([]uint64, error)
+	recordTrace                       func(operation string, location Location, duration time.Duration, attrs []attribute.KeyValue)
+	meterMemory                       func(usage common.MemoryUsage) error
 }
 
 // testRuntimeInterface should implement Interface
@@ -423,11 +426,16 @@ func (i *testRuntimeInterface) GetBlockAtHeight(height uint64) (block stdlib.Blo
 	var hash stdlib.BlockHash
 	copy(hash[sema.BlockIDSize-len(encoded):], encoded)
 
+	timestamp := time.Unix(int64(height), 0)
+	if i.blockTimestamp != nil {
+		timestamp = i.blockTimestamp(height)
+	}
+
 	block = stdlib.Block{
 		Height:    height,
 		View:      height,
 		Hash:      hash,
-		Timestamp: time.Unix(int64(height), 0).UnixNano(),
+		Timestamp: timestamp.UnixNano(),
 	}
 	return block, true, nil
 }
@@ -549,6 +557,20 @@ func (i *testRuntimeInterface) GetAccountContractNames(address Address) ([]strin
 	return i.getAccountContractNames(address)
 }
 
+func (i *testRuntimeInterface) IssueStorageCapabilityController(address Address, targetPath cadence.Path) (uint64, error) {
+	if i.issueStorageCapabilityController == nil {
+		panic("must specify testRuntimeInterface.issueStorageCapabilityController")
+	}
+	return i.issueStorageCapabilityController(address, targetPath)
+}
+
+func (i *testRuntimeInterface) GetStorageCapabilityControllerIDs(address Address, targetPath cadence.Path) ([]uint64, error) {
+	if i.getStorageCapabilityControllerIDs == nil {
+		panic("must specify testRuntimeInterface.getStorageCapabilityControllerIDs")
+	}
+	return i.getStorageCapabilityControllerIDs(address, targetPath)
+}
+
 func (i *testRuntimeInterface) RecordTrace(operation string, location Location, duration time.Duration, attrs []attribute.KeyValue) {
 	if i.recordTrace == nil {
 		return
@@ -3050,7 +3072,7 @@ func TestRuntimeTransaction_CreateAccount(t *testing.T) {
 			return []Address{{42}}, nil
 		},
 		createAccount: func(payer Address) (address Address, err error) {
-			return Address{42}, nil
+			return Address{43}, nil
 		},
 		emitEvent: func(event cadence.Event) error {
 			events = append(events, event)
@@ -3077,6 +3099,17 @@ func TestRuntimeTransaction_CreateAccount(t *testing.T) {
 		stdlib.AccountCreatedEventType.ID(),
 		events[0].Type().ID(),
 	)
+
+	// The event carries both the address of the new account,
+	// and the address of the account which paid for it
+
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.Address{43},
+			cadence.Address{42},
+		},
+		events[0].Fields,
+	)
 }
 
 func TestRuntimeContractAccount(t *testing.T) {
@@ -4519,6 +4552,55 @@ func TestRuntimeBlock(t *testing.T) {
 	)
 }
 
+func TestRuntimeBlockTimestamp(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      pub fun main(): [UFix64] {
+          let block = getCurrentBlock()
+          let nextBlock = getBlock(at: block.height + UInt64(1))!
+          return [block.timestamp, nextBlock.timestamp]
+      }
+    `)
+
+	clock := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		blockTimestamp: func(height uint64) time.Time {
+			return clock.Add(time.Duration(height) * time.Second)
+		},
+	}
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	expectedTimestamp := func(height int64) cadence.UFix64 {
+		return cadence.UFix64((clock.Unix() + height) * sema.Fix64Factor)
+	}
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			expectedTimestamp(1),
+			expectedTimestamp(2),
+		}).WithType(cadence.VariableSizedArrayType{
+			ElementType: cadence.UFix64Type{},
+		}),
+		result,
+	)
+}
+
 func TestRuntimeUnsafeRandom(t *testing.T) {
 
 	t.Parallel()
@@ -4566,6 +4648,138 @@ func TestRuntimeUnsafeRandom(t *testing.T) {
 	)
 }
 
+type testComputationBudgetRuntimeInterface struct {
+	*testRuntimeInterface
+	computationLimit uint64
+	computationUsed  uint64
+}
+
+var _ ComputationBudgetProvider = &testComputationBudgetRuntimeInterface{}
+
+func (i *testComputationBudgetRuntimeInterface) MeterComputation(_ common.ComputationKind, intensity uint) error {
+	i.computationUsed += uint64(intensity)
+	return nil
+}
+
+func (i *testComputationBudgetRuntimeInterface) GetComputationLimit() (uint64, error) {
+	return i.computationLimit, nil
+}
+
+func (i *testComputationBudgetRuntimeInterface) GetComputationUsed() (uint64, error) {
+	return i.computationUsed, nil
+}
+
+func TestRuntimeGetRemainingComputation(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): [UInt64] {
+          let before = getRemainingComputation()
+
+          var i = 0
+          while i < 10 {
+              i = i + 1
+          }
+
+          let after = getRemainingComputation()
+
+          return [before, after]
+      }
+    `)
+
+	t.Run("remaining budget decreases", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		const computationLimit = 1000
+
+		runtimeInterface := &testComputationBudgetRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{},
+			computationLimit:     computationLimit,
+		}
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		values := result.(cadence.Array).Values
+		require.Len(t, values, 2)
+
+		before := uint64(values[0].(cadence.UInt64))
+		after := uint64(values[1].(cadence.UInt64))
+
+		// The computation used before the first call is already accounted for
+
+		assert.Less(t, before, uint64(computationLimit))
+
+		// The loop used at least one unit of computation per iteration
+
+		assert.LessOrEqual(t, after, before-10)
+
+		// The computation used after the second call is not included
+
+		assert.GreaterOrEqual(t, after, computationLimit-runtimeInterface.computationUsed)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testComputationBudgetRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{},
+			computationLimit:     5,
+		}
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		values := result.(cadence.Array).Values
+		require.Len(t, values, 2)
+		assert.Equal(t, cadence.UInt64(0), values[1])
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+
+		var notImplementedErr NotImplementedError
+		require.ErrorAs(t, err, &notImplementedErr)
+	})
+}
+
 func TestRuntimeTransactionTopLevelDeclarations(t *testing.T) {
 
 	t.Parallel()
@@ -5853,6 +6067,513 @@ func TestRuntimeExternalError(t *testing.T) {
 	assertRuntimeErrorIsExternalError(t, err)
 }
 
+func TestRuntimeExternalErrorStack(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      transaction {
+        prepare() {
+          log("ok")
+        }
+      }
+    `)
+
+	t.Run("unexpected failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		interpreterRuntime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+			log: func(message string) {
+				panic(fmt.Errorf("unexpected host failure"))
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := interpreterRuntime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var externalErr runtimeErrors.ExternalError
+		require.ErrorAs(t, err, &externalErr)
+
+		// The stack must include the frames of the failed host call
+		assert.Contains(t, string(externalErr.Stack), "TestRuntimeExternalErrorStack")
+	})
+
+	t.Run("user error", func(t *testing.T) {
+
+		t.Parallel()
+
+		interpreterRuntime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+			log: func(message string) {
+				panic(runtimeErrors.NewDefaultUserError("invalid log message"))
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := interpreterRuntime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var externalErr runtimeErrors.ExternalError
+		require.ErrorAs(t, err, &externalErr)
+
+		assert.Nil(t, externalErr.Stack)
+	})
+}
+
+func TestRuntimeHostErrorMapping(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): UFix64 {
+          return getAccount(0x1).balance
+      }
+    `)
+
+	errInsufficientBalance := errors.New("payer has insufficient balance")
+	errStorageBackend := errors.New("storage backend failure")
+
+	hostErrorMapper := func(err error) error {
+		if errors.Is(err, errInsufficientBalance) {
+			return stdlib.HostUserError{
+				Message: "insufficient balance",
+				Err:     err,
+			}
+		}
+		return err
+	}
+
+	executeScript := func(hostErr error) error {
+		runtime := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled: true,
+			HostErrorMapper:        hostErrorMapper,
+		})
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountBalance: func(_ Address) (uint64, error) {
+				return 0, hostErr
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return err
+	}
+
+	t.Run("mapped to user error", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeScript(errInsufficientBalance)
+		require.Error(t, err)
+
+		assertRuntimeErrorIsUserError(t, err)
+
+		var hostUserErr stdlib.HostUserError
+		require.ErrorAs(t, err, &hostUserErr)
+		assert.Equal(t, "insufficient balance", hostUserErr.Message)
+		assert.ErrorIs(t, err, errInsufficientBalance)
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeScript(errStorageBackend)
+		require.Error(t, err)
+
+		assertRuntimeErrorIsExternalError(t, err)
+	})
+}
+
+type testMultiTokenBalanceRuntimeInterface struct {
+	*testRuntimeInterface
+	getAccountBalanceForType func(address Address, vaultType sema.Type) (uint64, error)
+}
+
+var _ MultiTokenBalanceProvider = &testMultiTokenBalanceRuntimeInterface{}
+
+func (i *testMultiTokenBalanceRuntimeInterface) GetAccountBalanceForType(
+	address Address,
+	vaultType sema.Type,
+) (uint64, error) {
+	return i.getAccountBalanceForType(address, vaultType)
+}
+
+func TestRuntimeAccountGetBalance(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub resource FlowVault {}
+
+      pub resource USDCVault {}
+
+      pub fun main(): [UFix64?] {
+          let account = getAccount(0x1)
+          return [
+              account.getBalance(ofType: Type<@FlowVault>()),
+              account.getBalance(ofType: Type<@USDCVault>()),
+              account.getBalance(ofType: Type<Int>())
+          ]
+      }
+    `)
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountBalance: func(_ Address) (uint64, error) {
+				return 1_00000000, nil
+			},
+		}
+	}
+
+	executeScript := func(runtimeInterface Interface) (cadence.Value, error) {
+		runtime := newTestInterpreterRuntime()
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	resultType := cadence.VariableSizedArrayType{
+		ElementType: cadence.OptionalType{
+			Type: cadence.UFix64Type{},
+		},
+	}
+
+	t.Run("multi-token provider", func(t *testing.T) {
+
+		t.Parallel()
+
+		var queriedAddresses []Address
+
+		runtimeInterface := &testMultiTokenBalanceRuntimeInterface{
+			testRuntimeInterface: newRuntimeInterface(),
+			getAccountBalanceForType: func(address Address, vaultType sema.Type) (uint64, error) {
+				queriedAddresses = append(queriedAddresses, address)
+
+				switch vaultType.QualifiedString() {
+				case "FlowVault":
+					return 2_00000000, nil
+				case "USDCVault":
+					return 3_00000000, nil
+				default:
+					return 0, fmt.Errorf("unexpected vault type: %s", vaultType)
+				}
+			},
+		}
+
+		result, err := executeScript(runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewOptional(cadence.UFix64(2_00000000)),
+				cadence.NewOptional(cadence.UFix64(3_00000000)),
+				cadence.NewOptional(nil),
+			}).WithType(resultType),
+			result,
+		)
+
+		// Non-resource types are not queried
+
+		assert.Equal(t,
+			[]Address{
+				common.MustBytesToAddress([]byte{0x1}),
+				common.MustBytesToAddress([]byte{0x1}),
+			},
+			queriedAddresses,
+		)
+	})
+
+	t.Run("default token fallback", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(newRuntimeInterface())
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewOptional(cadence.UFix64(1_00000000)),
+				cadence.NewOptional(cadence.UFix64(1_00000000)),
+				cadence.NewOptional(nil),
+			}).WithType(resultType),
+			result,
+		)
+	})
+}
+
+type testDefaultTokenAddressRuntimeInterface struct {
+	*testRuntimeInterface
+	defaultTokenAddress Address
+}
+
+var _ DefaultTokenAddressProvider = &testDefaultTokenAddressRuntimeInterface{}
+
+func (i *testDefaultTokenAddressRuntimeInterface) GetDefaultTokenAddress() (Address, error) {
+	return i.defaultTokenAddress, nil
+}
+
+type testMultiTokenDefaultTokenAddressRuntimeInterface struct {
+	*testMultiTokenBalanceRuntimeInterface
+	defaultTokenAddress Address
+}
+
+var _ DefaultTokenAddressProvider = &testMultiTokenDefaultTokenAddressRuntimeInterface{}
+
+func (i *testMultiTokenDefaultTokenAddressRuntimeInterface) GetDefaultTokenAddress() (Address, error) {
+	return i.defaultTokenAddress, nil
+}
+
+func TestRuntimeAccountGetBalanceDefaultTokenAddress(t *testing.T) {
+
+	t.Parallel()
+
+	tokenAAddress := common.MustBytesToAddress([]byte{0x1})
+	tokenBAddress := common.MustBytesToAddress([]byte{0x2})
+
+	contracts := map[Address][]byte{
+		tokenAAddress: []byte(`
+          pub contract TokenA {
+              pub resource Vault {}
+          }
+        `),
+		tokenBAddress: []byte(`
+          pub contract TokenB {
+              pub resource Vault {}
+          }
+        `),
+	}
+
+	script := []byte(`
+      import TokenA from 0x1
+      import TokenB from 0x2
+
+      pub fun main(): [UFix64?] {
+          let account = getAccount(0x3)
+          return [
+              account.getBalance(ofType: Type<@TokenA.Vault>()),
+              account.getBalance(ofType: Type<@TokenB.Vault>())
+          ]
+      }
+    `)
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage:         newTestLedger(nil, nil),
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getAccountContractCode: func(address Address, _ string) ([]byte, error) {
+				return contracts[address], nil
+			},
+			getAccountBalance: func(_ Address) (uint64, error) {
+				return 1_00000000, nil
+			},
+		}
+	}
+
+	executeScript := func(runtimeInterface Interface) (cadence.Value, error) {
+		runtime := newTestInterpreterRuntime()
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	resultType := cadence.VariableSizedArrayType{
+		ElementType: cadence.OptionalType{
+			Type: cadence.UFix64Type{},
+		},
+	}
+
+	newResult := func(balanceA, balanceB cadence.UFix64) cadence.Value {
+		return cadence.NewArray([]cadence.Value{
+			cadence.NewOptional(balanceA),
+			cadence.NewOptional(balanceB),
+		}).WithType(resultType)
+	}
+
+	for _, testCase := range []struct {
+		defaultTokenAddress Address
+		expected            cadence.Value
+	}{
+		{
+			defaultTokenAddress: tokenAAddress,
+			expected:            newResult(1_00000000, 0),
+		},
+		{
+			defaultTokenAddress: tokenBAddress,
+			expected:            newResult(0, 1_00000000),
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.defaultTokenAddress.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			runtimeInterface := &testDefaultTokenAddressRuntimeInterface{
+				testRuntimeInterface: newRuntimeInterface(),
+				defaultTokenAddress:  testCase.defaultTokenAddress,
+			}
+
+			result, err := executeScript(runtimeInterface)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+
+	t.Run("multi-token provider", func(t *testing.T) {
+
+		t.Parallel()
+
+		var queriedTypes []string
+
+		runtimeInterface := &testMultiTokenDefaultTokenAddressRuntimeInterface{
+			testMultiTokenBalanceRuntimeInterface: &testMultiTokenBalanceRuntimeInterface{
+				testRuntimeInterface: newRuntimeInterface(),
+				getAccountBalanceForType: func(_ Address, vaultType sema.Type) (uint64, error) {
+					queriedTypes = append(queriedTypes, vaultType.QualifiedString())
+					return 2_00000000, nil
+				},
+			},
+			defaultTokenAddress: tokenAAddress,
+		}
+
+		result, err := executeScript(runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, newResult(1_00000000, 2_00000000), result)
+
+		// The default token vault is not queried from the multi-token provider
+
+		assert.Equal(t, []string{"TokenB.Vault"}, queriedTypes)
+	})
+}
+
+type testAccessObserverRuntimeInterface struct {
+	*testRuntimeInterface
+	onAccountAccess func(address Address, kind AccountAccessKind)
+}
+
+var _ AccessObserver = &testAccessObserverRuntimeInterface{}
+
+func (i *testAccessObserverRuntimeInterface) OnAccountAccess(address Address, kind AccountAccessKind) {
+	i.onAccountAccess(address, kind)
+}
+
+func TestRuntimeAccessObserver(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      pub fun main() {
+          getAccount(0x1)
+
+          let account = getAuthAccount(0x2)
+          account.save(1, to: /storage/answer)
+          account.borrow<&Int>(from: /storage/answer)
+      }
+    `)
+
+	type accountAccess struct {
+		address Address
+		kind    AccountAccessKind
+	}
+
+	var accesses []accountAccess
+
+	runtimeInterface := &testAccessObserverRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		},
+		onAccountAccess: func(address Address, kind AccountAccessKind) {
+			accesses = append(accesses, accountAccess{
+				address: address,
+				kind:    kind,
+			})
+		},
+	}
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	assert.Equal(t,
+		[]accountAccess{
+			{address: address1, kind: AccountAccessKindAccount},
+			{address: address2, kind: AccountAccessKindAccount},
+			{address: address2, kind: AccountAccessKindStorageRead},
+			{address: address2, kind: AccountAccessKindStorageWrite},
+			{address: address2, kind: AccountAccessKindStorageRead},
+		},
+		accesses,
+	)
+}
+
 func TestRuntimeDeployCodeCaching(t *testing.T) {
 
 	t.Parallel()
@@ -7354,6 +8075,67 @@ func TestRuntimeComputationMetring(t *testing.T) {
 	}
 }
 
+func TestRuntimeComputationWeights(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun f() {}
+
+      pub fun main() {
+          f()
+          f()
+          f()
+      }
+    `)
+
+	executeScript := func(t *testing.T, weights map[common.ComputationKind]uint64) map[common.ComputationKind]uint {
+
+		runtime := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled: true,
+			ComputationWeights:     weights,
+		})
+
+		compUsed := map[common.ComputationKind]uint{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			meterComputation: func(kind common.ComputationKind, intensity uint) error {
+				compUsed[kind] += intensity
+				return nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		return compUsed
+	}
+
+	defaultCompUsed := executeScript(t, nil)
+	require.Equal(t, uint(3), defaultCompUsed[common.ComputationKindFunctionInvocation])
+
+	weightedCompUsed := executeScript(t, map[common.ComputationKind]uint64{
+		common.ComputationKindFunctionInvocation: 10,
+	})
+	assert.Equal(t, uint(30), weightedCompUsed[common.ComputationKindFunctionInvocation])
+
+	// Kinds without a weight are metered as-is
+
+	assert.Equal(t,
+		defaultCompUsed[common.ComputationKindStatement],
+		weightedCompUsed[common.ComputationKindStatement],
+	)
+}
+
 func TestRuntimeImportAnyStruct(t *testing.T) {
 
 	t.Parallel()
@@ -103,7 +103,7 @@ func (executor *interpreterScriptExecutor) preprocess() (err error) {
 
 	runtimeInterface := context.Interface
 
-	storage := NewStorage(runtimeInterface, runtimeInterface)
+	storage := NewStorage(context.storageLedger(), runtimeInterface)
 	executor.storage = storage
 
 	environment := context.Environment
@@ -177,6 +177,8 @@ func (executor *interpreterScriptExecutor) execute() (val cadence.Value, err err
 	codesAndPrograms := executor.codesAndPrograms
 	interpreterRuntime := executor.runtime
 
+	defer environment.ReportMemoryUsage()
+
 	defer interpreterRuntime.Recover(
 		func(internalErr Error) {
 			err = internalErr
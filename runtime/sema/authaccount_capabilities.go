@@ -0,0 +1,196 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const AuthAccountCapabilitiesTypeName = "Capabilities"
+const AuthAccountCapabilitiesTypeIssueFunctionName = "issue"
+const AuthAccountCapabilitiesTypePublishFunctionName = "publish"
+const AuthAccountCapabilitiesTypeGetFunctionName = "get"
+const AuthAccountCapabilitiesTypeGetControllersFunctionName = "getControllers"
+
+// AuthAccountCapabilitiesType represents the type `AuthAccount.Capabilities`
+//
+var AuthAccountCapabilitiesType = func() *CompositeType {
+
+	authAccountCapabilitiesType := &CompositeType{
+		Identifier: AuthAccountCapabilitiesTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewUnmeteredPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeIssueFunctionName,
+			AuthAccountCapabilitiesTypeIssueFunctionType,
+			authAccountCapabilitiesTypeIssueFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypePublishFunctionName,
+			AuthAccountCapabilitiesTypePublishFunctionType,
+			authAccountCapabilitiesTypePublishFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeGetFunctionName,
+			AuthAccountCapabilitiesTypeGetFunctionType,
+			authAccountCapabilitiesTypeGetFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeGetControllersFunctionName,
+			AuthAccountCapabilitiesTypeGetControllersFunctionType,
+			authAccountCapabilitiesTypeGetControllersFunctionDocString,
+		),
+	}
+
+	authAccountCapabilitiesType.Members = GetMembersAsMap(members)
+	authAccountCapabilitiesType.Fields = GetFieldNames(members)
+	return authAccountCapabilitiesType
+}()
+
+func init() {
+	// Set the container type after initializing the `AuthAccountCapabilitiesType`, to avoid initializing loop.
+	AuthAccountCapabilitiesType.SetContainerType(AuthAccountType)
+}
+
+const authAccountCapabilitiesTypeIssueFunctionDocString = `
+Issues a new capability with a fresh ID, which targets the given storage path.
+
+The given type defines how the capability can be borrowed, i.e., how the stored value can be accessed.
+
+The issued capability is not published. Use ` + "`publish`" + ` to make it available at a public path.
+`
+
+var AuthAccountCapabilitiesTypeIssueFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Identifier:     "target",
+				TypeAnnotation: NewTypeAnnotation(StoragePathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&CapabilityType{
+				BorrowType: &GenericType{
+					TypeParameter: typeParameter,
+				},
+			},
+		),
+	}
+}()
+
+const authAccountCapabilitiesTypePublishFunctionDocString = `
+Publishes the given capability at the given public path.
+
+Fails if the capability was not issued by this account,
+or if there is already a capability or link at the given path.
+`
+
+var AuthAccountCapabilitiesTypePublishFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "capability",
+			TypeAnnotation: NewTypeAnnotation(&CapabilityType{}),
+		},
+		{
+			Label:          "at",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(PublicPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
+
+const authAccountCapabilitiesTypeGetFunctionDocString = `
+Returns the capability published at the given public path, or nil if no capability is published at the given path,
+or if the published capability cannot be borrowed as the given type
+`
+
+var AuthAccountCapabilitiesTypeGetFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "path",
+				TypeAnnotation: NewTypeAnnotation(PublicPathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &CapabilityType{
+					BorrowType: &GenericType{
+						TypeParameter: typeParameter,
+					},
+				},
+			},
+		),
+	}
+}()
+
+const authAccountCapabilitiesTypeGetControllersFunctionDocString = `
+Returns references to the controllers of all capabilities which were issued against the given storage path.
+
+Returns an empty array if no capabilities were issued against the given path.
+`
+
+var AuthAccountCapabilitiesTypeGetControllersFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          "forPath",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(StoragePathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: &ReferenceType{
+				Type: StorageCapabilityControllerType,
+			},
+		},
+	),
+}
@@ -24,9 +24,20 @@ import (
 
 const AuthAccountContractsTypeName = "Contracts"
 const AuthAccountContractsTypeAddFunctionName = "add"
+const AuthAccountContractsTypeAddInferredFunctionName = "addInferred"
+const AuthAccountContractsTypeAddWithResultFunctionName = "addWithResult"
+const AuthAccountContractsTypeAddWithInitFunctionName = "addWithInit"
 const AuthAccountContractsTypeGetFunctionName = "get"
 const AuthAccountContractsTypeRemoveFunctionName = "remove"
+const AuthAccountContractsTypeRemoveWithResultFunctionName = "removeWithResult"
+const AuthAccountContractsTypeEnumTypesFunctionName = "enumTypes"
+const AuthAccountContractsTypeGetContractTypeFunctionName = "getContractType"
+const AuthAccountContractsTypeNamesWithPrefixFunctionName = "namesWithPrefix"
+const AuthAccountContractsTypeManifestFunctionName = "manifest"
+const AuthAccountContractsTypeUpdateHistoryFunctionName = "updateHistory"
 const AuthAccountContractsTypeUpdateExperimentalFunctionName = "update__experimental"
+const AuthAccountContractsTypeUpdateWithMigrationFunctionName = "updateWithMigration"
+const AuthAccountContractsTypeUpdateWithResultFunctionName = "updateWithResult"
 const AuthAccountContractsTypeNamesField = "names"
 
 // AuthAccountContractsType represents the type `AuthAccount.Contracts`
@@ -46,12 +57,42 @@ var AuthAccountContractsType = func() *CompositeType {
 			AuthAccountContractsTypeAddFunctionType,
 			authAccountContractsTypeAddFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeAddInferredFunctionName,
+			AuthAccountContractsTypeAddInferredFunctionType,
+			authAccountContractsTypeAddInferredFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeAddWithResultFunctionName,
+			AuthAccountContractsTypeAddWithResultFunctionType,
+			authAccountContractsTypeAddWithResultFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeAddWithInitFunctionName,
+			AuthAccountContractsTypeAddWithInitFunctionType,
+			authAccountContractsTypeAddWithInitFunctionDocString,
+		),
 		NewUnmeteredPublicFunctionMember(
 			authAccountContractsType,
 			AuthAccountContractsTypeUpdateExperimentalFunctionName,
 			AuthAccountContractsTypeUpdateExperimentalFunctionType,
 			authAccountContractsTypeUpdateExperimentalFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeUpdateWithMigrationFunctionName,
+			AuthAccountContractsTypeUpdateWithMigrationFunctionType,
+			authAccountContractsTypeUpdateWithMigrationFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeUpdateWithResultFunctionName,
+			AuthAccountContractsTypeUpdateWithResultFunctionType,
+			authAccountContractsTypeUpdateWithResultFunctionDocString,
+		),
 		NewUnmeteredPublicFunctionMember(
 			authAccountContractsType,
 			AuthAccountContractsTypeGetFunctionName,
@@ -64,6 +105,42 @@ var AuthAccountContractsType = func() *CompositeType {
 			AuthAccountContractsTypeRemoveFunctionType,
 			authAccountContractsTypeRemoveFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeRemoveWithResultFunctionName,
+			AuthAccountContractsTypeRemoveWithResultFunctionType,
+			authAccountContractsTypeRemoveWithResultFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeEnumTypesFunctionName,
+			AuthAccountContractsTypeEnumTypesFunctionType,
+			authAccountContractsTypeEnumTypesFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeGetContractTypeFunctionName,
+			AuthAccountContractsTypeGetContractTypeFunctionType,
+			authAccountContractsTypeGetContractTypeFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeNamesWithPrefixFunctionName,
+			AuthAccountContractsTypeNamesWithPrefixFunctionType,
+			authAccountContractsTypeNamesWithPrefixFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeManifestFunctionName,
+			AuthAccountContractsTypeManifestFunctionType,
+			authAccountContractsTypeManifestFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeUpdateHistoryFunctionName,
+			AuthAccountContractsTypeUpdateHistoryFunctionType,
+			authAccountContractsTypeUpdateHistoryFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			authAccountContractsType,
 			AuthAccountContractsTypeNamesField,
@@ -123,6 +200,131 @@ var AuthAccountContractsTypeAddFunctionType = &FunctionType{
 	RequiredArgumentCount: RequiredArgumentCount(2),
 }
 
+const authAccountContractsTypeAddInferredFunctionDocString = `
+Adds the given contract to the account,
+under the name of the contract or contract interface declared in the code.
+
+The ` + "`code`" + ` parameter is the UTF-8 encoded representation of the source code.
+The code must contain exactly one contract or contract interface.
+
+All additional arguments that are given are passed further to the initializer
+of the contract that is being deployed.
+
+Fails if a contract/contract interface with the declared name already exists in the account,
+or if the given code does not declare exactly one contract or contract interface.
+
+Returns the deployed contract.
+`
+
+var AuthAccountContractsTypeAddInferredFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeployedContractType,
+	),
+	// additional arguments are passed to the contract initializer
+	RequiredArgumentCount: RequiredArgumentCount(1),
+}
+
+const authAccountContractsTypeAddWithResultFunctionDocString = `
+Adds the given contract to the account, like ` + "`add`" + `.
+
+Returns the result of the deployment, which contains the deployed contract
+and the hash of the deployed code.
+`
+
+var AuthAccountContractsTypeAddWithResultFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeploymentResultType,
+	),
+	// additional arguments are passed to the contract initializer
+	RequiredArgumentCount: RequiredArgumentCount(2),
+}
+
+const authAccountContractsTypeAddWithInitFunctionDocString = `
+Adds the given contract to the account, like ` + "`add`" + `,
+and calls the given post-init function with a reference to the newly initialized contract.
+
+The post-init function may check invariants of the contract and panic to abort the deployment.
+The post-init function is called before the code is deployed,
+so if it fails, no code is deployed and the contract is not stored.
+
+Fails if the given code does not declare a contract.
+
+All additional arguments that are given are passed further to the initializer
+of the contract that is being deployed.
+
+Returns the deployed contract.
+`
+
+var AuthAccountContractsTypeAddWithInitFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+		{
+			Identifier: "postInit",
+			TypeAnnotation: NewTypeAnnotation(
+				&FunctionType{
+					Parameters: []*Parameter{
+						{
+							Label:      ArgumentLabelNotRequired,
+							Identifier: "contract",
+							TypeAnnotation: NewTypeAnnotation(
+								AuthAccountContractsTypePostInitReferenceType,
+							),
+						},
+					},
+					ReturnTypeAnnotation: NewTypeAnnotation(
+						VoidType,
+					),
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeployedContractType,
+	),
+	// additional arguments are passed to the contract initializer
+	RequiredArgumentCount: RequiredArgumentCount(3),
+}
+
+// AuthAccountContractsTypePostInitReferenceType is the type of the reference to the newly initialized contract value,
+// which is passed to the post-init function of `addWithInit`
+//
+var AuthAccountContractsTypePostInitReferenceType = &ReferenceType{
+	Type: AnyStructType,
+}
+
 const authAccountContractsTypeUpdateExperimentalFunctionDocString = `
 **Experimental**
 
@@ -162,6 +364,102 @@ var AuthAccountContractsTypeUpdateExperimentalFunctionType = &FunctionType{
 	),
 }
 
+const authAccountContractsTypeUpdateWithMigrationFunctionDocString = `
+Updates the code for the contract in the account,
+and migrates the stored contract value using the given migration function.
+
+The ` + "`code`" + ` parameter is the UTF-8 encoded representation of the source code.
+The code must contain exactly one contract,
+which must have the same name as the ` + "`name`" + ` parameter.
+
+After the update is validated, the migration function is called with an authorized reference
+to the stored contract value, before the code of the contract is updated.
+If the migration function fails, the update fails as a whole.
+
+Does **not** run the initializer of the contract again.
+
+Fails if no contract with the given name exists in the account,
+if the given code does not declare exactly one contract,
+or if the given name does not match the name of the contract declaration in the code.
+
+Returns the deployed contract for the updated contract.
+`
+
+var AuthAccountContractsTypeUpdateWithMigrationFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+		{
+			Identifier: "migrate",
+			TypeAnnotation: NewTypeAnnotation(
+				&FunctionType{
+					Parameters: []*Parameter{
+						{
+							Label:      ArgumentLabelNotRequired,
+							Identifier: "contract",
+							TypeAnnotation: NewTypeAnnotation(
+								AuthAccountContractsTypeMigrationReferenceType,
+							),
+						},
+					},
+					ReturnTypeAnnotation: NewTypeAnnotation(
+						VoidType,
+					),
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeployedContractType,
+	),
+}
+
+const authAccountContractsTypeUpdateWithResultFunctionDocString = `
+Updates the code for the contract/contract interface in the account, like ` + "`update__experimental`" + `.
+
+Returns the result of the deployment, which contains the deployed contract,
+and the hashes of the code before and after the update.
+`
+
+var AuthAccountContractsTypeUpdateWithResultFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeploymentResultType,
+	),
+}
+
+// AuthAccountContractsTypeMigrationReferenceType is the type of the reference to the stored contract value,
+// which is passed to the migration function of `updateWithMigration`.
+// The reference is authorized, so it can be downcast to the contract's type
+//
+var AuthAccountContractsTypeMigrationReferenceType = &ReferenceType{
+	Authorized: true,
+	Type:       AnyStructType,
+}
+
 const authAccountContractsTypeGetFunctionDocString = `
 Returns the deployed contract for the contract/contract interface with the given name in the account, if any.
 
@@ -206,6 +504,130 @@ var AuthAccountContractsTypeRemoveFunctionType = &FunctionType{
 	),
 }
 
+const authAccountContractsTypeRemoveWithResultFunctionDocString = `
+Removes the contract/contract interface from the account which has the given name, if any, like ` + "`remove`" + `.
+
+Returns the result of the removal, which contains the removed contract,
+the hash of the removed code, and whether the removed code declared enums.
+
+Returns nil if no contract/contract interface with the given name exists in the account.
+`
+
+var AuthAccountContractsTypeRemoveWithResultFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: RemovalResultType,
+		},
+	),
+}
+
+const authAccountContractsTypeEnumTypesFunctionDocString = `
+Returns the types of all enums declared by the contract/contract interface
+in the account which has the given name, including nested enums.
+
+Contracts which declare enums cannot be removed,
+so this can be used to find stored enum values which must be removed first.
+
+Returns an empty array if no contract/contract interface with the given name exists in the account,
+or if it does not declare any enums.
+`
+
+var AuthAccountContractsTypeEnumTypesFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: MetaType,
+		},
+	),
+}
+
+const authAccountContractsTypeGetContractTypeFunctionDocString = `
+Returns the type of the contract/contract interface in the account which has the given name, if any.
+
+Returns nil if no contract/contract interface with the given name exists in the account.
+`
+
+var AuthAccountContractsTypeGetContractTypeFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: MetaType,
+		},
+	),
+}
+
+const authAccountContractsTypeNamesWithPrefixFunctionDocString = `
+Returns the names of all contracts deployed in the account which start with the given prefix.
+
+Returns an empty array if no contract name starts with the given prefix.
+`
+
+var AuthAccountContractsTypeNamesWithPrefixFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "prefix",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: StringType,
+		},
+	),
+}
+
+const authAccountContractsTypeManifestFunctionDocString = `
+Returns the name, the code hash, and the code size of each contract deployed in the account,
+without returning the code of the contracts.
+`
+
+var AuthAccountContractsTypeManifestFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: ContractInfoType,
+		},
+	),
+}
+
+const authAccountContractsTypeUpdateHistoryFunctionDocString = `
+Returns the code hash, the version number, and the timestamp of each recorded version of the contract with the given name,
+starting with the oldest version.
+
+Returns an empty array if no history is recorded for the contract.
+`
+
+var AuthAccountContractsTypeUpdateHistoryFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: ContractVersionType,
+		},
+	),
+	RequiredArgumentCount: RequiredArgumentCount(1),
+}
+
 const authAccountContractsTypeGetNamesDocString = `
 Names of all contracts deployed in the account.
 `
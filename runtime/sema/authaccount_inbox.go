@@ -0,0 +1,177 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const AuthAccountInboxTypeName = "Inbox"
+const AuthAccountInboxTypePublishFunctionName = "publish"
+const AuthAccountInboxTypeUnpublishFunctionName = "unpublish"
+const AuthAccountInboxTypeClaimFunctionName = "claim"
+
+// AuthAccountInboxType represents the type `AuthAccount.Inbox`
+//
+var AuthAccountInboxType = func() *CompositeType {
+
+	authAccountInboxType := &CompositeType{
+		Identifier: AuthAccountInboxTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewUnmeteredPublicFunctionMember(
+			authAccountInboxType,
+			AuthAccountInboxTypePublishFunctionName,
+			AuthAccountInboxTypePublishFunctionType,
+			authAccountInboxTypePublishFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountInboxType,
+			AuthAccountInboxTypeUnpublishFunctionName,
+			AuthAccountInboxTypeUnpublishFunctionType,
+			authAccountInboxTypeUnpublishFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountInboxType,
+			AuthAccountInboxTypeClaimFunctionName,
+			AuthAccountInboxTypeClaimFunctionType,
+			authAccountInboxTypeClaimFunctionDocString,
+		),
+	}
+
+	authAccountInboxType.Members = GetMembersAsMap(members)
+	authAccountInboxType.Fields = GetFieldNames(members)
+	return authAccountInboxType
+}()
+
+func init() {
+	// Set the container type after initializing the `AuthAccountInboxType`, to avoid initializing loop.
+	AuthAccountInboxType.SetContainerType(AuthAccountType)
+}
+
+const authAccountInboxTypePublishFunctionDocString = `
+Publishes the given capability under the given name, to be claimed by the given recipient.
+
+Fails if there is already a value published under the given name.
+`
+
+var AuthAccountInboxTypePublishFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: NewTypeAnnotation(&CapabilityType{}),
+		},
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+		{
+			Identifier:     "recipient",
+			TypeAnnotation: NewTypeAnnotation(&AddressType{}),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
+
+const authAccountInboxTypeUnpublishFunctionDocString = `
+Unpublishes the capability published under the given name, and returns it.
+
+Returns nil if no capability is published under the given name,
+or if the published capability cannot be borrowed as the given type
+`
+
+var AuthAccountInboxTypeUnpublishFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "name",
+				TypeAnnotation: NewTypeAnnotation(StringType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &CapabilityType{
+					BorrowType: &GenericType{
+						TypeParameter: typeParameter,
+					},
+				},
+			},
+		),
+	}
+}()
+
+const authAccountInboxTypeClaimFunctionDocString = `
+Claims the capability published under the given name by the given provider account for this account,
+and returns it.
+
+Returns nil if the provider did not publish a capability under the given name for this account,
+or if the published capability cannot be borrowed as the given type
+`
+
+var AuthAccountInboxTypeClaimFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "name",
+				TypeAnnotation: NewTypeAnnotation(StringType),
+			},
+			{
+				Identifier:     "provider",
+				TypeAnnotation: NewTypeAnnotation(&AddressType{}),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &CapabilityType{
+					BorrowType: &GenericType{
+						TypeParameter: typeParameter,
+					},
+				},
+			},
+		),
+	}
+}()
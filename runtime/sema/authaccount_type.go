@@ -26,24 +26,31 @@ const AuthAccountTypeName = "AuthAccount"
 const AuthAccountAddressField = "address"
 const AuthAccountBalanceField = "balance"
 const AuthAccountAvailableBalanceField = "availableBalance"
+const AuthAccountGetBalanceField = "getBalance"
 const AuthAccountStorageUsedField = "storageUsed"
+const AuthAccountStorageUsedByDomainField = "storageUsedByDomain"
 const AuthAccountStorageCapacityField = "storageCapacity"
 const AuthAccountAddPublicKeyField = "addPublicKey"
 const AuthAccountRemovePublicKeyField = "removePublicKey"
+const AuthAccountAddEncodedKeyWithMetadataField = "addEncodedKeyWithMetadata"
 const AuthAccountSaveField = "save"
 const AuthAccountLoadField = "load"
 const AuthAccountTypeField = "type"
+const AuthAccountStoragePathsExistField = "storagePathsExist"
 const AuthAccountCopyField = "copy"
 const AuthAccountBorrowField = "borrow"
 const AuthAccountLinkField = "link"
 const AuthAccountUnlinkField = "unlink"
 const AuthAccountGetCapabilityField = "getCapability"
 const AuthAccountGetLinkTargetField = "getLinkTarget"
+const AuthAccountGetLinkTargetTypeField = "getLinkTargetType"
 const AuthAccountForEachPublicField = "forEachPublic"
 const AuthAccountForEachPrivateField = "forEachPrivate"
 const AuthAccountForEachStoredField = "forEachStored"
 const AuthAccountContractsField = "contracts"
 const AuthAccountKeysField = "keys"
+const AuthAccountCapabilitiesField = "capabilities"
+const AuthAccountInboxField = "inbox"
 const AuthAccountPublicPathsField = "publicPaths"
 const AuthAccountPrivatePathsField = "privatePaths"
 const AuthAccountStoragePathsField = "storagePaths"
@@ -59,10 +66,13 @@ var AuthAccountType = func() *CompositeType {
 		Kind:               common.CompositeKindStructure,
 		hasComputedMembers: true,
 		importable:         false,
+		equatable:          true,
 		NestedTypes: func() *StringTypeOrderedMap {
 			nestedTypes := &StringTypeOrderedMap{}
 			nestedTypes.Set(AuthAccountContractsTypeName, AuthAccountContractsType)
 			nestedTypes.Set(AccountKeysTypeName, AuthAccountKeysType)
+			nestedTypes.Set(AuthAccountCapabilitiesTypeName, AuthAccountCapabilitiesType)
+			nestedTypes.Set(AuthAccountInboxTypeName, AuthAccountInboxType)
 			return nestedTypes
 		}(),
 	}
@@ -86,12 +96,24 @@ var AuthAccountType = func() *CompositeType {
 			UFix64Type,
 			accountTypeAccountAvailableBalanceFieldDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountType,
+			AuthAccountGetBalanceField,
+			AccountTypeGetBalanceFunctionType,
+			accountTypeGetBalanceFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			authAccountType,
 			AuthAccountStorageUsedField,
 			UInt64Type,
 			accountTypeStorageUsedFieldDocString,
 		),
+		NewUnmeteredPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountStorageUsedByDomainField,
+			AuthAccountStorageUsedByDomainType,
+			authAccountTypeStorageUsedByDomainFieldDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			authAccountType,
 			AuthAccountStorageCapacityField,
@@ -110,6 +132,12 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountTypeRemovePublicKeyFunctionType,
 			authAccountTypeRemovePublicKeyFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountType,
+			AuthAccountAddEncodedKeyWithMetadataField,
+			AuthAccountTypeAddEncodedKeyWithMetadataFunctionType,
+			authAccountTypeAddEncodedKeyWithMetadataFunctionDocString,
+		),
 		NewUnmeteredPublicFunctionMember(
 			authAccountType,
 			AuthAccountSaveField,
@@ -122,6 +150,12 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountTypeTypeFunctionType,
 			authAccountTypeTypeFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountType,
+			AuthAccountStoragePathsExistField,
+			AuthAccountTypeStoragePathsExistFunctionType,
+			authAccountTypeStoragePathsExistFunctionDocString,
+		),
 		NewUnmeteredPublicFunctionMember(
 			authAccountType,
 			AuthAccountLoadField,
@@ -164,6 +198,12 @@ var AuthAccountType = func() *CompositeType {
 			AccountTypeGetLinkTargetFunctionType,
 			accountTypeGetLinkTargetFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			authAccountType,
+			AuthAccountGetLinkTargetTypeField,
+			AuthAccountTypeGetLinkTargetTypeFunctionType,
+			authAccountTypeGetLinkTargetTypeFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			authAccountType,
 			AuthAccountContractsField,
@@ -176,6 +216,18 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountKeysType,
 			accountTypeKeysFieldDocString,
 		),
+		NewUnmeteredPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountCapabilitiesField,
+			AuthAccountCapabilitiesType,
+			authAccountTypeCapabilitiesFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountInboxField,
+			AuthAccountInboxType,
+			authAccountTypeInboxFieldDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			authAccountType,
 			AuthAccountPublicPathsField,
@@ -320,6 +372,41 @@ const authAccountTypeRemovePublicKeyFunctionDocString = `
 Removes the public key at the given index from the account's keys
 `
 
+var AuthAccountTypeAddEncodedKeyWithMetadataFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "encodedKey",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+		{
+			Identifier: AccountKeyHashAlgoField,
+			TypeAnnotation: NewTypeAnnotation(
+				HashAlgorithmType,
+			),
+		},
+		{
+			Identifier: AccountKeyWeightField,
+			TypeAnnotation: NewTypeAnnotation(
+				UFix64Type,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		AccountKeyType,
+	),
+}
+
+const authAccountTypeAddEncodedKeyWithMetadataFunctionDocString = `
+Adds the public key of the given encoded key, in the encoding accepted by ` + "`addPublicKey`" + `,
+to the account's keys, using the given hash algorithm and weight.
+
+The hash algorithm and weight in the encoded key are ignored.
+
+Returns the added key.
+`
+
 var AuthAccountTypeSaveFunctionType = func() *FunctionType {
 
 	typeParameter := &TypeParameter{
@@ -411,6 +498,31 @@ var AuthAccountTypeTypeFunctionType = &FunctionType{
 	),
 }
 
+const authAccountTypeStoragePathsExistFunctionDocString = `
+Returns for each of the given storage paths if an object is stored under the path in the account's storage.
+
+The objects are not read, so checking many paths at once is cheaper than reading the type of each object
+`
+
+var AuthAccountTypeStoragePathsExistFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "paths",
+			TypeAnnotation: NewTypeAnnotation(
+				&VariableSizedType{
+					Type: StoragePathType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: BoolType,
+		},
+	),
+}
+
 const authAccountTypeLoadFunctionDocString = `
 Loads an object from the account's storage which is stored under the given path, or nil if no object is stored under the given path.
 
@@ -625,6 +737,37 @@ var AccountTypeGetLinkTargetFunctionType = &FunctionType{
 	),
 }
 
+const AccountGetBalanceOfTypeParameterName = "ofType"
+
+var AccountTypeGetBalanceFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     AccountGetBalanceOfTypeParameterName,
+			TypeAnnotation: NewTypeAnnotation(MetaType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: UFix64Type,
+		},
+	),
+}
+
+var AuthAccountTypeGetLinkTargetTypeFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(CapabilityPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: MetaType,
+		},
+	),
+}
+
 // AuthAccountKeysType represents the keys associated with an auth account.
 var AuthAccountKeysType = func() *CompositeType {
 
@@ -647,12 +790,48 @@ var AuthAccountKeysType = func() *CompositeType {
 			AccountKeysTypeGetFunctionType,
 			accountKeysTypeGetFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysFindFunctionName,
+			AccountKeysTypeFindFunctionType,
+			accountKeysTypeFindFunctionDocString,
+		),
 		NewUnmeteredPublicFunctionMember(
 			accountKeys,
 			AccountKeysRevokeFunctionName,
 			AuthAccountKeysTypeRevokeFunctionType,
 			authAccountKeysTypeRevokeFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysRotateFunctionName,
+			AuthAccountKeysTypeRotateFunctionType,
+			authAccountKeysTypeRotateFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysSetActiveFunctionName,
+			AuthAccountKeysTypeSetActiveFunctionType,
+			authAccountKeysTypeSetActiveFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysTotalWeightFunctionName,
+			AccountKeysTypeTotalWeightFunctionType,
+			accountKeysTypeTotalWeightFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysMeetsThresholdFunctionName,
+			AccountKeysTypeMeetsThresholdFunctionType,
+			accountKeysTypeMeetsThresholdFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysActiveKeyIndicesFunctionName,
+			AccountKeysTypeActiveKeyIndicesFunctionType,
+			accountKeysTypeActiveKeyIndicesFunctionDocString,
+		),
 	}
 
 	accountKeys.Members = GetMembersAsMap(members)
@@ -690,6 +869,21 @@ var AccountKeysTypeGetFunctionType = &FunctionType{
 	RequiredArgumentCount: RequiredArgumentCount(1),
 }
 
+var AccountKeysTypeFindFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     AccountKeyKeyIndexField,
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+		{
+			Identifier:     AccountKeysIncludeRevokedParameterName,
+			TypeAnnotation: NewTypeAnnotation(BoolType),
+		},
+	},
+	ReturnTypeAnnotation:  NewTypeAnnotation(&OptionalType{Type: AccountKeyType}),
+	RequiredArgumentCount: RequiredArgumentCount(2),
+}
+
 var AuthAccountKeysTypeRevokeFunctionType = &FunctionType{
 	Parameters: []*Parameter{
 		{
@@ -701,6 +895,75 @@ var AuthAccountKeysTypeRevokeFunctionType = &FunctionType{
 	RequiredArgumentCount: RequiredArgumentCount(1),
 }
 
+var AuthAccountKeysTypeRotateFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     AccountKeysOldIndexParameterName,
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+		{
+			Identifier:     AccountKeysNewKeyParameterName,
+			TypeAnnotation: NewTypeAnnotation(PublicKeyType),
+		},
+		{
+			Identifier:     AccountKeyHashAlgoField,
+			TypeAnnotation: NewTypeAnnotation(HashAlgorithmType),
+		},
+		{
+			Identifier:     AccountKeyWeightField,
+			TypeAnnotation: NewTypeAnnotation(UFix64Type),
+		},
+	},
+	ReturnTypeAnnotation:  NewTypeAnnotation(AccountKeyType),
+	RequiredArgumentCount: RequiredArgumentCount(4),
+}
+
+var AuthAccountKeysTypeSetActiveFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     AccountKeyKeyIndexField,
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+		{
+			Identifier:     AccountKeysActiveParameterName,
+			TypeAnnotation: NewTypeAnnotation(BoolType),
+		},
+	},
+	ReturnTypeAnnotation:  NewTypeAnnotation(VoidType),
+	RequiredArgumentCount: RequiredArgumentCount(2),
+}
+
+var AccountKeysTypeTotalWeightFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(UFix64Type),
+}
+
+var AccountKeysTypeMeetsThresholdFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: AccountKeysSignedKeyIndicesParameterName,
+			TypeAnnotation: NewTypeAnnotation(
+				&VariableSizedType{
+					Type: IntType,
+				},
+			),
+		},
+		{
+			Identifier:     AccountKeysThresholdParameterName,
+			TypeAnnotation: NewTypeAnnotation(UFix64Type),
+		},
+	},
+	ReturnTypeAnnotation:  NewTypeAnnotation(BoolType),
+	RequiredArgumentCount: RequiredArgumentCount(2),
+}
+
+var AccountKeysTypeActiveKeyIndicesFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: IntType,
+		},
+	),
+}
+
 func init() {
 	// Set the container type after initializing the AccountKeysTypes, to avoid initializing loop.
 	AuthAccountKeysType.SetContainerType(AuthAccountType)
@@ -709,12 +972,28 @@ func init() {
 const AccountKeysTypeName = "Keys"
 const AccountKeysAddFunctionName = "add"
 const AccountKeysGetFunctionName = "get"
+const AccountKeysFindFunctionName = "find"
+const AccountKeysIncludeRevokedParameterName = "includeRevoked"
 const AccountKeysRevokeFunctionName = "revoke"
+const AccountKeysRotateFunctionName = "rotate"
+const AccountKeysOldIndexParameterName = "oldIndex"
+const AccountKeysNewKeyParameterName = "newKey"
+const AccountKeysSetActiveFunctionName = "setActive"
+const AccountKeysActiveParameterName = "active"
+const AccountKeysTotalWeightFunctionName = "totalWeight"
+const AccountKeysMeetsThresholdFunctionName = "meetsThreshold"
+const AccountKeysSignedKeyIndicesParameterName = "signedKeyIndices"
+const AccountKeysThresholdParameterName = "threshold"
+const AccountKeysActiveKeyIndicesFunctionName = "activeKeyIndices"
 
 const accountTypeGetLinkTargetFunctionDocString = `
 Returns the target path of the capability at the given public or private path, or nil if there exists no capability at the given path.
 `
 
+const authAccountTypeGetLinkTargetTypeFunctionDocString = `
+Returns the borrow type of the link at the given public or private path, or nil if there exists no link at the given path.
+`
+
 const accountTypeAddressFieldDocString = `
 The address of the account
 `
@@ -731,10 +1010,30 @@ const accountTypeAccountAvailableBalanceFieldDocString = `
 The FLOW balance of the default vault of this account that is available to be moved
 `
 
+const accountTypeGetBalanceFunctionDocString = `
+Returns the balance of the vault of the given type of this account, or nil if the given type is not a resource type
+`
+
 const accountTypeStorageUsedFieldDocString = `
 The current amount of storage used by the account in bytes
 `
 
+const authAccountTypeStorageUsedByDomainFieldDocString = `
+The current amount of storage used by the account in bytes, broken down by path domain,
+or nil if the breakdown is not available
+`
+
+// AuthAccountStorageUsedByDomainType is the type of the field `storageUsedByDomain`,
+// which maps path domain identifiers to the amount of storage used in bytes.
+// The breakdown is optional, as not all host environments provide it
+//
+var AuthAccountStorageUsedByDomainType = &OptionalType{
+	Type: &DictionaryType{
+		KeyType:   StringType,
+		ValueType: UInt64Type,
+	},
+}
+
 const accountTypeStorageCapacityFieldDocString = `
 The storage capacity of the account in bytes
 `
@@ -743,14 +1042,57 @@ const accountTypeKeysFieldDocString = `
 The keys associated with the account
 `
 
+const authAccountTypeCapabilitiesFieldDocString = `
+The capabilities of the account
+`
+
+const authAccountTypeInboxFieldDocString = `
+The inbox of the account, which allows publishing capabilities to other accounts, and claiming capabilities from them
+`
+
 const authAccountKeysTypeAddFunctionDocString = `
 Adds the given key to the keys list of the account.
 `
 
 const accountKeysTypeGetFunctionDocString = `
 Retrieves the key at the given index of the account.
+
+Revoked keys are also returned, use the ` + "`isRevoked`" + ` field of the key to check if it is revoked.
+`
+
+const accountKeysTypeFindFunctionDocString = `
+Retrieves the key at the given index of the account.
+
+Revoked keys are only returned if ` + "`includeRevoked`" + ` is true, otherwise nil is returned for them.
 `
 
 const authAccountKeysTypeRevokeFunctionDocString = `
 Revokes the key at the given index of the account.
 `
+
+const authAccountKeysTypeRotateFunctionDocString = `
+Atomically adds the given new key to the account and revokes the key at the given old index.
+
+If either step fails, neither takes effect. Returns the added key.
+`
+
+const authAccountKeysTypeSetActiveFunctionDocString = `
+Activates or deactivates the key at the given index of the account.
+
+Unlike revocation, deactivation is reversible. The status of revoked keys cannot be changed.
+`
+
+const accountKeysTypeTotalWeightFunctionDocString = `
+Returns the sum of the weights of all keys of the account which are not revoked.
+`
+
+const accountKeysTypeMeetsThresholdFunctionDocString = `
+Returns true if the sum of the weights of the keys at the given indices is at least the given threshold.
+
+Each key is only counted once, even if its index is given multiple times.
+Returns false if any of the given indices refers to a key which does not exist, is revoked, or is inactive.
+`
+
+const accountKeysTypeActiveKeyIndicesFunctionDocString = `
+Returns the indices of all keys of the account which are neither revoked nor inactive, in ascending order.
+`
@@ -0,0 +1,69 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const ContractInfoTypeName = "ContractInfo"
+const ContractInfoTypeNameFieldName = "name"
+const ContractInfoTypeCodeHashFieldName = "codeHash"
+const ContractInfoTypeCodeSizeFieldName = "codeSize"
+
+// ContractInfoType represents the type `ContractInfo`,
+// which is returned by `AuthAccount.contracts.manifest` and `PublicAccount.contracts.manifest`
+//
+var ContractInfoType = func() *CompositeType {
+
+	contractInfoType := &CompositeType{
+		Identifier: ContractInfoTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	const contractInfoTypeNameFieldDocString = `The name of the contract`
+	const contractInfoTypeCodeHashFieldDocString = `The SHA3-256 hash of the code of the contract`
+	const contractInfoTypeCodeSizeFieldDocString = `The size of the code of the contract, in bytes`
+
+	var members = []*Member{
+		NewUnmeteredPublicConstantFieldMember(
+			contractInfoType,
+			ContractInfoTypeNameFieldName,
+			StringType,
+			contractInfoTypeNameFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			contractInfoType,
+			ContractInfoTypeCodeHashFieldName,
+			ByteArrayType,
+			contractInfoTypeCodeHashFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			contractInfoType,
+			ContractInfoTypeCodeSizeFieldName,
+			IntType,
+			contractInfoTypeCodeSizeFieldDocString,
+		),
+	}
+
+	contractInfoType.Members = GetMembersAsMap(members)
+	contractInfoType.Fields = GetFieldNames(members)
+	return contractInfoType
+}()
@@ -0,0 +1,69 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const ContractVersionTypeName = "ContractVersion"
+const ContractVersionTypeVersionFieldName = "version"
+const ContractVersionTypeCodeHashFieldName = "codeHash"
+const ContractVersionTypeTimestampFieldName = "timestamp"
+
+// ContractVersionType represents the type `ContractVersion`,
+// which is returned by `AuthAccount.contracts.updateHistory` and `PublicAccount.contracts.updateHistory`
+//
+var ContractVersionType = func() *CompositeType {
+
+	contractVersionType := &CompositeType{
+		Identifier: ContractVersionTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	const contractVersionTypeVersionFieldDocString = `The version number of the contract, starting at 1 for the initial deployment`
+	const contractVersionTypeCodeHashFieldDocString = `The SHA3-256 hash of the code of this version of the contract`
+	const contractVersionTypeTimestampFieldDocString = `The timestamp of the block in which this version was deployed, in seconds since the Unix epoch`
+
+	var members = []*Member{
+		NewUnmeteredPublicConstantFieldMember(
+			contractVersionType,
+			ContractVersionTypeVersionFieldName,
+			UInt64Type,
+			contractVersionTypeVersionFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			contractVersionType,
+			ContractVersionTypeCodeHashFieldName,
+			ByteArrayType,
+			contractVersionTypeCodeHashFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			contractVersionType,
+			ContractVersionTypeTimestampFieldName,
+			UFix64Type,
+			contractVersionTypeTimestampFieldDocString,
+		),
+	}
+
+	contractVersionType.Members = GetMembersAsMap(members)
+	contractVersionType.Fields = GetFieldNames(members)
+	return contractVersionType
+}()
@@ -152,6 +152,34 @@ const HashAlgorithmTypeHashWithTagFunctionDocString = `
 Returns the hash of the given data and tag
 `
 
+const HashAlgorithmTypeHashWithSaltFunctionName = "hashWithSalt"
+
+var HashAlgorithmTypeHashWithSaltFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "data",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+		{
+			Identifier: "salt",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		ByteArrayType,
+	),
+}
+
+const HashAlgorithmTypeHashWithSaltFunctionDocString = `
+Returns the hash of the given salt, followed by the given data.
+The salt must be between 16 and 64 bytes long
+`
+
 var HashAlgorithmType = newNativeEnumType(
 	HashAlgorithmTypeName,
 	UInt8Type,
@@ -169,6 +197,12 @@ var HashAlgorithmType = newNativeEnumType(
 				HashAlgorithmTypeHashWithTagFunctionType,
 				HashAlgorithmTypeHashWithTagFunctionDocString,
 			),
+			NewUnmeteredPublicFunctionMember(
+				enumType,
+				HashAlgorithmTypeHashWithSaltFunctionName,
+				HashAlgorithmTypeHashWithSaltFunctionType,
+				HashAlgorithmTypeHashWithSaltFunctionDocString,
+			),
 		}
 	},
 )
@@ -0,0 +1,79 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const DeploymentResultTypeName = "DeploymentResult"
+const DeploymentResultTypeContractFieldName = "contract"
+const DeploymentResultTypeWasUpdateFieldName = "wasUpdate"
+const DeploymentResultTypeOldCodeHashFieldName = "oldCodeHash"
+const DeploymentResultTypeCodeHashFieldName = "codeHash"
+
+// DeploymentResultType represents the type `DeploymentResult`,
+// which is returned by `AuthAccount.contracts.addWithResult` and `AuthAccount.contracts.updateWithResult`
+//
+var DeploymentResultType = func() *CompositeType {
+
+	deploymentResultType := &CompositeType{
+		Identifier: DeploymentResultTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	const deploymentResultTypeContractFieldDocString = `The deployed contract`
+	const deploymentResultTypeWasUpdateFieldDocString = `Flag indicating whether an existing contract was updated`
+	const deploymentResultTypeOldCodeHashFieldDocString = `The SHA3-256 hash of the code before the update, or nil if the contract was added`
+	const deploymentResultTypeCodeHashFieldDocString = `The SHA3-256 hash of the deployed code`
+
+	var members = []*Member{
+		NewUnmeteredPublicConstantFieldMember(
+			deploymentResultType,
+			DeploymentResultTypeContractFieldName,
+			DeployedContractType,
+			deploymentResultTypeContractFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			deploymentResultType,
+			DeploymentResultTypeWasUpdateFieldName,
+			BoolType,
+			deploymentResultTypeWasUpdateFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			deploymentResultType,
+			DeploymentResultTypeOldCodeHashFieldName,
+			&OptionalType{
+				Type: ByteArrayType,
+			},
+			deploymentResultTypeOldCodeHashFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			deploymentResultType,
+			DeploymentResultTypeCodeHashFieldName,
+			ByteArrayType,
+			deploymentResultTypeCodeHashFieldDocString,
+		),
+	}
+
+	deploymentResultType.Members = GetMembersAsMap(members)
+	deploymentResultType.Fields = GetFieldNames(members)
+	return deploymentResultType
+}()
@@ -24,6 +24,11 @@ import (
 
 const PublicAccountContractsTypeName = "Contracts"
 const PublicAccountContractsTypeGetFunctionName = "get"
+const PublicAccountContractsTypeBorrowContractFunctionName = "borrowContract"
+const PublicAccountContractsTypeGetContractTypeFunctionName = "getContractType"
+const PublicAccountContractsTypeNamesWithPrefixFunctionName = "namesWithPrefix"
+const PublicAccountContractsTypeManifestFunctionName = "manifest"
+const PublicAccountContractsTypeUpdateHistoryFunctionName = "updateHistory"
 const PublicAccountContractsTypeNamesField = "names"
 
 // PublicAccountContractsType represents the type `PublicAccount.Contracts`
@@ -43,6 +48,36 @@ var PublicAccountContractsType = func() *CompositeType {
 			publicAccountContractsTypeGetFunctionType,
 			publicAccountContractsTypeGetFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountContractsType,
+			PublicAccountContractsTypeBorrowContractFunctionName,
+			PublicAccountContractsTypeBorrowContractFunctionType,
+			publicAccountContractsTypeBorrowContractFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountContractsType,
+			PublicAccountContractsTypeGetContractTypeFunctionName,
+			publicAccountContractsTypeGetContractTypeFunctionType,
+			publicAccountContractsTypeGetContractTypeFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountContractsType,
+			PublicAccountContractsTypeNamesWithPrefixFunctionName,
+			publicAccountContractsTypeNamesWithPrefixFunctionType,
+			publicAccountContractsTypeNamesWithPrefixFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountContractsType,
+			PublicAccountContractsTypeManifestFunctionName,
+			PublicAccountContractsTypeManifestFunctionType,
+			publicAccountContractsTypeManifestFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountContractsType,
+			PublicAccountContractsTypeUpdateHistoryFunctionName,
+			PublicAccountContractsTypeUpdateHistoryFunctionType,
+			publicAccountContractsTypeUpdateHistoryFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			publicAccountContractsType,
 			PublicAccountContractsTypeNamesField,
@@ -85,6 +120,113 @@ var publicAccountContractsTypeGetFunctionType = &FunctionType{
 	),
 }
 
+const publicAccountContractsTypeBorrowContractFunctionDocString = `
+Returns a reference to the contract value of the contract with the given name in the account, if any.
+
+The concrete type of the contract does not need to be known statically.
+
+Returns nil if no contract with the given name exists in the account,
+or if the contract is not instantiated, e.g. it is a contract interface.
+`
+
+var PublicAccountContractsTypeBorrowContractFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: &ReferenceType{
+				Type: AnyStructType,
+			},
+		},
+	),
+}
+
+const publicAccountContractsTypeGetContractTypeFunctionDocString = `
+Returns the type of the contract/contract interface in the account which has the given name, if any.
+
+Returns nil if no contract/contract interface with the given name exists in the account.
+`
+
+var publicAccountContractsTypeGetContractTypeFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: MetaType,
+		},
+	),
+}
+
+const publicAccountContractsTypeNamesWithPrefixFunctionDocString = `
+Returns the names of all contracts deployed in the account which start with the given prefix.
+
+Returns an empty array if no contract name starts with the given prefix.
+`
+
+var publicAccountContractsTypeNamesWithPrefixFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "prefix",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: StringType,
+		},
+	),
+}
+
+const publicAccountContractsTypeManifestFunctionDocString = `
+Returns the name, the code hash, and the code size of each contract deployed in the account,
+without returning the code of the contracts.
+`
+
+var PublicAccountContractsTypeManifestFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: ContractInfoType,
+		},
+	),
+}
+
+const publicAccountContractsTypeUpdateHistoryFunctionDocString = `
+Returns the code hash, the version number, and the timestamp of each recorded version of the contract with the given name,
+starting with the oldest version.
+
+Returns an empty array if no history is recorded for the contract.
+`
+
+var PublicAccountContractsTypeUpdateHistoryFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: ContractVersionType,
+		},
+	),
+	RequiredArgumentCount: RequiredArgumentCount(1),
+}
+
 const publicAccountContractsTypeNamesDocString = `
 Names of all contracts deployed in the account.
 `
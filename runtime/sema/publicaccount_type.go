@@ -26,10 +26,12 @@ const PublicAccountTypeName = "PublicAccount"
 const PublicAccountAddressField = "address"
 const PublicAccountBalanceField = "balance"
 const PublicAccountAvailableBalanceField = "availableBalance"
+const PublicAccountGetBalanceField = "getBalance"
 const PublicAccountStorageUsedField = "storageUsed"
 const PublicAccountStorageCapacityField = "storageCapacity"
 const PublicAccountGetCapabilityField = "getCapability"
 const PublicAccountGetTargetLinkField = "getLinkTarget"
+const PublicAccountGetLinkTargetTypeField = "getLinkTargetType"
 const PublicAccountForEachPublicField = "forEachPublic"
 const PublicAccountKeysField = "keys"
 const PublicAccountContractsField = "contracts"
@@ -44,6 +46,7 @@ var PublicAccountType = func() *CompositeType {
 		Kind:               common.CompositeKindStructure,
 		hasComputedMembers: true,
 		importable:         false,
+		equatable:          true,
 		NestedTypes: func() *StringTypeOrderedMap {
 			nestedTypes := &StringTypeOrderedMap{}
 			nestedTypes.Set(AccountKeysTypeName, PublicAccountKeysType)
@@ -71,6 +74,12 @@ var PublicAccountType = func() *CompositeType {
 			UFix64Type,
 			accountTypeAccountAvailableBalanceFieldDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountType,
+			PublicAccountGetBalanceField,
+			AccountTypeGetBalanceFunctionType,
+			accountTypeGetBalanceFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			publicAccountType,
 			PublicAccountStorageUsedField,
@@ -95,6 +104,12 @@ var PublicAccountType = func() *CompositeType {
 			AccountTypeGetLinkTargetFunctionType,
 			accountTypeGetLinkTargetFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			publicAccountType,
+			PublicAccountGetLinkTargetTypeField,
+			PublicAccountTypeGetLinkTargetTypeFunctionType,
+			publicAccountTypeGetLinkTargetTypeFunctionDocString,
+		),
 		NewUnmeteredPublicConstantFieldMember(
 			publicAccountType,
 			PublicAccountKeysField,
@@ -189,6 +204,30 @@ var PublicAccountKeysType = func() *CompositeType {
 			AccountKeysTypeGetFunctionType,
 			accountKeysTypeGetFunctionDocString,
 		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysFindFunctionName,
+			AccountKeysTypeFindFunctionType,
+			accountKeysTypeFindFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysTotalWeightFunctionName,
+			AccountKeysTypeTotalWeightFunctionType,
+			accountKeysTypeTotalWeightFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysMeetsThresholdFunctionName,
+			AccountKeysTypeMeetsThresholdFunctionType,
+			accountKeysTypeMeetsThresholdFunctionDocString,
+		),
+		NewUnmeteredPublicFunctionMember(
+			accountKeys,
+			AccountKeysActiveKeyIndicesFunctionName,
+			AccountKeysTypeActiveKeyIndicesFunctionType,
+			accountKeysTypeActiveKeyIndicesFunctionDocString,
+		),
 	}
 
 	accountKeys.Members = GetMembersAsMap(members)
@@ -232,6 +271,25 @@ var PublicAccountTypeGetCapabilityFunctionType = func() *FunctionType {
 	}
 }()
 
+var PublicAccountTypeGetLinkTargetTypeFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(PublicPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: MetaType,
+		},
+	),
+}
+
+const publicAccountTypeGetLinkTargetTypeFunctionDocString = `
+Returns the borrow type of the link at the given public path, or nil if there exists no link at the given path.
+`
+
 const publicAccountTypeGetLinkTargetFunctionDocString = `
 Returns the capability at the given public path, or nil if it does not exist
 `
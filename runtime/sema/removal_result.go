@@ -0,0 +1,61 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const RemovalResultTypeName = "RemovalResult"
+const RemovalResultTypeContractFieldName = "contract"
+const RemovalResultTypeCodeHashFieldName = "codeHash"
+
+// RemovalResultType represents the type `RemovalResult`,
+// which is returned by `AuthAccount.contracts.removeWithResult`
+//
+var RemovalResultType = func() *CompositeType {
+
+	removalResultType := &CompositeType{
+		Identifier: RemovalResultTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	const removalResultTypeContractFieldDocString = `The removed contract`
+	const removalResultTypeCodeHashFieldDocString = `The SHA3-256 hash of the removed code`
+
+	var members = []*Member{
+		NewUnmeteredPublicConstantFieldMember(
+			removalResultType,
+			RemovalResultTypeContractFieldName,
+			DeployedContractType,
+			removalResultTypeContractFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			removalResultType,
+			RemovalResultTypeCodeHashFieldName,
+			ByteArrayType,
+			removalResultTypeCodeHashFieldDocString,
+		),
+	}
+
+	removalResultType.Members = GetMembersAsMap(members)
+	removalResultType.Fields = GetFieldNames(members)
+	return removalResultType
+}()
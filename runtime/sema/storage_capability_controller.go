@@ -0,0 +1,61 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const StorageCapabilityControllerTypeName = "StorageCapabilityController"
+const StorageCapabilityControllerTypeCapabilityIDFieldName = "capabilityID"
+const StorageCapabilityControllerTypeTargetFieldName = "target"
+
+// StorageCapabilityControllerType represents the type `StorageCapabilityController`,
+// which controls a capability issued against a storage path
+//
+var StorageCapabilityControllerType = func() *CompositeType {
+
+	storageCapabilityControllerType := &CompositeType{
+		Identifier: StorageCapabilityControllerTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	const storageCapabilityControllerTypeCapabilityIDFieldDocString = `The ID of the controlled capability`
+	const storageCapabilityControllerTypeTargetFieldDocString = `The storage path the controlled capability targets`
+
+	var members = []*Member{
+		NewUnmeteredPublicConstantFieldMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeCapabilityIDFieldName,
+			UInt64Type,
+			storageCapabilityControllerTypeCapabilityIDFieldDocString,
+		),
+		NewUnmeteredPublicConstantFieldMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeTargetFieldName,
+			StoragePathType,
+			storageCapabilityControllerTypeTargetFieldDocString,
+		),
+	}
+
+	storageCapabilityControllerType.Members = GetMembersAsMap(members)
+	storageCapabilityControllerType.Fields = GetFieldNames(members)
+	return storageCapabilityControllerType
+}()
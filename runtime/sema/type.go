@@ -1620,6 +1620,13 @@ It does not modify the original array.
 If either of the parameters are out of the bounds of the array, or the indices are invalid (` + "`from > upTo`" + `), then the function will fail.
 `
 
+const ArrayTypeToHexStringFunctionName = "toHexString"
+
+const arrayTypeToHexStringFunctionDocString = `
+Returns the lowercase hex encoding of the bytes in the array.
+Available if the array element type is ` + "`UInt8`" + `
+`
+
 func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 
 	members := map[string]MemberResolver{
@@ -1715,6 +1722,22 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 		},
 	}
 
+	if arrayType.ElementType(false).Equal(UInt8Type) {
+
+		members[ArrayTypeToHexStringFunctionName] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayToHexStringFunctionType,
+					arrayTypeToHexStringFunctionDocString,
+				)
+			},
+		}
+	}
+
 	// TODO: maybe still return members but report a helpful error?
 
 	if _, ok := arrayType.(*VariableSizedType); ok {
@@ -1979,6 +2002,12 @@ func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	}
 }
 
+var ArrayToHexStringFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
 func ArrayAppendAllFunctionType(arrayType Type) *FunctionType {
 	return &FunctionType{
 		Parameters: []*Parameter{
@@ -2965,6 +2994,11 @@ func init() {
 		PublicPathType,
 		&CapabilityType{},
 		DeployedContractType,
+		DeploymentResultType,
+		RemovalResultType,
+		ContractInfoType,
+		ContractVersionType,
+		StorageCapabilityControllerType,
 		BlockType,
 		AccountKeyType,
 		PublicKeyType,
@@ -3252,16 +3286,61 @@ func init() {
 		panic(errors.NewUnreachableError())
 	}
 
+	functionType := AddressConversionFunctionType
+
+	addMember := func(member *Member) {
+		if functionType.Members == nil {
+			functionType.Members = &StringMemberOrderedMap{}
+		}
+		name := member.Identifier.Identifier
+		_, exists := functionType.Members.Get(name)
+		if exists {
+			panic(errors.NewUnreachableError())
+		}
+		functionType.Members.Set(name, member)
+	}
+
+	addMember(NewUnmeteredPublicFunctionMember(
+		functionType,
+		AddressTypeFromStringFunctionName,
+		AddressTypeFromStringFunctionType,
+		addressTypeFromStringFunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
 			typeName,
-			AddressConversionFunctionType,
+			functionType,
 			numberConversionDocString("an address"),
 		),
 	)
 }
 
+const AddressTypeFromStringFunctionName = "fromString"
+
+var AddressTypeFromStringFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "input",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: &AddressType{},
+		},
+	),
+}
+
+const addressTypeFromStringFunctionDocString = `
+Attempts to parse an address from the given hexadecimal string, with or without the ` + "`0x`" + ` prefix.
+Leading zeros may be omitted.
+
+Returns nil if the string is not a valid address.
+`
+
 func numberFunctionArgumentExpressionsChecker(targetType Type) ArgumentExpressionsCheck {
 	return func(checker *Checker, arguments []ast.Expression, invocationRange ast.Range) {
 		if len(arguments) < 1 {
@@ -3488,6 +3567,8 @@ type CompositeType struct {
 
 	// Only applicable for native composite types.
 	importable bool
+	// Only applicable for native composite types.
+	equatable bool
 
 	cachedIdentifiers *struct {
 		TypeID              TypeID
@@ -3716,6 +3797,11 @@ func (t *CompositeType) IsExternallyReturnable(results map[*Member]bool) bool {
 }
 
 func (t *CompositeType) IsEquatable() bool {
+	// Use the pre-determined flag for native types
+	if t.Location == nil && t.equatable {
+		return true
+	}
+
 	// TODO: add support for more composite kinds
 	return t.Kind == common.CompositeKindEnum
 }
@@ -6224,9 +6310,16 @@ func init() {
 		AuthAccountType,
 		AuthAccountKeysType,
 		AuthAccountContractsType,
+		AuthAccountCapabilitiesType,
+		AuthAccountInboxType,
 		PublicAccountType,
 		PublicAccountKeysType,
 		PublicAccountContractsType,
+		DeploymentResultType,
+		RemovalResultType,
+		ContractInfoType,
+		ContractVersionType,
+		StorageCapabilityControllerType,
 	}
 
 	for _, semaType := range types {
@@ -6240,6 +6333,7 @@ const AccountKeyPublicKeyField = "publicKey"
 const AccountKeyHashAlgoField = "hashAlgorithm"
 const AccountKeyWeightField = "weight"
 const AccountKeyIsRevokedField = "isRevoked"
+const AccountKeyIsActiveField = "isActive"
 
 // AccountKeyType represents the key associated with an account.
 var AccountKeyType = func() *CompositeType {
@@ -6255,6 +6349,7 @@ var AccountKeyType = func() *CompositeType {
 	const accountKeyHashAlgorithmFieldDocString = `The hash algorithm used by the public key`
 	const accountKeyWeightFieldDocString = `The weight assigned to the public key`
 	const accountKeyIsRevokedFieldDocString = `Flag indicating whether the key is revoked`
+	const accountKeyIsActiveFieldDocString = `Flag indicating whether the key is active. Unlike revocation, deactivation is reversible`
 
 	var members = []*Member{
 		NewUnmeteredPublicConstantFieldMember(
@@ -6287,6 +6382,12 @@ var AccountKeyType = func() *CompositeType {
 			BoolType,
 			accountKeyIsRevokedFieldDocString,
 		),
+		NewUnmeteredPublicConstantFieldMember(
+			accountKeyType,
+			AccountKeyIsActiveField,
+			BoolType,
+			accountKeyIsActiveFieldDocString,
+		),
 	}
 
 	accountKeyType.Members = GetMembersAsMap(members)
@@ -6297,6 +6398,7 @@ var AccountKeyType = func() *CompositeType {
 const PublicKeyTypeName = "PublicKey"
 const PublicKeyPublicKeyField = "publicKey"
 const PublicKeySignAlgoField = "signatureAlgorithm"
+const PublicKeyFingerprintField = "fingerprint"
 const PublicKeyVerifyFunction = "verify"
 const PublicKeyVerifyPoPFunction = "verifyPoP"
 
@@ -6308,6 +6410,11 @@ const publicKeySignAlgoFieldDocString = `
 The signature algorithm to be used with the key
 `
 
+const publicKeyFingerprintFieldDocString = `
+A short fingerprint of the public key, for display and comparison.
+The fingerprint is the hex-encoded first 8 bytes of the SHA2-256 hash of the public key
+`
+
 const publicKeyVerifyFunctionDocString = `
 Verifies a signature. Checks whether the signature was produced by signing
 the given tag and data, using this public key and the given hash algorithm
@@ -6360,6 +6467,18 @@ var PublicKeyType = func() *CompositeType {
 	publicKeyType.Members = GetMembersAsMap(members)
 	publicKeyType.Fields = GetFieldNames(members)
 
+	// The fingerprint is derived from the public key,
+	// so it is not a field of the value, i.e. it is neither exported nor imported
+	publicKeyType.Members.Set(
+		PublicKeyFingerprintField,
+		NewUnmeteredPublicConstantFieldMember(
+			publicKeyType,
+			PublicKeyFingerprintField,
+			StringType,
+			publicKeyFingerprintFieldDocString,
+		),
+	)
+
 	return publicKeyType
 }()
 
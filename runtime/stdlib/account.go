@@ -19,15 +19,24 @@
 package stdlib
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
+	"github.com/onflow/atree"
 	"golang.org/x/crypto/sha3"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/rlp"
 )
 
 const authAccountFunctionDocString = `
@@ -48,6 +57,36 @@ var authAccountFunctionType = &sema.FunctionType{
 	),
 }
 
+// AccountAccessKind is the kind of an access to an account
+type AccountAccessKind uint8
+
+const (
+	AccountAccessKindUnknown AccountAccessKind = iota
+	// AccountAccessKindAccount is the construction of an account value, e.g. using `getAccount`
+	AccountAccessKindAccount
+	// AccountAccessKindStorageRead is a read of a storage path of the account
+	AccountAccessKindStorageRead
+	// AccountAccessKindStorageWrite is a write of a storage path of the account
+	AccountAccessKindStorageWrite
+)
+
+// AccountAccessObserver is an optional interface a handler can implement,
+// to observe which accounts are accessed by a program
+type AccountAccessObserver interface {
+	OnAccountAccess(address common.Address, kind AccountAccessKind)
+}
+
+func recordAccountAccess(handler any, addressValue interpreter.AddressValue) {
+	observer, ok := handler.(AccountAccessObserver)
+	if !ok {
+		return
+	}
+
+	wrapPanic(func() {
+		observer.OnAccountAccess(addressValue.ToAddress(), AccountAccessKindAccount)
+	})
+}
+
 type EventEmitter interface {
 	EmitEvent(
 		inter *interpreter.Interpreter,
@@ -66,6 +105,8 @@ type AuthAccountHandler interface {
 	AccountEncodedKeyRevocationHandler
 	AuthAccountKeysHandler
 	AuthAccountContractsHandler
+	AuthAccountCapabilitiesHandler
+	AuthAccountInboxHandler
 }
 
 type AccountCreator interface {
@@ -76,6 +117,8 @@ type AccountCreator interface {
 }
 
 func NewAuthAccountConstructor(creator AccountCreator) StandardLibraryValue {
+	checkHandler(creator, "AccountCreator")
+
 	return NewStandardLibraryFunction(
 		"AuthAccount",
 		authAccountFunctionType,
@@ -120,17 +163,22 @@ func NewAuthAccountConstructor(creator AccountCreator) StandardLibraryValue {
 						address, err = creator.CreateAccount(payerAddress)
 					})
 					if err != nil {
-						panic(err)
+						panic(mapHostError(creator, err))
 					}
 
 					return
 				},
 			)
 
+			validateAddress(creator, addressValue.ToAddress(), getLocationRange)
+
 			creator.EmitEvent(
 				inter,
 				AccountCreatedEventType,
-				[]interpreter.Value{addressValue},
+				[]interpreter.Value{
+					addressValue,
+					payerAddressValue,
+				},
 				getLocationRange,
 			)
 
@@ -143,6 +191,38 @@ func NewAuthAccountConstructor(creator AccountCreator) StandardLibraryValue {
 	)
 }
 
+// AddressValidator is an optional interface a handler can implement,
+// to reject addresses which do not match the address format of the chain,
+// before account values are constructed for them.
+// If the handler does not implement it, all addresses are accepted
+type AddressValidator interface {
+	// ValidateAddress returns an error if the given address is not valid
+	ValidateAddress(address common.Address) error
+}
+
+func validateAddress(
+	handler any,
+	address common.Address,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	validator, ok := handler.(AddressValidator)
+	if !ok {
+		return
+	}
+
+	var err error
+	wrapPanic(func() {
+		err = validator.ValidateAddress(address)
+	})
+	if err != nil {
+		panic(&InvalidAddressError{
+			Address:       address,
+			Err:           err,
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
 const getAuthAccountDocString = `
 Returns the AuthAccount for the given address. Only available in scripts
 `
@@ -157,6 +237,8 @@ var getAuthAccountFunctionType = &sema.FunctionType{
 }
 
 func NewGetAuthAccountFunction(handler AuthAccountHandler) StandardLibraryValue {
+	checkHandler(handler, "AuthAccountHandler")
+
 	return NewStandardLibraryFunction(
 		"getAuthAccount",
 		getAuthAccountFunctionType,
@@ -167,6 +249,8 @@ func NewGetAuthAccountFunction(handler AuthAccountHandler) StandardLibraryValue
 				panic(errors.NewUnreachableError())
 			}
 
+			validateAddress(handler, accountAddress.ToAddress(), invocation.GetLocationRange)
+
 			gauge := invocation.Interpreter
 
 			return NewAuthAccountValue(
@@ -178,20 +262,313 @@ func NewGetAuthAccountFunction(handler AuthAccountHandler) StandardLibraryValue
 	)
 }
 
+const getAuthAccountsDocString = `
+Returns the AuthAccounts for the given addresses. Only available in scripts
+`
+
+var getAuthAccountsFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{{
+		Label:      sema.ArgumentLabelNotRequired,
+		Identifier: "addresses",
+		TypeAnnotation: sema.NewTypeAnnotation(
+			&sema.VariableSizedType{
+				Type: &sema.AddressType{},
+			},
+		),
+	}},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.VariableSizedType{
+			Type: sema.AuthAccountType,
+		},
+	),
+}
+
+var authAccountArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: interpreter.PrimitiveStaticTypeAuthAccount,
+}
+
+func NewGetAuthAccountsFunction(handler AuthAccountHandler) StandardLibraryValue {
+	checkHandler(handler, "AuthAccountHandler")
+
+	return NewStandardLibraryFunction(
+		"getAuthAccounts",
+		getAuthAccountsFunctionType,
+		getAuthAccountsDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			addressesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			accounts := make([]interpreter.Value, 0, addressesValue.Count())
+
+			addressesValue.Iterate(inter, func(element interpreter.Value) (resume bool) {
+				accountAddress, ok := element.(interpreter.AddressValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				validateAddress(handler, accountAddress.ToAddress(), invocation.GetLocationRange)
+
+				accounts = append(
+					accounts,
+					NewAuthAccountValue(
+						inter,
+						handler,
+						accountAddress,
+					),
+				)
+
+				return true
+			})
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.GetLocationRange,
+				authAccountArrayStaticType,
+				common.Address{},
+				accounts...,
+			)
+		},
+	)
+}
+
+const verifyAccountKeyStateDocString = `
+Returns true if the keys of the account at the given address are exactly the given expected keys.
+Every field of every key is compared, including the revocation and activation status. Only available in scripts
+`
+
+var verifyAccountKeyStateFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Identifier:     "address",
+			TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+		},
+		{
+			Identifier: "expectedKeys",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: sema.AccountKeyType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.BoolType),
+}
+
+func NewVerifyAccountKeyStateFunction(provider AccountKeyProvider) StandardLibraryValue {
+	return NewStandardLibraryFunction(
+		"verifyAccountKeyState",
+		verifyAccountKeyStateFunctionType,
+		verifyAccountKeyStateDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			addressValue, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedKeysValue, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			address := addressValue.ToAddress()
+
+			validateAddress(provider, address, getLocationRange)
+
+			getAccountKey := func(index int) *AccountKey {
+				var err error
+				var accountKey *AccountKey
+				wrapPanic(func() {
+					accountKey, err = provider.GetAccountKey(address, index)
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+				return accountKey
+			}
+
+			// Key indices are assigned sequentially and keys are never removed,
+			// so the expected keys must cover exactly the indices 0 to count-1
+
+			count := expectedKeysValue.Count()
+
+			expectedKeys := make([]interpreter.MemberAccessibleValue, count)
+
+			matches := true
+
+			expectedKeysValue.Iterate(inter, func(element interpreter.Value) (resume bool) {
+				expectedKey, ok := element.(interpreter.MemberAccessibleValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				keyIndexValue, ok := expectedKey.GetMember(inter, getLocationRange, sema.AccountKeyKeyIndexField).(interpreter.IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				keyIndex := keyIndexValue.ToInt()
+				if keyIndex < 0 || keyIndex >= count || expectedKeys[keyIndex] != nil {
+					matches = false
+					return false
+				}
+
+				expectedKeys[keyIndex] = expectedKey
+
+				return true
+			})
+
+			if !matches {
+				return interpreter.NewBoolValue(inter, false)
+			}
+
+			for index, expectedKey := range expectedKeys {
+				actualKey := getAccountKey(index)
+				if actualKey == nil ||
+					!accountKeyMatchesValue(inter, getLocationRange, actualKey, expectedKey) {
+
+					return interpreter.NewBoolValue(inter, false)
+				}
+			}
+
+			// The account must not have any keys beyond the expected ones
+
+			if getAccountKey(count) != nil {
+				return interpreter.NewBoolValue(inter, false)
+			}
+
+			return interpreter.NewBoolValue(inter, true)
+		},
+	)
+}
+
+func accountKeyMatchesValue(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	accountKey *AccountKey,
+	value interpreter.MemberAccessibleValue,
+) bool {
+	publicKeyValue, ok := value.GetMember(inter, getLocationRange, sema.AccountKeyPublicKeyField).(interpreter.MemberAccessibleValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	publicKey, err := NewPublicKeyFromValue(inter, getLocationRange, publicKeyValue)
+	if err != nil {
+		panic(err)
+	}
+
+	if accountKey.PublicKey == nil ||
+		publicKey.SignAlgo != accountKey.PublicKey.SignAlgo ||
+		!bytes.Equal(publicKey.PublicKey, accountKey.PublicKey.PublicKey) {
+
+		return false
+	}
+
+	hashAlgo := NewHashAlgorithmFromValue(
+		inter,
+		getLocationRange,
+		value.GetMember(inter, getLocationRange, sema.AccountKeyHashAlgoField),
+	)
+	if hashAlgo != accountKey.HashAlgo {
+		return false
+	}
+
+	weight, ok := value.GetMember(inter, getLocationRange, sema.AccountKeyWeightField).(interpreter.UFix64Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	if uint64(weight) != uint64(accountKey.Weight)*sema.Fix64Factor {
+		return false
+	}
+
+	isRevoked, ok := value.GetMember(inter, getLocationRange, sema.AccountKeyIsRevokedField).(interpreter.BoolValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	if bool(isRevoked) != accountKey.IsRevoked {
+		return false
+	}
+
+	isActive, ok := value.GetMember(inter, getLocationRange, sema.AccountKeyIsActiveField).(interpreter.BoolValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return bool(isActive) == (accountKey.Status == AccountKeyStatusActive)
+}
+
+// HostCallLocker is an optional interface a handler can implement,
+// to provide the lock which serializes the calls of the account value getters
+// (e.g. balance and storage used) into the host environment.
+// The getters may be invoked concurrently, e.g. if account values are shared across goroutines,
+// but the host environment is not necessarily safe for concurrent use.
+//
+// Only the getters are serialized. The lock does not make the interpreter
+// or the storage safe for concurrent use.
+// If the handler does not implement this interface, the getters are not serialized
+type HostCallLocker interface {
+	// HostCallLock returns the lock shared by all account values of the handler.
+	HostCallLock() sync.Locker
+}
+
+// hostCallLock returns the lock of the given handler, if it provides one,
+// or a lock which does not lock otherwise
+func hostCallLock(handler any) sync.Locker {
+	locker, ok := handler.(HostCallLocker)
+	if !ok {
+		return noopLocker{}
+	}
+	return locker.HostCallLock()
+}
+
+type noopLocker struct{}
+
+var _ sync.Locker = noopLocker{}
+
+func (noopLocker) Lock() {}
+
+func (noopLocker) Unlock() {}
+
 func NewAuthAccountValue(
 	gauge common.MemoryGauge,
 	handler AuthAccountHandler,
 	addressValue interpreter.AddressValue,
 ) interpreter.Value {
+	recordAccountAccess(handler, addressValue)
+
+	hostLock := hostCallLock(handler)
+
+	// The storage breakdown is optional and only available
+	// if the handler provides it
+
+	var storageUsedByDomainGet func(
+		inter *interpreter.Interpreter,
+		getLocationRange func() interpreter.LocationRange,
+	) interpreter.Value
+
+	if provider, ok := handler.(StorageBreakdownProvider); ok {
+		storageUsedByDomainGet = newStorageUsedByDomainGetFunction(hostLock, provider, addressValue)
+	}
+
 	return interpreter.NewAuthAccountValue(
 		gauge,
 		addressValue,
-		newAccountBalanceGetFunction(gauge, handler, addressValue),
-		newAccountAvailableBalanceGetFunction(gauge, handler, addressValue),
-		newStorageUsedGetFunction(handler, addressValue),
-		newStorageCapacityGetFunction(handler, addressValue),
+		newAccountBalanceGetFunction(gauge, hostLock, handler, addressValue),
+		newAccountAvailableBalanceGetFunction(gauge, hostLock, handler, addressValue),
+		newAccountGetBalanceFunction(gauge, hostLock, handler, addressValue),
+		newStorageUsedGetFunction(hostLock, handler, addressValue),
+		storageUsedByDomainGet,
+		newStorageCapacityGetFunction(hostLock, handler, addressValue),
 		newAddPublicKeyFunction(gauge, handler, addressValue),
 		newRemovePublicKeyFunction(gauge, handler, addressValue),
+		newAddEncodedKeyWithMetadataFunction(gauge, handler, addressValue),
 		func() interpreter.Value {
 			return newAuthAccountContractsValue(
 				gauge,
@@ -206,6 +583,20 @@ func NewAuthAccountValue(
 				addressValue,
 			)
 		},
+		func() interpreter.Value {
+			return newAuthAccountCapabilitiesValue(
+				gauge,
+				handler,
+				addressValue,
+			)
+		},
+		func() interpreter.Value {
+			return newAuthAccountInboxValue(
+				gauge,
+				handler,
+				addressValue,
+			)
+		},
 	)
 }
 
@@ -214,6 +605,7 @@ type AuthAccountContractsHandler interface {
 	AccountContractAdditionHandler
 	AccountContractRemovalHandler
 	AccountContractNamesProvider
+	AccountContractTypeProvider
 }
 
 func newAuthAccountContractsValue(
@@ -228,13 +620,43 @@ func newAuthAccountContractsValue(
 			gauge,
 			handler,
 			addressValue,
-			false,
+			authAccountContractsChangeOptions{},
 		),
 		newAuthAccountContractsChangeFunction(
 			gauge,
 			handler,
 			addressValue,
-			true,
+			authAccountContractsChangeOptions{inferName: true},
+		),
+		newAuthAccountContractsChangeFunction(
+			gauge,
+			handler,
+			addressValue,
+			authAccountContractsChangeOptions{withResult: true},
+		),
+		newAuthAccountContractsChangeFunction(
+			gauge,
+			handler,
+			addressValue,
+			authAccountContractsChangeOptions{withPostInit: true},
+		),
+		newAuthAccountContractsChangeFunction(
+			gauge,
+			handler,
+			addressValue,
+			authAccountContractsChangeOptions{isUpdate: true},
+		),
+		newAuthAccountContractsChangeFunction(
+			gauge,
+			handler,
+			addressValue,
+			authAccountContractsChangeOptions{isUpdate: true, withMigration: true},
+		),
+		newAuthAccountContractsChangeFunction(
+			gauge,
+			handler,
+			addressValue,
+			authAccountContractsChangeOptions{isUpdate: true, withResult: true},
 		),
 		newAccountContractsGetFunction(
 			gauge,
@@ -245,6 +667,38 @@ func newAuthAccountContractsValue(
 			gauge,
 			handler,
 			addressValue,
+			false,
+		),
+		newAuthAccountContractsRemoveFunction(
+			gauge,
+			handler,
+			addressValue,
+			true,
+		),
+		newAuthAccountContractsEnumTypesFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsGetContractTypeFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsNamesWithPrefixFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsManifestFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsUpdateHistoryFunction(
+			gauge,
+			handler,
+			addressValue,
 		),
 		newAccountContractsGetNamesFunction(
 			handler,
@@ -257,6 +711,8 @@ type AuthAccountKeysHandler interface {
 	AccountKeyProvider
 	AccountKeyAdditionHandler
 	AccountKeyRevocationHandler
+	AccountKeyActivationHandler
+	AccountKeysTotalWeightProvider
 }
 
 func newAuthAccountKeysValue(
@@ -277,160 +733,2058 @@ func newAuthAccountKeysValue(
 			handler,
 			addressValue,
 		),
-		newAccountKeysRevokeFunction(
+		newAccountKeysFindFunction(
 			gauge,
 			handler,
 			addressValue,
 		),
-	)
-}
+		newAccountKeysRevokeFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysRotateFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysSetActiveFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysTotalWeightFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysMeetsThresholdFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysActiveKeyIndicesFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+	)
+}
 
-type BalanceProvider interface {
-	// GetAccountBalance gets accounts default flow token balance.
-	GetAccountBalance(address common.Address) (uint64, error)
+type AuthAccountCapabilitiesHandler interface {
+	CapabilityControllerIssueHandler
+	CapabilityControllerEnumerationHandler
 }
 
-func newAccountBalanceGetFunction(
+func newAuthAccountCapabilitiesValue(
 	gauge common.MemoryGauge,
-	provider BalanceProvider,
+	handler AuthAccountCapabilitiesHandler,
 	addressValue interpreter.AddressValue,
-) func() interpreter.UFix64Value {
+) interpreter.Value {
+	return interpreter.NewAuthAccountCapabilitiesValue(
+		gauge,
+		addressValue,
+		newAccountCapabilitiesIssueFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountCapabilitiesGetControllersFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+	)
+}
+
+type CapabilityControllerIssueHandler interface {
+	// IssueStorageCapabilityController issues a new capability controller for the given storage path,
+	// and returns the ID of the issued capability.
+	IssueStorageCapabilityController(address common.Address, targetPath interpreter.PathValue) (uint64, error)
+}
+
+func newAccountCapabilitiesIssueFunction(
+	gauge common.MemoryGauge,
+	handler CapabilityControllerIssueHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
-	return func() interpreter.UFix64Value {
-		return interpreter.NewUFix64Value(gauge, func() (balance uint64) {
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			borrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			targetPath, ok := invocation.Arguments[0].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			var capabilityID uint64
 			var err error
 			wrapPanic(func() {
-				balance, err = provider.GetAccountBalance(address)
+				capabilityID, err = handler.IssueStorageCapabilityController(address, targetPath)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(handler, err))
 			}
 
-			return
-		})
-	}
+			return interpreter.NewIDCapabilityValue(
+				inter,
+				interpreter.NewUInt64Value(
+					inter,
+					func() uint64 {
+						return capabilityID
+					},
+				),
+				addressValue,
+				targetPath,
+				interpreter.ConvertSemaToStaticType(inter, borrowType),
+			)
+		},
+		sema.AuthAccountCapabilitiesTypeIssueFunctionType,
+	)
 }
 
-type AvailableBalanceProvider interface {
-	// GetAccountAvailableBalance gets accounts default flow token balance - balance that is reserved for storage.
-	GetAccountAvailableBalance(address common.Address) (uint64, error)
+type CapabilityControllerEnumerationHandler interface {
+	// GetStorageCapabilityControllerIDs returns the IDs of all capabilities
+	// which were issued against the given storage path.
+	GetStorageCapabilityControllerIDs(address common.Address, targetPath interpreter.PathValue) ([]uint64, error)
 }
 
-func newAccountAvailableBalanceGetFunction(
+var storageCapabilityControllerReferenceStaticType = interpreter.ReferenceStaticType{
+	BorrowedType: interpreter.ConvertSemaToStaticType(nil, sema.StorageCapabilityControllerType),
+}
+
+var storageCapabilityControllerReferenceArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: storageCapabilityControllerReferenceStaticType,
+}
+
+func newAccountCapabilitiesGetControllersFunction(
 	gauge common.MemoryGauge,
-	provider AvailableBalanceProvider,
+	handler CapabilityControllerEnumerationHandler,
 	addressValue interpreter.AddressValue,
-) func() interpreter.UFix64Value {
+) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
-	return func() interpreter.UFix64Value {
-		return interpreter.NewUFix64Value(gauge, func() (balance uint64) {
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+
+			targetPath, ok := invocation.Arguments[0].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			var capabilityIDs []uint64
 			var err error
 			wrapPanic(func() {
-				balance, err = provider.GetAccountAvailableBalance(address)
+				capabilityIDs, err = handler.GetStorageCapabilityControllerIDs(address, targetPath)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(handler, err))
 			}
 
-			return
-		})
-	}
+			// The order of the IDs returned by the host environment is not necessarily deterministic,
+			// so sort them to ensure the controllers are returned in a reproducible order
+
+			capabilityIDs = append([]uint64(nil), capabilityIDs...)
+			sort.Slice(capabilityIDs, func(i, j int) bool {
+				return capabilityIDs[i] < capabilityIDs[j]
+			})
+
+			controllers := make([]interpreter.Value, 0, len(capabilityIDs))
+			for _, capabilityID := range capabilityIDs {
+				capabilityID := capabilityID
+
+				controller := interpreter.NewStorageCapabilityControllerValue(
+					inter,
+					interpreter.NewUInt64Value(
+						inter,
+						func() uint64 {
+							return capabilityID
+						},
+					),
+					targetPath,
+				)
+
+				controllers = append(
+					controllers,
+					interpreter.NewEphemeralReferenceValue(
+						inter,
+						false,
+						controller,
+						sema.StorageCapabilityControllerType,
+					),
+				)
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.GetLocationRange,
+				storageCapabilityControllerReferenceArrayStaticType,
+				common.Address{},
+				controllers...,
+			)
+		},
+		sema.AuthAccountCapabilitiesTypeGetControllersFunctionType,
+	)
 }
 
-type StorageUsedProvider interface {
-	CommitStorageTemporarily(inter *interpreter.Interpreter) error
-	// GetStorageUsed gets storage used in bytes by the address at the moment of the function call.
-	GetStorageUsed(address common.Address) (uint64, error)
+type AuthAccountInboxHandler interface {
+	EventEmitter
 }
 
-func newStorageUsedGetFunction(
-	provider StorageUsedProvider,
+func newAuthAccountInboxValue(
+	gauge common.MemoryGauge,
+	handler AuthAccountInboxHandler,
 	addressValue interpreter.AddressValue,
-) func(inter *interpreter.Interpreter) interpreter.UInt64Value {
+) interpreter.Value {
+	return interpreter.NewAuthAccountInboxValue(
+		gauge,
+		addressValue,
+		newAccountInboxPublishFunction(gauge, handler, addressValue),
+		newAccountInboxUnpublishFunction(gauge, handler, addressValue),
+		newAccountInboxClaimFunction(gauge, handler, addressValue),
+	)
+}
+
+func newAccountInboxPublishFunction(
+	gauge common.MemoryGauge,
+	handler EventEmitter,
+	providerValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	provider := providerValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			capability, ok := invocation.Arguments[0].(*interpreter.CapabilityValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			nameValue, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			recipientValue, ok := invocation.Arguments[2].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			name := nameValue.Str
+
+			if _, _, ok := inter.ReadInboxValue(provider, name); ok {
+				panic(errors.NewDefaultUserError(
+					"cannot publish inbox value: a value is already published under the name %q",
+					name,
+				))
+			}
+
+			// Transfer the capability into the inbox of the provider account
+
+			capability, ok = capability.Transfer(
+				inter,
+				invocation.GetLocationRange,
+				atree.Address(provider),
+				true,
+				nil,
+			).(*interpreter.CapabilityValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter.WriteInboxValue(provider, name, capability, recipientValue)
+
+			handler.EmitEvent(
+				inter,
+				InboxValuePublishedEventType,
+				[]interpreter.Value{
+					providerValue,
+					recipientValue,
+					nameValue,
+					interpreter.NewTypeValue(inter, capability.StaticType(inter)),
+				},
+				invocation.GetLocationRange,
+			)
+
+			return interpreter.VoidValue{}
+		},
+		sema.AuthAccountInboxTypePublishFunctionType,
+	)
+}
+
+// newInboxCapabilityValue returns the given published capability with the requested borrow type,
+// or nil if the capability cannot be borrowed as the requested type
+func newInboxCapabilityValue(
+	invocation interpreter.Invocation,
+	capability *interpreter.CapabilityValue,
+) *interpreter.CapabilityValue {
+
+	typeParameterPair := invocation.TypeParameterTypes.Oldest()
+	if typeParameterPair == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	wantedBorrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+
+	if capability.BorrowType == nil {
+		return nil
+	}
+
+	capabilityBorrowType := inter.MustConvertStaticToSemaType(capability.BorrowType)
+	if !sema.IsSubType(capabilityBorrowType, wantedBorrowType) {
+		return nil
+	}
+
+	return interpreter.NewIDCapabilityValue(
+		inter,
+		capability.ID,
+		capability.Address,
+		capability.Path,
+		interpreter.ConvertSemaToStaticType(inter, wantedBorrowType),
+	)
+}
+
+func newAccountInboxUnpublishFunction(
+	gauge common.MemoryGauge,
+	handler EventEmitter,
+	providerValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	provider := providerValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			name := nameValue.Str
+
+			publishedCapability, _, ok := inter.ReadInboxValue(provider, name)
+			if !ok {
+				return interpreter.NewNilValue(inter)
+			}
+
+			capability := newInboxCapabilityValue(invocation, publishedCapability)
+			if capability == nil {
+				return interpreter.NewNilValue(inter)
+			}
+
+			inter.RemoveInboxValue(provider, name)
+
+			handler.EmitEvent(
+				inter,
+				InboxValueUnpublishedEventType,
+				[]interpreter.Value{
+					providerValue,
+					nameValue,
+				},
+				invocation.GetLocationRange,
+			)
+
+			return interpreter.NewSomeValueNonCopying(inter, capability)
+		},
+		sema.AuthAccountInboxTypeUnpublishFunctionType,
+	)
+}
+
+func newAccountInboxClaimFunction(
+	gauge common.MemoryGauge,
+	handler EventEmitter,
+	recipientValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			providerValue, ok := invocation.Arguments[1].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			name := nameValue.Str
+			provider := providerValue.ToAddress()
+
+			publishedCapability, publishedRecipient, ok := inter.ReadInboxValue(provider, name)
+			if !ok {
+				return interpreter.NewNilValue(inter)
+			}
+
+			// Only the recipient may claim the published capability
+
+			if publishedRecipient != recipientValue {
+				return interpreter.NewNilValue(inter)
+			}
+
+			capability := newInboxCapabilityValue(invocation, publishedCapability)
+			if capability == nil {
+				return interpreter.NewNilValue(inter)
+			}
+
+			inter.RemoveInboxValue(provider, name)
+
+			handler.EmitEvent(
+				inter,
+				InboxValueClaimedEventType,
+				[]interpreter.Value{
+					providerValue,
+					recipientValue,
+					nameValue,
+				},
+				invocation.GetLocationRange,
+			)
+
+			return interpreter.NewSomeValueNonCopying(inter, capability)
+		},
+		sema.AuthAccountInboxTypeClaimFunctionType,
+	)
+}
+
+type BalanceProvider interface {
+	// GetAccountBalance gets accounts default flow token balance.
+	GetAccountBalance(address common.Address) (uint64, error)
+}
+
+func newAccountBalanceGetFunction(
+	gauge common.MemoryGauge,
+	hostLock sync.Locker,
+	provider BalanceProvider,
+	addressValue interpreter.AddressValue,
+) func() interpreter.UFix64Value {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return func() interpreter.UFix64Value {
+		hostLock.Lock()
+		defer hostLock.Unlock()
+
+		return interpreter.NewUFix64Value(gauge, func() (balance uint64) {
+			var err error
+			wrapPanic(func() {
+				balance, err = provider.GetAccountBalance(address)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			return
+		})
+	}
+}
+
+// MultiTokenBalanceProvider is an optional interface a BalanceProvider can implement,
+// to provide the balances of vaults other than the default token vault.
+type MultiTokenBalanceProvider interface {
+	// MultiTokenBalancesSupported returns true if the balances of vaults
+	// other than the default token vault are available.
+	MultiTokenBalancesSupported() bool
+	// GetAccountBalanceForType gets the balance of the account's vault of the given type.
+	GetAccountBalanceForType(address common.Address, vaultType sema.Type) (uint64, error)
+}
+
+// DefaultTokenAddressResolver is an optional interface a BalanceProvider can implement,
+// to resolve the address of the contract which defines the default token of the network.
+// The balance of a vault defined at this address is the account's default token balance.
+type DefaultTokenAddressResolver interface {
+	// ResolveDefaultTokenAddress gets the address of the default token contract.
+	// It returns false if the address is not known.
+	ResolveDefaultTokenAddress() (common.Address, bool, error)
+}
+
+// isDefinedAtAddress returns true if the given type is a composite type
+// declared in a contract deployed at the given address
+func isDefinedAtAddress(ty sema.Type, address common.Address) bool {
+	compositeType, ok := ty.(*sema.CompositeType)
+	if !ok {
+		return false
+	}
+
+	location, ok := compositeType.Location.(common.AddressLocation)
+	if !ok {
+		return false
+	}
+
+	return location.Address == address
+}
+
+// newAccountGetBalanceFunction returns a function which gets the balance of the account's vault of the given type.
+//
+// If the provider implements DefaultTokenAddressResolver and the default token address is known,
+// the default token balance is returned for vault types defined at that address.
+// The balances of other vault types are provided by MultiTokenBalanceProvider, if supported,
+// and are zero otherwise.
+//
+// If the default token address is not known and multi-token balances are not supported,
+// the default token balance is returned for all vault types
+func newAccountGetBalanceFunction(
+	gauge common.MemoryGauge,
+	hostLock sync.Locker,
+	provider BalanceProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	multiTokenProvider, isMultiTokenProvider := provider.(MultiTokenBalanceProvider)
+	defaultTokenAddressResolver, isDefaultTokenAddressResolver := provider.(DefaultTokenAddressResolver)
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			typeValue, ok := invocation.Arguments[0].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Vaults are resources, so non-resource types never have a balance
+
+			if typeValue.Type == nil {
+				return interpreter.NilValue{}
+			}
+
+			vaultType := inter.MustConvertStaticToSemaType(typeValue.Type)
+			if !vaultType.IsResourceType() {
+				return interpreter.NilValue{}
+			}
+
+			hostLock.Lock()
+			defer hostLock.Unlock()
+
+			var defaultTokenAddress common.Address
+			var hasDefaultTokenAddress bool
+			var err error
+
+			if isDefaultTokenAddressResolver {
+				wrapPanic(func() {
+					defaultTokenAddress, hasDefaultTokenAddress, err = defaultTokenAddressResolver.ResolveDefaultTokenAddress()
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+			}
+
+			isDefaultTokenVault := hasDefaultTokenAddress &&
+				isDefinedAtAddress(vaultType, defaultTokenAddress)
+
+			supportsMultiToken := isMultiTokenProvider &&
+				multiTokenProvider.MultiTokenBalancesSupported()
+
+			var balance uint64
+			wrapPanic(func() {
+				switch {
+				case isDefaultTokenVault:
+					balance, err = provider.GetAccountBalance(address)
+				case supportsMultiToken:
+					balance, err = multiTokenProvider.GetAccountBalanceForType(address, vaultType)
+				case hasDefaultTokenAddress:
+					// The default token balance is not the balance of other vaults
+					balance = 0
+				default:
+					balance, err = provider.GetAccountBalance(address)
+				}
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				interpreter.NewUFix64Value(
+					inter,
+					func() uint64 {
+						return balance
+					},
+				),
+			)
+		},
+		sema.AccountTypeGetBalanceFunctionType,
+	)
+}
+
+type AvailableBalanceProvider interface {
+	// GetAccountAvailableBalance gets accounts default flow token balance - balance that is reserved for storage.
+	GetAccountAvailableBalance(address common.Address) (uint64, error)
+}
+
+func newAccountAvailableBalanceGetFunction(
+	gauge common.MemoryGauge,
+	hostLock sync.Locker,
+	provider AvailableBalanceProvider,
+	addressValue interpreter.AddressValue,
+) func() interpreter.UFix64Value {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return func() interpreter.UFix64Value {
+		hostLock.Lock()
+		defer hostLock.Unlock()
+
+		return interpreter.NewUFix64Value(gauge, func() (balance uint64) {
+			var err error
+			wrapPanic(func() {
+				balance, err = provider.GetAccountAvailableBalance(address)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			return
+		})
+	}
+}
+
+type StorageUsedProvider interface {
+	CommitStorageTemporarily(inter *interpreter.Interpreter, getLocationRange func() interpreter.LocationRange) error
+	// GetStorageUsed gets storage used in bytes by the address at the moment of the function call.
+	GetStorageUsed(address common.Address) (uint64, error)
+}
+
+func newStorageUsedGetFunction(
+	hostLock sync.Locker,
+	provider StorageUsedProvider,
+	addressValue interpreter.AddressValue,
+) func(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) interpreter.UInt64Value {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return func(
+		inter *interpreter.Interpreter,
+		getLocationRange func() interpreter.LocationRange,
+	) interpreter.UInt64Value {
+		hostLock.Lock()
+		defer hostLock.Unlock()
+
+		// NOTE: flush the cached values, so the host environment
+		// can properly calculate the amount of storage used by the account
+		err := provider.CommitStorageTemporarily(inter, getLocationRange)
+		if err != nil {
+			panic(err)
+		}
+
+		return interpreter.NewUInt64Value(
+			inter,
+			func() uint64 {
+				var used uint64
+				wrapPanic(func() {
+					used, err = provider.GetStorageUsed(address)
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+
+				return used
+			},
+		)
+	}
+}
+
+type StorageBreakdownProvider interface {
+	CommitStorageTemporarily(inter *interpreter.Interpreter, getLocationRange func() interpreter.LocationRange) error
+	// StorageBreakdownSupported returns true if the storage used by domain is available.
+	StorageBreakdownSupported() bool
+	// GetStorageUsedByDomain gets the storage used in bytes by the address at the moment of the function call,
+	// broken down by path domain. Returns nil if the breakdown is not available.
+	GetStorageUsedByDomain(address common.Address) (map[string]uint64, error)
+}
+
+var storageUsedByDomainStaticType = interpreter.DictionaryStaticType{
+	KeyType:   interpreter.PrimitiveStaticTypeString,
+	ValueType: interpreter.PrimitiveStaticTypeUInt64,
+}
+
+func newStorageUsedByDomainGetFunction(
+	hostLock sync.Locker,
+	provider StorageBreakdownProvider,
+	addressValue interpreter.AddressValue,
+) func(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) interpreter.Value {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return func(
+		inter *interpreter.Interpreter,
+		getLocationRange func() interpreter.LocationRange,
+	) interpreter.Value {
+		// Avoid committing the storage if the breakdown is not available anyway
+
+		if !provider.StorageBreakdownSupported() {
+			return interpreter.NewNilValue(inter)
+		}
+
+		hostLock.Lock()
+		defer hostLock.Unlock()
+
+		// NOTE: flush the cached values, so the host environment
+		// can properly calculate the amount of storage used by the account
+		err := provider.CommitStorageTemporarily(inter, getLocationRange)
+		if err != nil {
+			panic(err)
+		}
+
+		var usedByDomain map[string]uint64
+		wrapPanic(func() {
+			usedByDomain, err = provider.GetStorageUsedByDomain(address)
+		})
+		if err != nil {
+			panic(mapHostError(provider, err))
+		}
+
+		if usedByDomain == nil {
+			return interpreter.NewNilValue(inter)
+		}
+
+		// Sort the domains, so the dictionary is constructed deterministically
+
+		domains := make([]string, 0, len(usedByDomain))
+		for domain := range usedByDomain {
+			domains = append(domains, domain)
+		}
+		sort.Strings(domains)
+
+		keysAndValues := make([]interpreter.Value, 0, len(domains)*2)
+
+		for _, domain := range domains {
+			domain := domain
+			used := usedByDomain[domain]
+
+			keysAndValues = append(
+				keysAndValues,
+				interpreter.NewStringValue(
+					inter,
+					common.NewStringMemoryUsage(len(domain)),
+					func() string {
+						return domain
+					},
+				),
+				interpreter.NewUInt64Value(
+					inter,
+					func() uint64 {
+						return used
+					},
+				),
+			)
+		}
+
+		return interpreter.NewSomeValueNonCopying(
+			inter,
+			interpreter.NewDictionaryValue(
+				inter,
+				getLocationRange,
+				storageUsedByDomainStaticType,
+				keysAndValues...,
+			),
+		)
+	}
+}
+
+type StorageCapacityProvider interface {
+	CommitStorageTemporarily(inter *interpreter.Interpreter, getLocationRange func() interpreter.LocationRange) error
+	// GetStorageCapacity gets storage capacity in bytes on the address.
+	GetStorageCapacity(address common.Address) (uint64, error)
+}
+
+func newStorageCapacityGetFunction(
+	hostLock sync.Locker,
+	provider StorageCapacityProvider,
+	addressValue interpreter.AddressValue,
+) func(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) interpreter.UInt64Value {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return func(
+		inter *interpreter.Interpreter,
+		getLocationRange func() interpreter.LocationRange,
+	) interpreter.UInt64Value {
+		hostLock.Lock()
+		defer hostLock.Unlock()
+
+		// NOTE: flush the cached values, so the host environment
+		// can properly calculate the amount of storage available for the account
+		err := provider.CommitStorageTemporarily(inter, getLocationRange)
+		if err != nil {
+			panic(err)
+		}
+
+		return interpreter.NewUInt64Value(
+			inter,
+			func() uint64 {
+				var capacity uint64
+				wrapPanic(func() {
+					capacity, err = provider.GetStorageCapacity(address)
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+				return capacity
+			},
+		)
+
+	}
+}
+
+type AccountEncodedKeyAdditionHandler interface {
+	EventEmitter
+	// AddEncodedAccountKey appends an encoded key to an account.
+	AddEncodedAccountKey(address common.Address, key []byte) error
+}
+
+func newAddPublicKeyFunction(
+	gauge common.MemoryGauge,
+	handler AccountEncodedKeyAdditionHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			publicKeyValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			err := RequireByteArrayLength(publicKeyValue, 1, -1)
+			if err != nil {
+				panic(err)
+			}
+
+			publicKey, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, publicKeyValue)
+			if err != nil {
+				panic(err)
+			}
+
+			wrapPanic(func() {
+				err = handler.AddEncodedAccountKey(address, publicKey)
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			inter := invocation.Interpreter
+
+			handler.EmitEvent(
+				inter,
+				AccountKeyAddedEventType,
+				[]interpreter.Value{
+					addressValue,
+					publicKeyValue,
+				},
+				invocation.GetLocationRange,
+			)
+
+			return interpreter.VoidValue{}
+		},
+		sema.AuthAccountTypeAddPublicKeyFunctionType,
+	)
+}
+
+type AccountEncodedKeyRevocationHandler interface {
+	EventEmitter
+	// RevokeEncodedAccountKey removes a key from an account by index, add returns the encoded key.
+	RevokeEncodedAccountKey(address common.Address, index int) ([]byte, error)
+}
+
+func newRemovePublicKeyFunction(
+	gauge common.MemoryGauge,
+	handler AccountEncodedKeyRevocationHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			index, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var publicKey []byte
+			var err error
+			wrapPanic(func() {
+				publicKey, err = handler.RevokeEncodedAccountKey(address, index.ToInt())
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			inter := invocation.Interpreter
+
+			publicKeyValue := interpreter.ByteSliceToByteArrayValue(
+				inter,
+				publicKey,
+			)
+
+			handler.EmitEvent(
+				inter,
+				AccountKeyRemovedEventType,
+				[]interpreter.Value{
+					addressValue,
+					publicKeyValue,
+				},
+				invocation.GetLocationRange,
+			)
+
+			return interpreter.VoidValue{}
+		},
+		sema.AuthAccountTypeRemovePublicKeyFunctionType,
+	)
+}
+
+// The signature algorithms of encoded account keys, as accepted by `addPublicKey`.
+// They are different from the raw values of the SignatureAlgorithm enum
+const (
+	encodedAccountKeySignAlgoBLS_BLS12_381   = 1
+	encodedAccountKeySignAlgoECDSA_P256      = 2
+	encodedAccountKeySignAlgoECDSA_secp256k1 = 3
+)
+
+// encodedAccountKeyFieldCount is the number of fields of an encoded account key:
+// the public key, the signature algorithm, the hash algorithm, and the weight
+const encodedAccountKeyFieldCount = 4
+
+// decodeEncodedAccountKey decodes the public key of an RLP-encoded account key,
+// as accepted by `addPublicKey`.
+// The hash algorithm and weight of the encoded key are not decoded
+func decodeEncodedAccountKey(encodedKey []byte) (*PublicKey, error) {
+
+	newInvalidEncodedKeyError := func(message string) error {
+		return errors.NewDefaultUserError("invalid encoded account key: %s", message)
+	}
+
+	items, bytesRead, err := rlp.DecodeList(encodedKey, 0)
+	if err != nil {
+		return nil, newInvalidEncodedKeyError(err.Error())
+	}
+
+	if bytesRead != len(encodedKey) {
+		return nil, newInvalidEncodedKeyError("unexpected trailing bytes")
+	}
+
+	if len(items) != encodedAccountKeyFieldCount {
+		return nil, newInvalidEncodedKeyError(
+			fmt.Sprintf(
+				"expected %d fields, got %d",
+				encodedAccountKeyFieldCount,
+				len(items),
+			),
+		)
+	}
+
+	publicKey, _, err := rlp.DecodeString(items[0], 0)
+	if err != nil {
+		return nil, newInvalidEncodedKeyError(err.Error())
+	}
+
+	encodedSignAlgo, _, err := rlp.DecodeString(items[1], 0)
+	if err != nil {
+		return nil, newInvalidEncodedKeyError(err.Error())
+	}
+
+	signAlgo := sema.SignatureAlgorithmUnknown
+
+	if len(encodedSignAlgo) == 1 {
+		switch encodedSignAlgo[0] {
+		case encodedAccountKeySignAlgoBLS_BLS12_381:
+			signAlgo = sema.SignatureAlgorithmBLS_BLS12_381
+		case encodedAccountKeySignAlgoECDSA_P256:
+			signAlgo = sema.SignatureAlgorithmECDSA_P256
+		case encodedAccountKeySignAlgoECDSA_secp256k1:
+			signAlgo = sema.SignatureAlgorithmECDSA_secp256k1
+		}
+	}
+
+	if signAlgo == sema.SignatureAlgorithmUnknown {
+		return nil, newInvalidEncodedKeyError("unsupported signature algorithm")
+	}
+
+	return &PublicKey{
+		PublicKey: publicKey,
+		SignAlgo:  signAlgo,
+	}, nil
+}
+
+func newAddEncodedKeyWithMetadataFunction(
+	gauge common.MemoryGauge,
+	handler AccountKeyAdditionHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			encodedKeyValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			encodedKey, err := interpreter.ByteArrayValueToByteSlice(inter, encodedKeyValue)
+			if err != nil {
+				panic(errors.NewUnexpectedError("failed to get encoded key. %w", err))
+			}
+
+			publicKey, err := decodeEncodedAccountKey(encodedKey)
+			if err != nil {
+				panic(err)
+			}
+
+			hashAlgo := NewHashAlgorithmFromValue(inter, getLocationRange, invocation.Arguments[1])
+
+			publicKeyValue := NewPublicKeyValue(
+				inter,
+				getLocationRange,
+				publicKey,
+				hashAlgo,
+				inter.Config.PublicKeyValidationHandler,
+			)
+
+			weightValue, ok := invocation.Arguments[2].(interpreter.UFix64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			return addAccountKey(
+				inter,
+				getLocationRange,
+				handler,
+				address,
+				addressValue,
+				publicKey,
+				publicKeyValue,
+				hashAlgo,
+				weightValue,
+			)
+		},
+		sema.AuthAccountTypeAddEncodedKeyWithMetadataFunctionType,
+	)
+}
+
+// FallibleEventEmitter is an EventEmitter which returns the error of the host environment,
+// instead of panicking, if the event could not be emitted.
+type FallibleEventEmitter interface {
+	TryEmitEvent(
+		inter *interpreter.Interpreter,
+		eventType *sema.CompositeType,
+		values []interpreter.Value,
+		getLocationRange func() interpreter.LocationRange,
+	) error
+}
+
+type AccountKeyAdditionHandler interface {
+	FallibleEventEmitter
+	// AddAccountKey appends a key to an account.
+	AddAccountKey(address common.Address, key *PublicKey, algo sema.HashAlgorithm, weight int) (*AccountKey, error)
+	// RevokeAccountKey removes a key from an account by index.
+	// It is used to roll back the addition of a key if the key added event could not be emitted.
+	RevokeAccountKey(address common.Address, index int) (*AccountKey, error)
+	// AccountKeyEventsDisabled returns true if the AccountKeyAdded and AccountKeyRemoved events
+	// should not be emitted when keys are added or revoked.
+	AccountKeyEventsDisabled() bool
+	// DuplicateAccountKeysRejected returns true if adding a key whose public key
+	// already exists as an unrevoked key of the account should be rejected.
+	DuplicateAccountKeysRejected() bool
+	// GetAccountKeyByPublicKey returns the unrevoked key of the account with the given public key,
+	// or nil if the account has no such key.
+	GetAccountKeyByPublicKey(address common.Address, publicKey *PublicKey) (*AccountKey, error)
+}
+
+func newAccountKeysAddFunction(
+	gauge common.MemoryGauge,
+	handler AccountKeyAdditionHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			publicKeyValue, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			publicKey, err := NewPublicKeyFromValue(inter, getLocationRange, publicKeyValue)
+			if err != nil {
+				panic(err)
+			}
+
+			hashAlgo := NewHashAlgorithmFromValue(inter, getLocationRange, invocation.Arguments[1])
+			weightValue, ok := invocation.Arguments[2].(interpreter.UFix64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			return addAccountKey(
+				inter,
+				getLocationRange,
+				handler,
+				address,
+				addressValue,
+				publicKey,
+				publicKeyValue,
+				hashAlgo,
+				weightValue,
+			)
+		},
+		sema.AuthAccountKeysTypeAddFunctionType,
+	)
+}
+
+// addAccountKey adds the given public key to the account,
+// emits the AccountKeyAdded event, and returns the added key
+func addAccountKey(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	handler AccountKeyAdditionHandler,
+	address common.Address,
+	addressValue interpreter.AddressValue,
+	publicKey *PublicKey,
+	publicKeyValue interpreter.Value,
+	hashAlgo sema.HashAlgorithm,
+	weightValue interpreter.UFix64Value,
+) interpreter.Value {
+
+	accountKey := insertAccountKey(
+		getLocationRange,
+		handler,
+		address,
+		publicKey,
+		hashAlgo,
+		weightValue,
+	)
+
+	if !handler.AccountKeyEventsDisabled() {
+		err := handler.TryEmitEvent(
+			inter,
+			AccountKeyAddedEventType,
+			[]interpreter.Value{
+				addressValue,
+				publicKeyValue,
+			},
+			getLocationRange,
+		)
+		if err != nil {
+			// Roll back the addition of the key,
+			// so there is no added key without a corresponding event
+
+			var rollbackErr error
+			wrapPanic(func() {
+				_, rollbackErr = handler.RevokeAccountKey(address, accountKey.KeyIndex)
+			})
+			if rollbackErr != nil {
+				panic(mapHostError(handler, rollbackErr))
+			}
+
+			panic(err)
+		}
+	}
+
+	return NewAccountKeyValue(
+		inter,
+		getLocationRange,
+		accountKey,
+		inter.Config.PublicKeyValidationHandler,
+	)
+}
+
+// insertAccountKey adds the given public key to the account,
+// rejecting duplicate keys if configured, and returns the added key.
+// It does not emit an event
+func insertAccountKey(
+	getLocationRange func() interpreter.LocationRange,
+	handler AccountKeyAdditionHandler,
+	address common.Address,
+	publicKey *PublicKey,
+	hashAlgo sema.HashAlgorithm,
+	weightValue interpreter.UFix64Value,
+) *AccountKey {
+
+	weight := weightValue.ToInt()
+
+	var accountKey *AccountKey
+	var err error
+
+	if handler.DuplicateAccountKeysRejected() {
+		var existingKey *AccountKey
+		wrapPanic(func() {
+			existingKey, err = handler.GetAccountKeyByPublicKey(address, publicKey)
+		})
+		if err != nil {
+			panic(mapHostError(handler, err))
+		}
+
+		if existingKey != nil && !existingKey.IsRevoked {
+			panic(&DuplicateAccountKeyError{
+				Address:       address,
+				KeyIndex:      existingKey.KeyIndex,
+				Fingerprint:   interpreter.PublicKeyFingerprint(publicKey.PublicKey),
+				LocationRange: getLocationRange(),
+			})
+		}
+	}
+
+	wrapPanic(func() {
+		accountKey, err = handler.AddAccountKey(address, publicKey, hashAlgo, weight)
+	})
+	if err != nil {
+		panic(mapHostError(handler, err))
+	}
+
+	return accountKey
+}
+
+type AccountKey struct {
+	KeyIndex  int
+	PublicKey *PublicKey
+	HashAlgo  sema.HashAlgorithm
+	Weight    int
+	IsRevoked bool
+	Status    AccountKeyStatus
+}
+
+// AccountKeyStatus is the status of an account key.
+// Unlike revocation, which is terminal, the status of a key can be changed back and forth.
+// The zero value is AccountKeyStatusActive, so keys are active unless deactivated explicitly.
+type AccountKeyStatus uint8
+
+const (
+	AccountKeyStatusActive AccountKeyStatus = iota
+	AccountKeyStatusInactive
+)
+
+type PublicKey struct {
+	PublicKey []byte
+	SignAlgo  sema.SignatureAlgorithm
+}
+
+type AccountKeyProvider interface {
+	// GetAccountKey retrieves a key from an account by index.
+	GetAccountKey(address common.Address, index int) (*AccountKey, error)
+}
+
+func newAccountKeysGetFunction(
+	gauge common.MemoryGauge,
+	provider AccountKeyProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			index := indexValue.ToInt()
+
+			var err error
+			var accountKey *AccountKey
+			wrapPanic(func() {
+				accountKey, err = provider.GetAccountKey(address, index)
+			})
+
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			// Here it is expected the host function to return a nil key, if a key is not found at the given index.
+			// This is done because, if the host function returns an error when a key is not found, then
+			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
+			if accountKey == nil {
+				return interpreter.NewNilValue(invocation.Interpreter)
+			}
+
+			inter := invocation.Interpreter
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				NewAccountKeyValue(
+					inter,
+					invocation.GetLocationRange,
+					accountKey,
+					// public keys are assumed to be already validated.
+					func(
+						_ *interpreter.Interpreter,
+						_ func() interpreter.LocationRange,
+						_ *interpreter.CompositeValue,
+					) error {
+						return nil
+					},
+				),
+			)
+		},
+		sema.AccountKeysTypeGetFunctionType,
+	)
+}
+
+func newAccountKeysFindFunction(
+	gauge common.MemoryGauge,
+	provider AccountKeyProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			index := indexValue.ToInt()
+
+			includeRevoked, ok := invocation.Arguments[1].(interpreter.BoolValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var err error
+			var accountKey *AccountKey
+			wrapPanic(func() {
+				accountKey, err = provider.GetAccountKey(address, index)
+			})
+
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			// Like for get, the host function is expected to return a nil key,
+			// if a key is not found at the given index.
+			// Revoked keys are only returned if requested explicitly.
+			if accountKey == nil || (accountKey.IsRevoked && !bool(includeRevoked)) {
+				return interpreter.NewNilValue(invocation.Interpreter)
+			}
+
+			inter := invocation.Interpreter
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				NewAccountKeyValue(
+					inter,
+					invocation.GetLocationRange,
+					accountKey,
+					// public keys are assumed to be already validated.
+					func(
+						_ *interpreter.Interpreter,
+						_ func() interpreter.LocationRange,
+						_ *interpreter.CompositeValue,
+					) error {
+						return nil
+					},
+				),
+			)
+		},
+		sema.AccountKeysTypeFindFunctionType,
+	)
+}
+
+type AccountKeysTotalWeightProvider interface {
+	// GetAccountKeysTotalWeight returns the sum of the weights of all unrevoked keys of an account.
+	GetAccountKeysTotalWeight(address common.Address) (uint64, error)
+}
+
+func newAccountKeysTotalWeightFunction(
+	gauge common.MemoryGauge,
+	provider AccountKeysTotalWeightProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			var err error
+			var totalWeight uint64
+			wrapPanic(func() {
+				totalWeight, err = provider.GetAccountKeysTotalWeight(address)
+			})
+
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			return interpreter.NewUFix64ValueWithInteger(
+				invocation.Interpreter,
+				func() uint64 {
+					return totalWeight
+				},
+			)
+		},
+		sema.AccountKeysTypeTotalWeightFunctionType,
+	)
+}
+
+// newAccountKeysMeetsThresholdFunction returns a function which checks
+// if the sum of the weights of the keys at the given indices meets the given threshold.
+// Keys are only counted once, and missing, revoked, and inactive keys never meet the threshold
+func newAccountKeysMeetsThresholdFunction(
+	gauge common.MemoryGauge,
+	provider AccountKeyProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			indicesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			thresholdValue, ok := invocation.Arguments[1].(interpreter.UFix64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			threshold := uint64(thresholdValue)
+
+			count := indicesValue.Count()
+			seenIndices := make(map[int]struct{}, count)
+
+			var totalWeight uint64
+
+			for i := 0; i < count; i++ {
+				indexValue, ok := indicesValue.Get(inter, getLocationRange, i).(interpreter.IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				index := indexValue.ToInt()
+
+				if _, ok := seenIndices[index]; ok {
+					continue
+				}
+				seenIndices[index] = struct{}{}
+
+				var err error
+				var accountKey *AccountKey
+				wrapPanic(func() {
+					accountKey, err = provider.GetAccountKey(address, index)
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+
+				if accountKey == nil ||
+					accountKey.IsRevoked ||
+					accountKey.Status != AccountKeyStatusActive {
+
+					return interpreter.NewBoolValue(inter, false)
+				}
+
+				// Weights are integers, the threshold is a fixed-point number
+				integerWeight := uint64(accountKey.Weight)
+				if integerWeight > sema.UFix64TypeMaxInt {
+					panic(interpreter.OverflowError{})
+				}
+				weight := integerWeight * sema.Fix64Factor
+
+				if totalWeight+weight < totalWeight {
+					panic(interpreter.OverflowError{})
+				}
+				totalWeight += weight
+			}
+
+			return interpreter.NewBoolValue(inter, totalWeight >= threshold)
+		},
+		sema.AccountKeysTypeMeetsThresholdFunctionType,
+	)
+}
+
+var intArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: interpreter.PrimitiveStaticTypeInt,
+}
+
+// newAccountKeysActiveKeyIndicesFunction returns a function which returns
+// the indices of all keys of the account which are neither revoked nor inactive, in ascending order.
+// Keys are never removed from an account, only revoked,
+// so the keys are iterated until the first index without a key
+func newAccountKeysActiveKeyIndicesFunction(
+	gauge common.MemoryGauge,
+	provider AccountKeyProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			var indices []interpreter.Value
+
+			for index := 0; ; index++ {
+				var err error
+				var accountKey *AccountKey
+				wrapPanic(func() {
+					accountKey, err = provider.GetAccountKey(address, index)
+				})
+				if err != nil {
+					panic(mapHostError(provider, err))
+				}
+
+				if accountKey == nil {
+					break
+				}
+
+				if accountKey.IsRevoked ||
+					accountKey.Status != AccountKeyStatusActive {
+
+					continue
+				}
+
+				indices = append(
+					indices,
+					interpreter.NewIntValueFromInt64(inter, int64(index)),
+				)
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.GetLocationRange,
+				intArrayStaticType,
+				common.Address{},
+				indices...,
+			)
+		},
+		sema.AccountKeysTypeActiveKeyIndicesFunctionType,
+	)
+}
+
+type AccountKeyRevocationHandler interface {
+	EventEmitter
+	// RevokeAccountKey removes a key from an account by index.
+	RevokeAccountKey(address common.Address, index int) (*AccountKey, error)
+	// AccountKeyEventsDisabled returns true if the AccountKeyAdded and AccountKeyRemoved events
+	// should not be emitted when keys are added or revoked.
+	AccountKeyEventsDisabled() bool
+}
+
+func newAccountKeysRevokeFunction(
+	gauge common.MemoryGauge,
+	handler AccountKeyRevocationHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			index := indexValue.ToInt()
+
+			var err error
+			var accountKey *AccountKey
+			wrapPanic(func() {
+				accountKey, err = handler.RevokeAccountKey(address, index)
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			// Here it is expected the host function to return a nil key, if a key is not found at the given index.
+			// This is done because, if the host function returns an error when a key is not found, then
+			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
+			if accountKey == nil {
+				return interpreter.NewNilValue(invocation.Interpreter)
+			}
+
+			inter := invocation.Interpreter
+
+			if !handler.AccountKeyEventsDisabled() {
+				handler.EmitEvent(
+					inter,
+					AccountKeyRemovedEventType,
+					[]interpreter.Value{
+						addressValue,
+						indexValue,
+					},
+					invocation.GetLocationRange,
+				)
+			}
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				NewAccountKeyValue(
+					inter,
+					invocation.GetLocationRange,
+					accountKey,
+					// public keys are assumed to be already validated.
+					func(
+						_ *interpreter.Interpreter,
+						_ func() interpreter.LocationRange,
+						_ *interpreter.CompositeValue,
+					) error {
+						return nil
+					},
+				),
+			)
+		},
+		sema.AuthAccountKeysTypeRevokeFunctionType,
+	)
+}
+
+type AccountKeyRotationHandler interface {
+	AccountKeyProvider
+	AccountKeyAdditionHandler
+}
+
+func newAccountKeysRotateFunction(
+	gauge common.MemoryGauge,
+	handler AccountKeyRotationHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			oldIndexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			oldIndex := oldIndexValue.ToInt()
+
+			publicKeyValue, ok := invocation.Arguments[1].(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			publicKey, err := NewPublicKeyFromValue(inter, getLocationRange, publicKeyValue)
+			if err != nil {
+				panic(err)
+			}
+
+			hashAlgo := NewHashAlgorithmFromValue(inter, getLocationRange, invocation.Arguments[2])
+			weightValue, ok := invocation.Arguments[3].(interpreter.UFix64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Ensure the old key can be revoked before adding the new key
+
+			var oldKey *AccountKey
+			wrapPanic(func() {
+				oldKey, err = handler.GetAccountKey(address, oldIndex)
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			if oldKey == nil {
+				panic(errors.NewDefaultUserError(
+					"cannot rotate account key: key with index %d does not exist",
+					oldIndex,
+				))
+			}
+
+			if oldKey.IsRevoked {
+				panic(errors.NewDefaultUserError(
+					"cannot rotate account key: key with index %d is already revoked",
+					oldIndex,
+				))
+			}
+
+			newKey := insertAccountKey(
+				getLocationRange,
+				handler,
+				address,
+				publicKey,
+				hashAlgo,
+				weightValue,
+			)
+
+			// Roll back the addition of the new key if the old key cannot be revoked,
+			// so that either both or neither of the steps take effect
+
+			rollback := func() {
+				var rollbackErr error
+				wrapPanic(func() {
+					_, rollbackErr = handler.RevokeAccountKey(address, newKey.KeyIndex)
+				})
+				if rollbackErr != nil {
+					panic(mapHostError(handler, rollbackErr))
+				}
+			}
+
+			var revokedKey *AccountKey
+			wrapPanic(func() {
+				revokedKey, err = handler.RevokeAccountKey(address, oldIndex)
+			})
+			if err != nil {
+				rollback()
+				panic(mapHostError(handler, err))
+			}
+			if revokedKey == nil {
+				rollback()
+				panic(errors.NewDefaultUserError(
+					"cannot rotate account key: key with index %d does not exist",
+					oldIndex,
+				))
+			}
+
+			if !handler.AccountKeyEventsDisabled() {
+				err = handler.TryEmitEvent(
+					inter,
+					AccountKeyRotatedEventType,
+					[]interpreter.Value{
+						addressValue,
+						oldIndexValue,
+						interpreter.NewIntValueFromInt64(inter, int64(newKey.KeyIndex)),
+					},
+					getLocationRange,
+				)
+				if err != nil {
+					// A revoked key cannot be reinstated,
+					// so abort the whole transaction instead of rolling back
+					panic(err)
+				}
+			}
+
+			return NewAccountKeyValue(
+				inter,
+				getLocationRange,
+				newKey,
+				inter.Config.PublicKeyValidationHandler,
+			)
+		},
+		sema.AuthAccountKeysTypeRotateFunctionType,
+	)
+}
+
+type AccountKeyActivationHandler interface {
+	AccountKeyProvider
+	// SetAccountKeyActive activates or deactivates a key of an account by index.
+	SetAccountKeyActive(address common.Address, index int, active bool) error
+}
+
+func newAccountKeysSetActiveFunction(
+	gauge common.MemoryGauge,
+	handler AccountKeyActivationHandler,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			index := indexValue.ToInt()
+
+			activeValue, ok := invocation.Arguments[1].(interpreter.BoolValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var err error
+			var accountKey *AccountKey
+			wrapPanic(func() {
+				accountKey, err = handler.GetAccountKey(address, index)
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			if accountKey == nil {
+				panic(errors.NewDefaultUserError(
+					"cannot set status of account key: key with index %d does not exist",
+					index,
+				))
+			}
+
+			// Revocation is terminal
+			if accountKey.IsRevoked {
+				panic(errors.NewDefaultUserError(
+					"cannot set status of account key: key with index %d is revoked",
+					index,
+				))
+			}
+
+			wrapPanic(func() {
+				err = handler.SetAccountKeyActive(address, index, bool(activeValue))
+			})
+			if err != nil {
+				panic(mapHostError(handler, err))
+			}
+
+			return interpreter.VoidValue{}
+		},
+		sema.AuthAccountKeysTypeSetActiveFunctionType,
+	)
+}
+
+type PublicAccountKeysHandler interface {
+	AccountKeyProvider
+	AccountKeysTotalWeightProvider
+}
+
+func newPublicAccountKeysValue(
+	gauge common.MemoryGauge,
+	handler PublicAccountKeysHandler,
+	addressValue interpreter.AddressValue,
+) interpreter.Value {
+	return interpreter.NewPublicAccountKeysValue(
+		gauge,
+		addressValue,
+		newAccountKeysGetFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysFindFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysTotalWeightFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysMeetsThresholdFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountKeysActiveKeyIndicesFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+	)
+}
+
+type PublicAccountContractsHandler interface {
+	AccountContractNamesProvider
+	AccountContractProvider
+	AccountContractValueProvider
+	AccountContractTypeProvider
+}
+
+func newPublicAccountContractsValue(
+	gauge common.MemoryGauge,
+	handler PublicAccountContractsHandler,
+	addressValue interpreter.AddressValue,
+) interpreter.Value {
+	return interpreter.NewPublicAccountContractsValue(
+		gauge,
+		addressValue,
+		newAccountContractsGetFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsBorrowContractFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsGetContractTypeFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsNamesWithPrefixFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsManifestFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsUpdateHistoryFunction(
+			gauge,
+			handler,
+			addressValue,
+		),
+		newAccountContractsGetNamesFunction(
+			handler,
+			addressValue,
+		),
+	)
+}
+
+type AccountContractNamesProvider interface {
+	// GetAccountContractNames returns the names of all contracts deployed in an account.
+	GetAccountContractNames(address common.Address) ([]string, error)
+}
+
+func newAccountContractsGetNamesFunction(
+	provider AccountContractNamesProvider,
+	addressValue interpreter.AddressValue,
+) func(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+) *interpreter.ArrayValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
-	return func(inter *interpreter.Interpreter) interpreter.UInt64Value {
-
-		// NOTE: flush the cached values, so the host environment
-		// can properly calculate the amount of storage used by the account
-		err := provider.CommitStorageTemporarily(inter)
+	return func(
+		inter *interpreter.Interpreter,
+		getLocationRange func() interpreter.LocationRange,
+	) *interpreter.ArrayValue {
+		var names []string
+		var err error
+		wrapPanic(func() {
+			names, err = provider.GetAccountContractNames(address)
+		})
 		if err != nil {
-			panic(err)
+			panic(mapHostError(provider, err))
 		}
 
-		return interpreter.NewUInt64Value(
-			inter,
-			func() uint64 {
-				var capacity uint64
-				wrapPanic(func() {
-					capacity, err = provider.GetStorageUsed(address)
-				})
-				if err != nil {
-					panic(err)
-				}
-				return capacity
-			},
-		)
+		return newContractNamesArrayValue(inter, getLocationRange, names)
 	}
 }
 
-type StorageCapacityProvider interface {
-	CommitStorageTemporarily(inter *interpreter.Interpreter) error
-	// GetStorageCapacity gets storage capacity in bytes on the address.
-	GetStorageCapacity(address common.Address) (uint64, error)
+// sortedContractNames returns a sorted copy of the given contract names.
+// The order of the names returned by the host environment is not necessarily deterministic,
+// so they are sorted to ensure contracts are always iterated in a reproducible order
+func sortedContractNames(names []string) []string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return sorted
 }
 
-func newStorageCapacityGetFunction(
-	provider StorageCapacityProvider,
-	addressValue interpreter.AddressValue,
-) func(inter *interpreter.Interpreter) interpreter.UInt64Value {
-
-	// Converted addresses can be cached and don't have to be recomputed on each function invocation
-	address := addressValue.ToAddress()
-
-	return func(inter *interpreter.Interpreter) interpreter.UInt64Value {
+func newContractNamesArrayValue(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	names []string,
+) *interpreter.ArrayValue {
 
-		// NOTE: flush the cached values, so the host environment
-		// can properly calculate the amount of storage available for the account
-		err := provider.CommitStorageTemporarily(inter)
-		if err != nil {
-			panic(err)
-		}
+	names = sortedContractNames(names)
 
-		return interpreter.NewUInt64Value(
+	values := make([]interpreter.Value, len(names))
+	for i, name := range names {
+		memoryUsage := common.NewStringMemoryUsage(len(name))
+		values[i] = interpreter.NewStringValue(
 			inter,
-			func() uint64 {
-				var capacity uint64
-				wrapPanic(func() {
-					capacity, err = provider.GetStorageCapacity(address)
-				})
-				if err != nil {
-					panic(err)
-				}
-				return capacity
+			memoryUsage,
+			func() string {
+				return name
 			},
 		)
-
 	}
-}
 
-type AccountEncodedKeyAdditionHandler interface {
-	EventEmitter
-	// AddEncodedAccountKey appends an encoded key to an account.
-	AddEncodedAccountKey(address common.Address, key []byte) error
+	arrayType := interpreter.NewVariableSizedStaticType(
+		inter,
+		interpreter.NewPrimitiveStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+		),
+	)
+
+	return interpreter.NewArrayValue(
+		inter,
+		getLocationRange,
+		arrayType,
+		common.Address{},
+		values...,
+	)
 }
 
-func newAddPublicKeyFunction(
+func newAccountContractsNamesWithPrefixFunction(
 	gauge common.MemoryGauge,
-	handler AccountEncodedKeyAdditionHandler,
+	provider AccountContractNamesProvider,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
@@ -440,50 +2794,79 @@ func newAddPublicKeyFunction(
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			publicKeyValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			prefixValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
+			prefix := prefixValue.Str
 
-			publicKey, err := interpreter.ByteArrayValueToByteSlice(gauge, publicKeyValue)
-			if err != nil {
-				panic("addPublicKey requires the first argument to be a byte array")
-			}
+			inter := invocation.Interpreter
 
+			var names []string
+			var err error
 			wrapPanic(func() {
-				err = handler.AddEncodedAccountKey(address, publicKey)
+				names, err = provider.GetAccountContractNames(address)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(provider, err))
 			}
 
-			inter := invocation.Interpreter
+			names = sortedContractNames(names)
 
-			handler.EmitEvent(
+			// Filter the names before constructing any values,
+			// so only the matching names are metered and allocated
+
+			var values []interpreter.Value
+			for _, name := range names {
+				if !strings.HasPrefix(name, prefix) {
+					continue
+				}
+
+				name := name
+				memoryUsage := common.NewStringMemoryUsage(len(name))
+				values = append(
+					values,
+					interpreter.NewStringValue(
+						inter,
+						memoryUsage,
+						func() string {
+							return name
+						},
+					),
+				)
+			}
+
+			arrayType := interpreter.NewVariableSizedStaticType(
 				inter,
-				AccountKeyAddedEventType,
-				[]interpreter.Value{
-					addressValue,
-					publicKeyValue,
-				},
-				invocation.GetLocationRange,
+				interpreter.NewPrimitiveStaticType(
+					inter,
+					interpreter.PrimitiveStaticTypeString,
+				),
 			)
 
-			return interpreter.VoidValue{}
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.GetLocationRange,
+				arrayType,
+				common.Address{},
+				values...,
+			)
 		},
-		sema.AuthAccountTypeAddPublicKeyFunctionType,
+		sema.AuthAccountContractsTypeNamesWithPrefixFunctionType,
 	)
 }
 
-type AccountEncodedKeyRevocationHandler interface {
-	EventEmitter
-	// RevokeEncodedAccountKey removes a key from an account by index, add returns the encoded key.
-	RevokeEncodedAccountKey(address common.Address, index int) ([]byte, error)
+type AccountContractManifestHandler interface {
+	AccountContractNamesProvider
+	AccountContractProvider
 }
 
-func newRemovePublicKeyFunction(
+// newAccountContractsManifestFunction returns the name, code hash, and code size
+// of each contract deployed in the account.
+// The code itself is only used to compute the hash and size, it is not returned
+func newAccountContractsManifestFunction(
 	gauge common.MemoryGauge,
-	handler AccountEncodedKeyRevocationHandler,
+	handler AccountContractManifestHandler,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
@@ -493,195 +2876,301 @@ func newRemovePublicKeyFunction(
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			index, ok := invocation.Arguments[0].(interpreter.IntValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
+			inter := invocation.Interpreter
 
-			var publicKey []byte
+			var names []string
 			var err error
 			wrapPanic(func() {
-				publicKey, err = handler.RevokeEncodedAccountKey(address, index.ToInt())
+				names, err = handler.GetAccountContractNames(address)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(handler, err))
 			}
 
-			inter := invocation.Interpreter
+			names = sortedContractNames(names)
 
-			publicKeyValue := interpreter.ByteSliceToByteArrayValue(
+			values := make([]interpreter.Value, 0, len(names))
+			for _, name := range names {
+				var code []byte
+				wrapPanic(func() {
+					code, err = handler.GetAccountContractCode(address, name)
+				})
+				if err != nil {
+					panic(mapHostError(handler, err))
+				}
+
+				name := name
+				nameValue := interpreter.NewStringValue(
+					inter,
+					common.NewStringMemoryUsage(len(name)),
+					func() string {
+						return name
+					},
+				)
+
+				values = append(
+					values,
+					interpreter.NewContractInfoValue(
+						inter,
+						nameValue,
+						CodeToHashValue(inter, code),
+						interpreter.NewIntValueFromInt64(inter, int64(len(code))),
+					),
+				)
+			}
+
+			arrayType := interpreter.NewVariableSizedStaticType(
 				inter,
-				publicKey,
+				interpreter.ConvertSemaToStaticType(inter, sema.ContractInfoType),
 			)
 
-			handler.EmitEvent(
+			return interpreter.NewArrayValue(
 				inter,
-				AccountKeyRemovedEventType,
-				[]interpreter.Value{
-					addressValue,
-					publicKeyValue,
-				},
 				invocation.GetLocationRange,
+				arrayType,
+				common.Address{},
+				values...,
 			)
-
-			return interpreter.VoidValue{}
 		},
-		sema.AuthAccountTypeRemovePublicKeyFunctionType,
+		sema.AuthAccountContractsTypeManifestFunctionType,
 	)
 }
 
-type AccountKeyAdditionHandler interface {
-	EventEmitter
-	// AddAccountKey appends a key to an account.
-	AddAccountKey(address common.Address, key *PublicKey, algo sema.HashAlgorithm, weight int) (*AccountKey, error)
+// ContractVersion is a recorded version of a contract
+type ContractVersion struct {
+	// Version is the version number of the contract, starting at 1 for the initial deployment
+	Version uint64
+	// CodeHash is the SHA3-256 hash of the code of this version
+	CodeHash []byte
+	// Timestamp is the timestamp of the block in which this version was deployed, in nanoseconds
+	Timestamp int64
 }
 
-func newAccountKeysAddFunction(
+// ContractHistoryProvider is an optional interface an AccountContractProvider can implement,
+// if the host retains the update history of contracts.
+type ContractHistoryProvider interface {
+	// GetAccountContractUpdateHistory returns the recorded versions of the given contract,
+	// starting with the oldest version.
+	GetAccountContractUpdateHistory(address common.Address, name string) ([]ContractVersion, error)
+}
+
+// newAccountContractsUpdateHistoryFunction returns the recorded versions of a contract.
+// No versions are returned if the handler does not retain the update history
+func newAccountContractsUpdateHistoryFunction(
 	gauge common.MemoryGauge,
-	handler AccountKeyAdditionHandler,
+	handler AccountContractProvider,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
+	historyProvider, isHistoryProvider := handler.(ContractHistoryProvider)
+
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			publicKeyValue, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
+			name := nameValue.Str
 
 			inter := invocation.Interpreter
-			getLocationRange := invocation.GetLocationRange
 
-			publicKey, err := NewPublicKeyFromValue(inter, getLocationRange, publicKeyValue)
-			if err != nil {
-				panic(err)
+			var versions []ContractVersion
+			if isHistoryProvider {
+				var err error
+				wrapPanic(func() {
+					versions, err = historyProvider.GetAccountContractUpdateHistory(address, name)
+				})
+				if err != nil {
+					panic(mapHostError(handler, err))
+				}
 			}
 
-			hashAlgo := NewHashAlgorithmFromValue(inter, getLocationRange, invocation.Arguments[1])
-			weightValue, ok := invocation.Arguments[2].(interpreter.UFix64Value)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
-			weight := weightValue.ToInt()
+			values := make([]interpreter.Value, 0, len(versions))
+			for _, version := range versions {
+				version := version
 
-			var accountKey *AccountKey
-			wrapPanic(func() {
-				accountKey, err = handler.AddAccountKey(address, publicKey, hashAlgo, weight)
-			})
-			if err != nil {
-				panic(err)
+				values = append(
+					values,
+					interpreter.NewContractVersionValue(
+						inter,
+						interpreter.NewUInt64Value(
+							inter,
+							func() uint64 {
+								return version.Version
+							},
+						),
+						interpreter.ByteSliceToByteArrayValue(inter, version.CodeHash),
+						interpreter.NewUFix64ValueWithInteger(
+							inter,
+							func() uint64 {
+								return uint64(time.Unix(0, version.Timestamp).Unix())
+							},
+						),
+					),
+				)
 			}
 
-			handler.EmitEvent(
+			arrayType := interpreter.NewVariableSizedStaticType(
 				inter,
-				AccountKeyAddedEventType,
-				[]interpreter.Value{
-					addressValue,
-					publicKeyValue,
-				},
-				invocation.GetLocationRange,
+				interpreter.ConvertSemaToStaticType(inter, sema.ContractVersionType),
 			)
 
-			return NewAccountKeyValue(
+			return interpreter.NewArrayValue(
 				inter,
-				getLocationRange,
-				accountKey,
-				inter.Config.PublicKeyValidationHandler,
+				invocation.GetLocationRange,
+				arrayType,
+				common.Address{},
+				values...,
 			)
 		},
-		sema.AuthAccountKeysTypeAddFunctionType,
+		sema.AuthAccountContractsTypeUpdateHistoryFunctionType,
 	)
 }
 
-type AccountKey struct {
-	KeyIndex  int
-	PublicKey *PublicKey
-	HashAlgo  sema.HashAlgorithm
-	Weight    int
-	IsRevoked bool
-}
-
-type PublicKey struct {
-	PublicKey []byte
-	SignAlgo  sema.SignatureAlgorithm
+type AccountContractProvider interface {
+	// GetAccountContractCode returns the code associated with an account contract.
+	GetAccountContractCode(address common.Address, name string) ([]byte, error)
 }
 
-type AccountKeyProvider interface {
-	// GetAccountKey retrieves a key from an account by index.
-	GetAccountKey(address common.Address, index int) (*AccountKey, error)
+// AccountContractExistenceProvider is an optional interface an AccountContractProvider can implement,
+// to check if an account contract exists before its code is fetched.
+// If supported, the code of a contract returned by `contracts.get` is only fetched
+// when the `code` field of the deployed contract is accessed.
+// Accessing the code fails if the contract was updated or removed in the meantime.
+type AccountContractExistenceProvider interface {
+	// AccountContractExistenceSupported returns true if the existence of an account contract
+	// can be checked without fetching its code.
+	AccountContractExistenceSupported() bool
+	// AccountContractExists returns true if the account contract exists.
+	AccountContractExists(address common.Address, name string) (bool, error)
+	// AccountContractChangeCount returns how often the account contract was updated or removed so far.
+	AccountContractChangeCount(address common.Address, name string) uint64
 }
 
-func newAccountKeysGetFunction(
+func newAccountContractsGetFunction(
 	gauge common.MemoryGauge,
-	provider AccountKeyProvider,
+	provider AccountContractProvider,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
+	existenceProvider, ok := provider.(AccountContractExistenceProvider)
+	if ok && !existenceProvider.AccountContractExistenceSupported() {
+		existenceProvider = nil
+	}
+
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
-			index := indexValue.ToInt()
+			name := nameValue.Str
 
 			var err error
-			var accountKey *AccountKey
+
+			// Avoid fetching the code if the contract does not exist,
+			// and defer fetching the code until it is accessed
+
+			if existenceProvider != nil {
+				var exists bool
+				wrapPanic(func() {
+					exists, err = existenceProvider.AccountContractExists(address, name)
+				})
+				if err != nil {
+					panic(mapHostError(existenceProvider, err))
+				}
+
+				if !exists {
+					return interpreter.NewNilValue(invocation.Interpreter)
+				}
+
+				inter := invocation.Interpreter
+
+				// The deployed contract must have the code of the contract at the time it was retrieved.
+				// If the contract was updated or removed before the code is accessed,
+				// the code at the time it was retrieved is no longer available
+
+				changeCount := existenceProvider.AccountContractChangeCount(address, name)
+
+				return interpreter.NewSomeValueNonCopying(
+					inter,
+					interpreter.NewLazyDeployedContractValue(
+						inter,
+						addressValue,
+						nameValue,
+						func() *interpreter.ArrayValue {
+							if existenceProvider.AccountContractChangeCount(address, name) != changeCount {
+								panic(errors.NewDefaultUserError(
+									"cannot get code of contract %s: the contract was updated or removed after it was retrieved",
+									name,
+								))
+							}
+
+							var code []byte
+							var err error
+							wrapPanic(func() {
+								code, err = provider.GetAccountContractCode(address, name)
+							})
+							if err != nil {
+								panic(mapHostError(provider, err))
+							}
+
+							return interpreter.ByteSliceToByteArrayValue(inter, code)
+						},
+					),
+				)
+			}
+
+			var code []byte
 			wrapPanic(func() {
-				accountKey, err = provider.GetAccountKey(address, index)
+				code, err = provider.GetAccountContractCode(address, name)
 			})
-
 			if err != nil {
-				panic(err)
+				panic(mapHostError(provider, err))
 			}
 
-			// Here it is expected the host function to return a nil key, if a key is not found at the given index.
-			// This is done because, if the host function returns an error when a key is not found, then
-			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
-			if accountKey == nil {
+			if len(code) > 0 {
+				return interpreter.NewSomeValueNonCopying(
+					invocation.Interpreter,
+					interpreter.NewDeployedContractValue(
+						invocation.Interpreter,
+						addressValue,
+						nameValue,
+						interpreter.ByteSliceToByteArrayValue(
+							invocation.Interpreter,
+							code,
+						),
+					),
+				)
+			} else {
 				return interpreter.NewNilValue(invocation.Interpreter)
 			}
-
-			inter := invocation.Interpreter
-
-			return interpreter.NewSomeValueNonCopying(
-				inter,
-				NewAccountKeyValue(
-					inter,
-					invocation.GetLocationRange,
-					accountKey,
-					// public keys are assumed to be already validated.
-					func(
-						_ *interpreter.Interpreter,
-						_ func() interpreter.LocationRange,
-						_ *interpreter.CompositeValue,
-					) error {
-						return nil
-					},
-				),
-			)
 		},
-		sema.AccountKeysTypeGetFunctionType,
+		sema.AuthAccountContractsTypeGetFunctionType,
 	)
 }
 
-type AccountKeyRevocationHandler interface {
-	EventEmitter
-	// RevokeAccountKey removes a key from an account by index.
-	RevokeAccountKey(address common.Address, index int) (*AccountKey, error)
+type AccountContractValueProvider interface {
+	// GetAccountContractValue returns the contract value of an account contract,
+	// or nil if the contract is not instantiated.
+	GetAccountContractValue(
+		inter *interpreter.Interpreter,
+		address common.Address,
+		name string,
+	) (*interpreter.CompositeValue, error)
 }
 
-func newAccountKeysRevokeFunction(
+func newAccountContractsBorrowContractFunction(
 	gauge common.MemoryGauge,
-	handler AccountKeyRevocationHandler,
+	provider AccountContractValueProvider,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
@@ -691,173 +3180,81 @@ func newAccountKeysRevokeFunction(
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			indexValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
-			index := indexValue.ToInt()
+			name := nameValue.Str
+
+			inter := invocation.Interpreter
 
+			var contractValue *interpreter.CompositeValue
 			var err error
-			var accountKey *AccountKey
 			wrapPanic(func() {
-				accountKey, err = handler.RevokeAccountKey(address, index)
+				contractValue, err = provider.GetAccountContractValue(inter, address, name)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(provider, err))
 			}
 
-			// Here it is expected the host function to return a nil key, if a key is not found at the given index.
-			// This is done because, if the host function returns an error when a key is not found, then
-			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
-			if accountKey == nil {
-				return interpreter.NewNilValue(invocation.Interpreter)
+			if contractValue == nil {
+				return interpreter.NewNilValue(inter)
 			}
 
-			inter := invocation.Interpreter
+			// NOTE: the reference is not authorized,
+			// it only allows read-only access to the contract
 
-			handler.EmitEvent(
+			reference := interpreter.NewEphemeralReferenceValue(
 				inter,
-				AccountKeyRemovedEventType,
-				[]interpreter.Value{
-					addressValue,
-					indexValue,
-				},
-				invocation.GetLocationRange,
+				false,
+				contractValue,
+				sema.AnyStructType,
 			)
 
-			return interpreter.NewSomeValueNonCopying(
-				inter,
-				NewAccountKeyValue(
-					inter,
-					invocation.GetLocationRange,
-					accountKey,
-					// public keys are assumed to be already validated.
-					func(
-						_ *interpreter.Interpreter,
-						_ func() interpreter.LocationRange,
-						_ *interpreter.CompositeValue,
-					) error {
-						return nil
-					},
-				),
-			)
+			return interpreter.NewSomeValueNonCopying(inter, reference)
 		},
-		sema.AuthAccountKeysTypeRevokeFunctionType,
+		sema.PublicAccountContractsTypeBorrowContractFunctionType,
 	)
 }
 
-type PublicAccountKeysHandler interface {
-	AccountKeyProvider
+// ContractDeploymentObserver is an optional interface an AccountContractAdditionHandler can implement,
+// to be notified after a contract was successfully added or updated,
+// e.g. to update indices or invalidate caches.
+type ContractDeploymentObserver interface {
+	// OnContractDeployed is called after the code of the contract was written
+	// and the AccountContractAdded or AccountContractUpdated event was emitted.
+	OnContractDeployed(address common.Address, name string, isUpdate bool, code []byte)
 }
 
-func newPublicAccountKeysValue(
-	gauge common.MemoryGauge,
-	handler PublicAccountKeysHandler,
-	addressValue interpreter.AddressValue,
-) interpreter.Value {
-	return interpreter.NewPublicAccountKeysValue(
-		gauge,
-		addressValue,
-		newAccountKeysGetFunction(
-			gauge,
-			handler,
-			addressValue,
-		),
-	)
+// DeploymentFeeProvider is an optional interface an AccountContractAdditionHandler can implement,
+// if the chain charges a fee for deploying contracts.
+type DeploymentFeeProvider interface {
+	// GetContractDeploymentFee returns the fee deducted for deploying the given contract code.
+	// No fee is deducted if the fee is zero.
+	GetContractDeploymentFee(address common.Address, name string, codeSize int) (uint64, error)
 }
 
-type PublicAccountContractsHandler interface {
+// ContractCountLimitProvider is an optional interface an AccountContractAdditionHandler can implement,
+// to limit the number of contracts an account may contain.
+type ContractCountLimitProvider interface {
 	AccountContractNamesProvider
-	AccountContractProvider
-}
-
-func newPublicAccountContractsValue(
-	gauge common.MemoryGauge,
-	handler PublicAccountContractsHandler,
-	addressValue interpreter.AddressValue,
-) interpreter.Value {
-	return interpreter.NewPublicAccountContractsValue(
-		gauge,
-		addressValue,
-		newAccountContractsGetFunction(
-			gauge,
-			handler,
-			addressValue,
-		),
-		newAccountContractsGetNamesFunction(
-			handler,
-			addressValue,
-		),
-	)
-}
-
-type AccountContractNamesProvider interface {
-	// GetAccountContractNames returns the names of all contracts deployed in an account.
-	GetAccountContractNames(address common.Address) ([]string, error)
-}
-
-func newAccountContractsGetNamesFunction(
-	provider AccountContractNamesProvider,
-	addressValue interpreter.AddressValue,
-) func(
-	inter *interpreter.Interpreter,
-	getLocationRange func() interpreter.LocationRange,
-) *interpreter.ArrayValue {
-
-	// Converted addresses can be cached and don't have to be recomputed on each function invocation
-	address := addressValue.ToAddress()
-
-	return func(
-		inter *interpreter.Interpreter,
-		getLocationRange func() interpreter.LocationRange,
-	) *interpreter.ArrayValue {
-		var names []string
-		var err error
-		wrapPanic(func() {
-			names, err = provider.GetAccountContractNames(address)
-		})
-		if err != nil {
-			panic(err)
-		}
-
-		values := make([]interpreter.Value, len(names))
-		for i, name := range names {
-			memoryUsage := common.NewStringMemoryUsage(len(name))
-			values[i] = interpreter.NewStringValue(
-				inter,
-				memoryUsage,
-				func() string {
-					return name
-				},
-			)
-		}
-
-		arrayType := interpreter.NewVariableSizedStaticType(
-			inter,
-			interpreter.NewPrimitiveStaticType(
-				inter,
-				interpreter.PrimitiveStaticTypeString,
-			),
-		)
-
-		return interpreter.NewArrayValue(
-			inter,
-			getLocationRange,
-			arrayType,
-			common.Address{},
-			values...,
-		)
-	}
+	// MaxContractsPerAccount returns the maximum number of contracts an account may contain.
+	// Zero means unlimited.
+	MaxContractsPerAccount() uint64
 }
 
-type AccountContractProvider interface {
-	// GetAccountContractCode returns the code associated with an account contract.
-	GetAccountContractCode(address common.Address, name string) ([]byte, error)
+type AccountContractTypeProvider interface {
+	AccountContractProvider
+	ParseAndCheckProgram(
+		code []byte,
+		location common.Location,
+		getAndSetProgram bool,
+	) (*interpreter.Program, error)
 }
 
-func newAccountContractsGetFunction(
+func newAccountContractsGetContractTypeFunction(
 	gauge common.MemoryGauge,
-	provider AccountContractProvider,
+	provider AccountContractTypeProvider,
 	addressValue interpreter.AddressValue,
 ) *interpreter.HostFunctionValue {
 
@@ -873,44 +3270,60 @@ func newAccountContractsGetFunction(
 			}
 			name := nameValue.Str
 
+			inter := invocation.Interpreter
+
 			var code []byte
 			var err error
 			wrapPanic(func() {
 				code, err = provider.GetAccountContractCode(address, name)
 			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			if len(code) == 0 {
+				return interpreter.NewNilValue(inter)
+			}
+
+			location := common.NewAddressLocation(inter, address, name)
+
+			var program *interpreter.Program
+			wrapPanic(func() {
+				program, err = provider.ParseAndCheckProgram(code, location, false)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			// The deployed code declares exactly one contract or contract interface,
+			// which is either a composite type or an interface type
+
+			_, declaredName, err := ClassifyContractCode(program)
 			if err != nil {
 				panic(err)
 			}
 
-			if len(code) > 0 {
-				return interpreter.NewSomeValueNonCopying(
-					invocation.Interpreter,
-					interpreter.NewDeployedContractValue(
-						invocation.Interpreter,
-						addressValue,
-						nameValue,
-						interpreter.ByteSliceToByteArrayValue(
-							invocation.Interpreter,
-							code,
-						),
-					),
-				)
-			} else {
-				return interpreter.NewNilValue(invocation.Interpreter)
+			variable, ok := program.Elaboration.GlobalTypes.Get(declaredName)
+			if !ok {
+				panic(errors.NewUnreachableError())
 			}
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				interpreter.NewTypeValue(
+					inter,
+					interpreter.ConvertSemaToStaticType(inter, variable.Type),
+				),
+			)
 		},
-		sema.AuthAccountContractsTypeGetFunctionType,
+		sema.AuthAccountContractsTypeGetContractTypeFunctionType,
 	)
 }
 
 type AccountContractAdditionHandler interface {
 	EventEmitter
 	AccountContractProvider
-	ParseAndCheckProgram(
-		code []byte,
-		location common.Location,
-		program bool,
-	) (*interpreter.Program, error)
+	AccountContractValueProvider
 	// UpdateAccountContractCode updates the code associated with an account contract.
 	UpdateAccountContractCode(address common.Address, name string, code []byte) error
 	RecordContractUpdate(address common.Address, name string, value *interpreter.CompositeValue)
@@ -924,40 +3337,187 @@ type AccountContractAdditionHandler interface {
 		error,
 	)
 	TemporarilyRecordCode(location common.AddressLocation, code []byte)
+	// ContractUpdateValidationDisabled returns true if contract updates
+	// are not validated to be compatible with the already stored data.
+	ContractUpdateValidationDisabled() bool
+	// ParseAndCheckContractDeployment parses and checks the code of a contract deployment,
+	// without storing the program.
+	// Imports of contracts which are not deployed are reported as sema.UnresolvedImportError.
+	ParseAndCheckContractDeployment(
+		code []byte,
+		location common.AddressLocation,
+	) (*interpreter.Program, error)
+}
+
+func emitContractDeploymentFeeDeductedEvent(
+	inter *interpreter.Interpreter,
+	emitter EventEmitter,
+	feeProvider DeploymentFeeProvider,
+	addressValue interpreter.AddressValue,
+	nameValue *interpreter.StringValue,
+	codeSize int,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	var fee uint64
+	var err error
+	wrapPanic(func() {
+		fee, err = feeProvider.GetContractDeploymentFee(addressValue.ToAddress(), nameValue.Str, codeSize)
+	})
+	if err != nil {
+		panic(mapHostError(feeProvider, err))
+	}
+
+	if fee == 0 {
+		return
+	}
+
+	emitter.EmitEvent(
+		inter,
+		ContractDeploymentFeeDeductedEventType,
+		[]interpreter.Value{
+			addressValue,
+			nameValue,
+			interpreter.NewUInt64Value(
+				inter,
+				func() uint64 {
+					return fee
+				},
+			),
+		},
+		getLocationRange,
+	)
+}
+
+type authAccountContractsChangeOptions struct {
+	isUpdate      bool
+	withMigration bool
+	withPostInit  bool
+	inferName     bool
+	withResult    bool
 }
 
 // newAuthAccountContractsChangeFunction called when e.g.
 // - adding: `AuthAccount.contracts.add(name: "Foo", code: [...])` (isUpdate = false)
 // - updating: `AuthAccount.contracts.update__experimental(name: "Foo", code: [...])` (isUpdate = true)
+// - updating with a migration: `AuthAccount.contracts.updateWithMigration(name: "Foo", code: [...], migrate: ...)`
+//   (isUpdate = true, withMigration = true)
+// - adding with an inferred name: `AuthAccount.contracts.addInferred(code: [...])` (inferName = true)
+// - adding or updating with a result: `AuthAccount.contracts.addWithResult(name: "Foo", code: [...])`
+//   and `AuthAccount.contracts.updateWithResult(name: "Foo", code: [...])` (withResult = true)
+// - adding with a post-init function: `AuthAccount.contracts.addWithInit(name: "Foo", code: [...], postInit: ...)`
+//   (withPostInit = true)
 //
 func newAuthAccountContractsChangeFunction(
 	gauge common.MemoryGauge,
 	handler AccountContractAdditionHandler,
 	addressValue interpreter.AddressValue,
-	isUpdate bool,
+	options authAccountContractsChangeOptions,
 ) *interpreter.HostFunctionValue {
+
+	isUpdate := options.isUpdate
+	withMigration := options.withMigration
+	withPostInit := options.withPostInit
+	inferName := options.inferName
+
+	var functionType *sema.FunctionType
+	switch {
+	case withMigration:
+		functionType = sema.AuthAccountContractsTypeUpdateWithMigrationFunctionType
+	case withPostInit:
+		functionType = sema.AuthAccountContractsTypeAddWithInitFunctionType
+	case inferName:
+		functionType = sema.AuthAccountContractsTypeAddInferredFunctionType
+	case options.withResult && isUpdate:
+		functionType = sema.AuthAccountContractsTypeUpdateWithResultFunctionType
+	case options.withResult:
+		functionType = sema.AuthAccountContractsTypeAddWithResultFunctionType
+	default:
+		functionType = sema.AuthAccountContractsTypeAddFunctionType
+	}
+
 	return interpreter.NewHostFunctionValue(
 		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
 
-			const requiredArgumentCount = 2
+			var nameValue *interpreter.StringValue
+			var newCodeValue *interpreter.ArrayValue
+			var ok bool
 
-			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
+			requiredArgumentCount := 2
+
+			if inferName {
+				requiredArgumentCount = 1
+
+				newCodeValue, ok = invocation.Arguments[0].(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+			} else {
+				nameValue, ok = invocation.Arguments[0].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				newCodeValue, ok = invocation.Arguments[1].(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
 			}
 
-			newCodeValue, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
+			var migrationFunction interpreter.FunctionValue
+			if withMigration {
+				migrationFunction, ok = invocation.Arguments[2].(interpreter.FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				requiredArgumentCount++
+			}
+
+			var postInitFunction interpreter.FunctionValue
+			if withPostInit {
+				postInitFunction, ok = invocation.Arguments[2].(interpreter.FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				requiredArgumentCount++
 			}
 
 			constructorArguments := invocation.Arguments[requiredArgumentCount:]
 			constructorArgumentTypes := invocation.ArgumentTypes[requiredArgumentCount:]
 
-			code, err := interpreter.ByteArrayValueToByteSlice(gauge, newCodeValue)
+			code, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, newCodeValue)
 			if err != nil {
-				panic(errors.NewDefaultUserError("add requires the second argument to be an array"))
+				panic(err)
+			}
+
+			// Ensure the code is valid UTF-8 before parsing it,
+			// to report a clear error instead of a parsing error
+
+			if offset := invalidUTF8Offset(code); offset >= 0 {
+				panic(errors.NewDefaultUserError(
+					"invalid contract code: invalid UTF-8 byte sequence at offset %d",
+					offset,
+				))
+			}
+
+			// Infer the name from the single contract or contract interface declared in the code
+
+			if inferName {
+				inferredName, err := inferContractName(handler, addressValue.ToAddress(), code)
+				if err != nil {
+					panic(&InvalidContractDeploymentError{
+						Err:           err,
+						LocationRange: invocation.GetLocationRange(),
+					})
+				}
+
+				nameValue = interpreter.NewStringValue(
+					invocation.Interpreter,
+					common.NewStringMemoryUsage(len(inferredName)),
+					func() string {
+						return inferredName
+					},
+				)
 			}
 
 			// Get the existing code
@@ -971,6 +3531,13 @@ func newAuthAccountContractsChangeFunction(
 				))
 			}
 
+			if !isValidIdentifier(contractName) {
+				panic(errors.NewDefaultUserError(
+					"invalid contract name argument `%s`: it must be a valid identifier",
+					contractName,
+				))
+			}
+
 			address := addressValue.ToAddress()
 			existingCode, err := handler.GetAccountContractCode(address, contractName)
 			if err != nil {
@@ -1000,6 +3567,8 @@ func newAuthAccountContractsChangeFunction(
 						address.ShortHexWithPrefix(),
 					))
 				}
+
+				checkContractCountLimit(handler, address, invocation.GetLocationRange)
 			}
 
 			// Check the code
@@ -1025,64 +3594,47 @@ func newAuthAccountContractsChangeFunction(
 			// NOTE: do NOT use the program obtained from the host environment, as the current program.
 			// Always re-parse and re-check the new program.
 
-			// NOTE: *DO NOT* store the program – the new or updated program
-			// should not be effective during the execution
-
-			const storeProgram = false
-
-			program, err := handler.ParseAndCheckProgram(
+			program, err := handler.ParseAndCheckContractDeployment(
 				code,
 				location,
-				storeProgram,
 			)
 			handleContractUpdateError(err)
 
 			// The code may declare exactly one contract or one contract interface.
 
-			var contractTypes []*sema.CompositeType
-			var contractInterfaceTypes []*sema.InterfaceType
+			declarationKind, declaredName, err := ClassifyContractCode(program)
+			if err != nil {
+				// Update the code for the error pretty printing
+				// NOTE: only do this when an error occurs
 
-			program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
-				switch ty := variable.Type.(type) {
-				case *sema.CompositeType:
-					if ty.Kind == common.CompositeKindContract {
-						contractTypes = append(contractTypes, ty)
-					}
+				handler.TemporarilyRecordCode(location, code)
 
-				case *sema.InterfaceType:
-					if ty.CompositeKind == common.CompositeKindContract {
-						contractInterfaceTypes = append(contractInterfaceTypes, ty)
-					}
-				}
-			})
+				panic(err)
+			}
 
-			var deployedType sema.Type
 			var contractType *sema.CompositeType
-			var contractInterfaceType *sema.InterfaceType
-			var declaredName string
-			var declarationKind common.DeclarationKind
-
-			switch {
-			case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
-				contractType = contractTypes[0]
-				declaredName = contractType.Identifier
-				deployedType = contractType
-				declarationKind = common.DeclarationKindContract
-			case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
-				contractInterfaceType = contractInterfaceTypes[0]
-				declaredName = contractInterfaceType.Identifier
-				deployedType = contractInterfaceType
-				declarationKind = common.DeclarationKindContractInterface
-			}
-
-			if deployedType == nil {
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+			if declarationKind == common.DeclarationKindContract {
+				variable, ok := program.Elaboration.GlobalTypes.Get(declaredName)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				contractType = variable.Type.(*sema.CompositeType)
+			}
 
-				handler.TemporarilyRecordCode(location, code)
+			// Only contracts have a stored value which can be migrated
 
+			if withMigration && contractType == nil {
 				panic(errors.NewDefaultUserError(
-					"invalid %s: the code must declare exactly one contract or contract interface",
+					"invalid %s: only contracts can be updated with a migration",
+					declarationKind.Name(),
+				))
+			}
+
+			// Only contracts have a value which can be checked after initialization
+
+			if withPostInit && contractType == nil {
+				panic(errors.NewDefaultUserError(
+					"invalid %s: only contracts can be added with a post-init function",
 					declarationKind.Name(),
 				))
 			}
@@ -1104,9 +3656,10 @@ func newAuthAccountContractsChangeFunction(
 				))
 			}
 
-			// Validate the contract update
+			// Validate the contract update,
+			// unless validation was explicitly disabled
 
-			if isUpdate {
+			if isUpdate && !handler.ContractUpdateValidationDisabled() {
 				oldCode, err := handler.GetAccountContractCode(address, contractName)
 				handleContractUpdateError(err)
 
@@ -1128,6 +3681,39 @@ func newAuthAccountContractsChangeFunction(
 
 			inter := invocation.Interpreter
 
+			// Migrate the stored contract value before the code is updated.
+			// The migration is part of the same execution as the update,
+			// so a failing migration also aborts the update
+
+			if migrationFunction != nil {
+				migrateContractValue(
+					handler,
+					inter,
+					address,
+					contractName,
+					migrationFunction,
+					invocation.GetLocationRange,
+				)
+			}
+
+			// The post-init function is called after the contract was initialized,
+			// but before the code is deployed, so a failing check aborts the deployment
+
+			var postInit func(contractValue *interpreter.CompositeValue)
+			if postInitFunction != nil {
+				postInit = func(contractValue *interpreter.CompositeValue) {
+					checkInitializedContractValue(
+						handler,
+						inter,
+						location,
+						program,
+						contractValue,
+						postInitFunction,
+						invocation.GetLocationRange,
+					)
+				}
+			}
+
 			err = updateAccountContractCode(
 				handler,
 				location,
@@ -1140,46 +3726,453 @@ func newAuthAccountContractsChangeFunction(
 				constructorArgumentTypes,
 				updateAccountContractCodeOptions{
 					createContract: !isUpdate,
+					postInit:       postInit,
 				},
 			)
 			if err != nil {
 				// Update the code for the error pretty printing
 				// NOTE: only do this when an error occurs
 
-				handler.TemporarilyRecordCode(location, code)
+				handler.TemporarilyRecordCode(location, code)
+
+				panic(err)
+			}
+
+			var eventType *sema.CompositeType
+
+			if isUpdate {
+				eventType = AccountContractUpdatedEventType
+			} else {
+				eventType = AccountContractAddedEventType
+			}
+
+			codeHashValue := CodeToHashValue(inter, code)
+
+			handler.EmitEvent(
+				inter,
+				eventType,
+				[]interpreter.Value{
+					addressValue,
+					codeHashValue,
+					nameValue,
+				},
+				invocation.GetLocationRange,
+			)
+
+			if feeProvider, ok := handler.(DeploymentFeeProvider); ok {
+				emitContractDeploymentFeeDeductedEvent(
+					inter,
+					handler,
+					feeProvider,
+					addressValue,
+					nameValue,
+					len(code),
+					invocation.GetLocationRange,
+				)
+			}
+
+			// Notify the host only after the code was written and the event was emitted,
+			// i.e. only if the deployment succeeded
+
+			if observer, ok := handler.(ContractDeploymentObserver); ok {
+				wrapPanic(func() {
+					observer.OnContractDeployed(address, contractName, isUpdate, code)
+				})
+			}
+
+			deployedContractValue := interpreter.NewDeployedContractValue(
+				inter,
+				addressValue,
+				nameValue,
+				newCodeValue,
+			)
+
+			if !options.withResult {
+				return deployedContractValue
+			}
+
+			var oldCodeHashValue interpreter.OptionalValue = interpreter.NilValue{}
+			if isUpdate {
+				oldCodeHashValue = interpreter.NewSomeValueNonCopying(
+					inter,
+					CodeToHashValue(inter, existingCode),
+				)
+			}
+
+			return interpreter.NewDeploymentResultValue(
+				inter,
+				deployedContractValue,
+				interpreter.NewBoolValue(inter, isUpdate),
+				oldCodeHashValue,
+				CodeToHashValue(inter, code),
+			)
+		},
+		functionType,
+	)
+}
+
+// invalidUTF8Offset returns the offset of the first invalid UTF-8 byte sequence in the given code,
+// or -1 if the code is valid UTF-8
+//
+func invalidUTF8Offset(code []byte) int {
+	offset := 0
+	for offset < len(code) {
+		r, size := utf8.DecodeRune(code[offset:])
+		if r == utf8.RuneError && size <= 1 {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
+
+// isValidIdentifier returns true if the given string is a syntactically valid identifier,
+// i.e. it consists only of letters, digits, and underscores, and does not start with a digit
+//
+func isValidIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+
+	for i, r := range identifier {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= 'A' && r <= 'Z',
+			r == '_':
+
+			continue
+
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				return false
+			}
+
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidContractCodeTopLevelDeclarationKinds are the kinds of declarations
+// which are allowed at the top-level of contract code
+var ValidContractCodeTopLevelDeclarationKinds = []common.DeclarationKind{
+	common.DeclarationKindPragma,
+	common.DeclarationKindImport,
+	common.DeclarationKindContract,
+	common.DeclarationKindContractInterface,
+}
+
+// checkContractCodeTopLevelDeclarations returns an error
+// if the given program has a top-level declaration which is not allowed in contract code,
+// e.g. a transaction or a function.
+//
+// The checker already rejects such declarations if it is configured to validate top-level declarations,
+// but the program might have been checked without this validation
+//
+func checkContractCodeTopLevelDeclarations(program *ast.Program) error {
+	if program == nil {
+		return nil
+	}
+
+	for _, declaration := range program.Declarations() {
+		declarationKind := declaration.DeclarationKind()
+
+		isValid := false
+		for _, validDeclarationKind := range ValidContractCodeTopLevelDeclarationKinds {
+			if declarationKind == validDeclarationKind {
+				isValid = true
+				break
+			}
+		}
+
+		if !isValid {
+			return &InvalidContractCodeTopLevelDeclarationError{
+				DeclarationKind: declarationKind,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ClassifyContractCode returns the declaration kind and the name
+// of the single contract or contract interface declared in the given program,
+// i.e. either common.DeclarationKindContract or common.DeclarationKindContractInterface.
+//
+// Returns an error if the program has a top-level declaration which is not allowed in contract code,
+// or if the program does not declare exactly one contract or contract interface.
+//
+func ClassifyContractCode(program *interpreter.Program) (kind common.DeclarationKind, name string, err error) {
+	err = checkContractCodeTopLevelDeclarations(program.Program)
+	if err != nil {
+		return common.DeclarationKindUnknown, "", err
+	}
+
+	var contractTypes []*sema.CompositeType
+	var contractInterfaceTypes []*sema.InterfaceType
+
+	program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
+		switch ty := variable.Type.(type) {
+		case *sema.CompositeType:
+			if ty.Kind == common.CompositeKindContract {
+				contractTypes = append(contractTypes, ty)
+			}
+
+		case *sema.InterfaceType:
+			if ty.CompositeKind == common.CompositeKindContract {
+				contractInterfaceTypes = append(contractInterfaceTypes, ty)
+			}
+		}
+	})
+
+	switch {
+	case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
+		return common.DeclarationKindContract, contractTypes[0].Identifier, nil
+
+	case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
+		return common.DeclarationKindContractInterface, contractInterfaceTypes[0].Identifier, nil
+	}
+
+	return common.DeclarationKindUnknown, "", errors.NewDefaultUserError(
+		"invalid %s: the code must declare exactly one contract or contract interface",
+		common.DeclarationKindUnknown.Name(),
+	)
+}
+
+// inferContractName returns the name of the single contract or contract interface
+// declared in the given code.
+// The name is not known yet, so the code is checked at the address location without a name.
+//
+func inferContractName(
+	handler AccountContractAdditionHandler,
+	address common.Address,
+	code []byte,
+) (string, error) {
+	location := common.AddressLocation{
+		Address: address,
+	}
+
+	program, err := handler.ParseAndCheckContractDeployment(code, location)
+	if err != nil {
+		// Update the code for the error pretty printing
+		// NOTE: only do this when an error occurs
+
+		handler.TemporarilyRecordCode(location, code)
+
+		return "", err
+	}
+
+	_, name, err := ClassifyContractCode(program)
+	if err != nil {
+		return "", errors.NewDefaultUserError(
+			"cannot infer contract name: %s",
+			err.Error(),
+		)
+	}
+
+	return name, nil
+}
+
+// migrateContractValue invokes the given migration function
+// with a reference to the stored value of the given contract.
+//
+func migrateContractValue(
+	handler AccountContractValueProvider,
+	inter *interpreter.Interpreter,
+	address common.Address,
+	name string,
+	migrationFunction interpreter.FunctionValue,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	contractValue, err := handler.GetAccountContractValue(inter, address, name)
+	if err != nil {
+		panic(err)
+	}
+
+	if contractValue == nil {
+		panic(errors.NewDefaultUserError(
+			"cannot migrate contract %q in account %s: no contract value is stored",
+			name,
+			address.ShortHexWithPrefix(),
+		))
+	}
+
+	referenceType := sema.AuthAccountContractsTypeMigrationReferenceType
+
+	reference := interpreter.NewEphemeralReferenceValue(
+		inter,
+		referenceType.Authorized,
+		contractValue,
+		referenceType.Type,
+	)
+
+	_, err = inter.InvokeFunctionValue(
+		migrationFunction,
+		[]interpreter.Value{reference},
+		[]sema.Type{referenceType},
+		[]sema.Type{referenceType},
+		getLocationRange(),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// InitializedContractProgramProvider is an optional interface an AccountContractAdditionHandler can implement,
+// to make the program of a contract which was initialized, but is not deployed yet,
+// available while the given function is called, e.g. so the type of the contract can be loaded
+type InitializedContractProgramProvider interface {
+	WithInitializedContractProgram(
+		location common.AddressLocation,
+		program *interpreter.Program,
+		f func(),
+	)
+}
+
+// checkInitializedContractValue calls the given post-init function
+// with a reference to the newly initialized contract value
+//
+func checkInitializedContractValue(
+	handler AccountContractAdditionHandler,
+	inter *interpreter.Interpreter,
+	location common.AddressLocation,
+	program *interpreter.Program,
+	contractValue *interpreter.CompositeValue,
+	postInitFunction interpreter.FunctionValue,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	referenceType := sema.AuthAccountContractsTypePostInitReferenceType
+
+	reference := interpreter.NewEphemeralReferenceValue(
+		inter,
+		referenceType.Authorized,
+		contractValue,
+		referenceType.Type,
+	)
+
+	invoke := func() {
+		_, err := inter.InvokeFunctionValue(
+			postInitFunction,
+			[]interpreter.Value{reference},
+			[]sema.Type{referenceType},
+			[]sema.Type{referenceType},
+			getLocationRange(),
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if provider, ok := handler.(InitializedContractProgramProvider); ok {
+		provider.WithInitializedContractProgram(location, program, invoke)
+	} else {
+		invoke()
+	}
+}
+
+// checkContractCountLimit ensures that adding a contract to the given account
+// does not exceed the maximum number of contracts per account, if the handler limits it
+//
+func checkContractCountLimit(
+	handler AccountContractAdditionHandler,
+	address common.Address,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	limitProvider, ok := handler.(ContractCountLimitProvider)
+	if !ok {
+		return
+	}
+
+	limit := limitProvider.MaxContractsPerAccount()
+	if limit == 0 {
+		return
+	}
+
+	var names []string
+	var err error
+	wrapPanic(func() {
+		names, err = limitProvider.GetAccountContractNames(address)
+	})
+	if err != nil {
+		panic(mapHostError(handler, err))
+	}
+
+	count := uint64(len(names)) + 1
+	if count > limit {
+		panic(&MaxContractsExceededError{
+			Address:       address,
+			Limit:         limit,
+			Count:         count,
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
+// MaxContractsExceededError is reported when adding a contract
+// would exceed the maximum number of contracts per account
+//
+type MaxContractsExceededError struct {
+	Address common.Address
+	Limit   uint64
+	Count   uint64
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = &MaxContractsExceededError{}
 
-				panic(err)
-			}
+func (*MaxContractsExceededError) IsUserError() {}
 
-			var eventType *sema.CompositeType
+func (e *MaxContractsExceededError) Error() string {
+	return fmt.Sprintf(
+		"cannot add contract to account %s: it would contain %d contracts, but at most %d are allowed",
+		e.Address.ShortHexWithPrefix(),
+		e.Count,
+		e.Limit,
+	)
+}
 
-			if isUpdate {
-				eventType = AccountContractUpdatedEventType
-			} else {
-				eventType = AccountContractAddedEventType
-			}
+// StorageGrowthLimitError is reported when the storage used by an account
+// exceeds the storage growth limit
+//
+type StorageGrowthLimitError struct {
+	Address common.Address
+	Used    uint64
+	Limit   uint64
+	interpreter.LocationRange
+}
 
-			codeHashValue := CodeToHashValue(inter, code)
+var _ errors.UserError = &StorageGrowthLimitError{}
 
-			handler.EmitEvent(
-				inter,
-				eventType,
-				[]interpreter.Value{
-					addressValue,
-					codeHashValue,
-					nameValue,
-				},
-				invocation.GetLocationRange,
-			)
+func (*StorageGrowthLimitError) IsUserError() {}
 
-			return interpreter.NewDeployedContractValue(
-				inter,
-				addressValue,
-				nameValue,
-				newCodeValue,
-			)
-		},
-		sema.AuthAccountContractsTypeAddFunctionType,
+func (e *StorageGrowthLimitError) Error() string {
+	return fmt.Sprintf(
+		"storage used by account %s exceeds the limit: %d bytes used, but at most %d bytes are allowed",
+		e.Address.ShortHexWithPrefix(),
+		e.Used,
+		e.Limit,
+	)
+}
+
+// InvalidContractCodeTopLevelDeclarationError is reported when contract code
+// has a top-level declaration which is not allowed in contract code,
+// e.g. a transaction or a function
+//
+type InvalidContractCodeTopLevelDeclarationError struct {
+	DeclarationKind common.DeclarationKind
+}
+
+var _ errors.UserError = &InvalidContractCodeTopLevelDeclarationError{}
+
+func (*InvalidContractCodeTopLevelDeclarationError) IsUserError() {}
+
+func (e *InvalidContractCodeTopLevelDeclarationError) Error() string {
+	return fmt.Sprintf(
+		"invalid contract code: %s declarations are not allowed at the top-level, "+
+			"the code may only declare a contract or contract interface",
+		e.DeclarationKind.Name(),
 	)
 }
 
@@ -1212,6 +4205,31 @@ func (e *InvalidContractDeploymentError) Unwrap() error {
 	return e.Err
 }
 
+// InvalidAddressError is reported when an address
+// does not match the address format of the chain
+//
+type InvalidAddressError struct {
+	Address common.Address
+	Err     error
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = &InvalidAddressError{}
+
+func (*InvalidAddressError) IsUserError() {}
+
+func (e *InvalidAddressError) Error() string {
+	return fmt.Sprintf(
+		"invalid address %s: %s",
+		e.Address.ShortHexWithPrefix(),
+		e.Err.Error(),
+	)
+}
+
+func (e *InvalidAddressError) Unwrap() error {
+	return e.Err
+}
+
 // InvalidContractDeploymentOriginError
 //
 type InvalidContractDeploymentOriginError struct {
@@ -1250,6 +4268,9 @@ func ignoreUpdatedProgramParserError(err error) bool {
 
 type updateAccountContractCodeOptions struct {
 	createContract bool
+	// postInit is called with the instantiated contract value,
+	// before the code is updated
+	postInit func(contractValue *interpreter.CompositeValue)
 }
 
 // updateAccountContractCode updates an account contract's code.
@@ -1301,6 +4322,10 @@ func updateAccountContractCode(
 		if err != nil {
 			return err
 		}
+
+		if options.postInit != nil {
+			options.postInit(contractValue)
+		}
 	}
 
 	// NOTE: only update account code if contract instantiation succeeded
@@ -1413,12 +4438,22 @@ type AccountContractRemovalHandler interface {
 	RecordContractRemoval(address common.Address, name string)
 }
 
+// newAuthAccountContractsRemoveFunction called when e.g.
+// - removing: `AuthAccount.contracts.remove(name: "Foo")` (withResult = false)
+// - removing with a result: `AuthAccount.contracts.removeWithResult(name: "Foo")` (withResult = true)
+//
 func newAuthAccountContractsRemoveFunction(
 	gauge common.MemoryGauge,
 	handler AccountContractRemovalHandler,
 	addressValue interpreter.AddressValue,
+	withResult bool,
 ) *interpreter.HostFunctionValue {
 
+	functionType := sema.AuthAccountContractsTypeRemoveFunctionType
+	if withResult {
+		functionType = sema.AuthAccountContractsTypeRemoveWithResultFunctionType
+	}
+
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
@@ -1441,7 +4476,7 @@ func newAuthAccountContractsRemoveFunction(
 				code, err = handler.GetAccountContractCode(address, name)
 			})
 			if err != nil {
-				panic(err)
+				panic(mapHostError(handler, err))
 			}
 
 			// Only remove the contract code, remove the contract value, and emit an event,
@@ -1468,7 +4503,7 @@ func newAuthAccountContractsRemoveFunction(
 					err = handler.RemoveAccountContractCode(address, name)
 				})
 				if err != nil {
-					panic(err)
+					panic(mapHostError(handler, err))
 				}
 
 				// NOTE: the contract recording function delays the write
@@ -1489,23 +4524,130 @@ func newAuthAccountContractsRemoveFunction(
 					invocation.GetLocationRange,
 				)
 
+				deployedContract := interpreter.NewDeployedContractValue(
+					inter,
+					addressValue,
+					nameValue,
+					interpreter.ByteSliceToByteArrayValue(
+						inter,
+						code,
+					),
+				)
+
+				if !withResult {
+					return interpreter.NewSomeValueNonCopying(
+						inter,
+						deployedContract,
+					)
+				}
+
 				return interpreter.NewSomeValueNonCopying(
 					inter,
-					interpreter.NewDeployedContractValue(
+					interpreter.NewRemovalResultValue(
 						inter,
-						addressValue,
-						nameValue,
-						interpreter.ByteSliceToByteArrayValue(
-							inter,
-							code,
-						),
+						deployedContract,
+						CodeToHashValue(inter, code),
 					),
 				)
 			} else {
 				return interpreter.NewNilValue(invocation.Interpreter)
 			}
 		},
-		sema.AuthAccountContractsTypeRemoveFunctionType,
+		functionType,
+	)
+}
+
+var enumTypesArrayStaticType = interpreter.VariableSizedStaticType{
+	Type: interpreter.PrimitiveStaticTypeMetaType,
+}
+
+func newAuthAccountContractsEnumTypesFunction(
+	gauge common.MemoryGauge,
+	provider AccountContractProvider,
+	addressValue interpreter.AddressValue,
+) *interpreter.HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+
+			inter := invocation.Interpreter
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			name := nameValue.Str
+
+			var code []byte
+			var err error
+			wrapPanic(func() {
+				code, err = provider.GetAccountContractCode(address, name)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			var enumTypes []interpreter.Value
+
+			if len(code) > 0 {
+				program, err := parser.ParseProgram(code, gauge)
+
+				// Like for the contract removal,
+				// code which is not parsable is considered to declare no enums
+				if err == nil {
+					location := common.NewAddressLocation(inter, address, name)
+
+					for _, qualifiedIdentifier := range enumQualifiedIdentifiersInProgram(program) {
+						enumTypes = append(
+							enumTypes,
+							interpreter.NewTypeValue(
+								inter,
+								interpreter.NewCompositeStaticTypeComputeTypeID(
+									inter,
+									location,
+									qualifiedIdentifier,
+								),
+							),
+						)
+					}
+				}
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.GetLocationRange,
+				enumTypesArrayStaticType,
+				common.Address{},
+				enumTypes...,
+			)
+		},
+		sema.AuthAccountContractsTypeEnumTypesFunctionType,
+	)
+}
+
+// DuplicateAccountKeyError is reported when a key is added to an account,
+// but the account already has an unrevoked key with the same public key
+//
+type DuplicateAccountKeyError struct {
+	Address     common.Address
+	KeyIndex    int
+	Fingerprint string
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = &DuplicateAccountKeyError{}
+
+func (*DuplicateAccountKeyError) IsUserError() {}
+
+func (e *DuplicateAccountKeyError) Error() string {
+	return fmt.Sprintf(
+		"cannot add duplicate key to account %s: public key (fingerprint %s) already exists as key %d",
+		e.Address.ShortHexWithPrefix(),
+		e.Fingerprint,
+		e.KeyIndex,
 	)
 }
 
@@ -1553,6 +4695,8 @@ type PublicAccountHandler interface {
 }
 
 func NewGetAccountFunction(handler PublicAccountHandler) StandardLibraryValue {
+	checkHandler(handler, "PublicAccountHandler")
+
 	return NewStandardLibraryFunction(
 		"getAccount",
 		getAccountFunctionType,
@@ -1563,6 +4707,8 @@ func NewGetAccountFunction(handler PublicAccountHandler) StandardLibraryValue {
 				panic(errors.NewUnreachableError())
 			}
 
+			validateAddress(handler, accountAddress.ToAddress(), invocation.GetLocationRange)
+
 			return NewPublicAccountValue(
 				invocation.Interpreter,
 				handler,
@@ -1572,18 +4718,209 @@ func NewGetAccountFunction(handler PublicAccountHandler) StandardLibraryValue {
 	)
 }
 
+// NameResolver resolves human-readable account names,
+// e.g. names registered in an on-chain name service
+type NameResolver interface {
+	// ResolveAccountName returns the address of the account with the given name.
+	// Returns false if the name cannot be resolved.
+	ResolveAccountName(name string) (common.Address, bool, error)
+}
+
+const getAccountByNameFunctionDocString = `
+Returns the public account for the given name, or nil if the name cannot be resolved
+`
+
+var getAccountByNameFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "name",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: sema.PublicAccountType,
+		},
+	),
+}
+
+// NewGetAccountByNameFunction returns the optional `getAccountByName` function,
+// which resolves the given name to an address using the given resolver,
+// and returns the public account for the address.
+//
+// The function is not declared by default,
+// environments for chains with a name service may declare it
+func NewGetAccountByNameFunction(resolver NameResolver, handler PublicAccountHandler) StandardLibraryValue {
+	checkHandler(handler, "PublicAccountHandler")
+
+	return NewStandardLibraryFunction(
+		"getAccountByName",
+		getAccountByNameFunctionType,
+		getAccountByNameFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			var address common.Address
+			var resolved bool
+			var err error
+			wrapPanic(func() {
+				address, resolved, err = resolver.ResolveAccountName(nameValue.Str)
+			})
+			if err != nil {
+				panic(mapHostError(resolver, err))
+			}
+
+			if !resolved {
+				return interpreter.NewNilValue(inter)
+			}
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				NewPublicAccountValue(
+					inter,
+					handler,
+					interpreter.NewAddressValue(inter, address),
+				),
+			)
+		},
+	)
+}
+
+const getContractNamesForFunctionDocString = `
+Returns the names of all contracts deployed in the given accounts, keyed by account address
+`
+
+var getContractNamesForFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "addresses",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: &sema.AddressType{},
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.DictionaryType{
+			KeyType: &sema.AddressType{},
+			ValueType: &sema.VariableSizedType{
+				Type: sema.StringType,
+			},
+		},
+	),
+}
+
+var contractNamesByAddressStaticType = interpreter.DictionaryStaticType{
+	KeyType: interpreter.PrimitiveStaticTypeAddress,
+	ValueType: interpreter.VariableSizedStaticType{
+		Type: interpreter.PrimitiveStaticTypeString,
+	},
+}
+
+type AccountContractNamesBatchProvider interface {
+	// GetAccountContractNamesBatch returns the names of all contracts deployed in the given accounts.
+	// Accounts without contracts may be omitted from the result.
+	GetAccountContractNamesBatch(addresses []common.Address) (map[common.Address][]string, error)
+}
+
+func NewGetContractNamesForFunction(provider AccountContractNamesBatchProvider) StandardLibraryValue {
+	return NewStandardLibraryFunction(
+		"getContractNamesFor",
+		getContractNamesForFunctionType,
+		getContractNamesForFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			addressesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			// Only request each account once, in the order of the given addresses
+
+			addresses := make([]common.Address, 0, addressesValue.Count())
+			seen := make(map[common.Address]struct{}, addressesValue.Count())
+
+			addressesValue.Iterate(inter, func(element interpreter.Value) (resume bool) {
+				addressValue, ok := element.(interpreter.AddressValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				address := addressValue.ToAddress()
+				if _, ok := seen[address]; ok {
+					return true
+				}
+				seen[address] = struct{}{}
+
+				validateAddress(provider, address, getLocationRange)
+
+				addresses = append(addresses, address)
+
+				return true
+			})
+
+			var namesByAddress map[common.Address][]string
+			var err error
+			wrapPanic(func() {
+				namesByAddress, err = provider.GetAccountContractNamesBatch(addresses)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			keysAndValues := make([]interpreter.Value, 0, len(addresses)*2)
+
+			for _, address := range addresses {
+				keysAndValues = append(
+					keysAndValues,
+					interpreter.NewAddressValue(inter, address),
+					newContractNamesArrayValue(
+						inter,
+						getLocationRange,
+						namesByAddress[address],
+					),
+				)
+			}
+
+			return interpreter.NewDictionaryValue(
+				inter,
+				getLocationRange,
+				contractNamesByAddressStaticType,
+				keysAndValues...,
+			)
+		},
+	)
+}
+
 func NewPublicAccountValue(
 	gauge common.MemoryGauge,
 	handler PublicAccountHandler,
 	addressValue interpreter.AddressValue,
 ) interpreter.Value {
+	recordAccountAccess(handler, addressValue)
+
+	hostLock := hostCallLock(handler)
+
 	return interpreter.NewPublicAccountValue(
 		gauge,
 		addressValue,
-		newAccountBalanceGetFunction(gauge, handler, addressValue),
-		newAccountAvailableBalanceGetFunction(gauge, handler, addressValue),
-		newStorageUsedGetFunction(handler, addressValue),
-		newStorageCapacityGetFunction(handler, addressValue),
+		newAccountBalanceGetFunction(gauge, hostLock, handler, addressValue),
+		newAccountAvailableBalanceGetFunction(gauge, hostLock, handler, addressValue),
+		newAccountGetBalanceFunction(gauge, hostLock, handler, addressValue),
+		newStorageUsedGetFunction(hostLock, handler, addressValue),
+		newStorageCapacityGetFunction(hostLock, handler, addressValue),
 		func() interpreter.Value {
 			return newPublicAccountKeysValue(gauge, handler, addressValue)
 		},
@@ -1606,17 +4943,25 @@ func NewAccountKeyValue(
 			inter,
 			getLocationRange,
 			accountKey.PublicKey,
+			sema.HashAlgorithmUnknown,
 			validatePublicKey,
 		),
 		NewHashAlgorithmCase(
-			interpreter.UInt8Value(accountKey.HashAlgo.RawValue()),
+			inter,
+			interpreter.NewUInt8Value(
+				inter,
+				func() uint8 {
+					return accountKey.HashAlgo.RawValue()
+				},
+			),
 		),
 		interpreter.NewUFix64ValueWithInteger(
 			inter, func() uint64 {
 				return uint64(accountKey.Weight)
 			},
 		),
-		interpreter.BoolValue(accountKey.IsRevoked),
+		interpreter.NewBoolValue(inter, accountKey.IsRevoked),
+		interpreter.NewBoolValue(inter, accountKey.Status == AccountKeyStatusActive),
 	)
 }
 
@@ -1629,7 +4974,17 @@ func NewPublicKeyFromValue(
 	error,
 ) {
 	// publicKey field
-	key := publicKey.GetMember(inter, getLocationRange, sema.PublicKeyPublicKeyField)
+	key, ok := publicKey.GetMember(inter, getLocationRange, sema.PublicKeyPublicKeyField).(*interpreter.ArrayValue)
+	if !ok {
+		return nil, errors.NewUnexpectedError("public key needs to be a byte array")
+	}
+
+	// Keys of all supported signature algorithms are at most as long as BLS keys.
+	// Whether a key is valid for its signature algorithm is checked by the host environment
+	err := RequireByteArrayLength(key, 0, blsPublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
 
 	byteArray, err := interpreter.ByteArrayValueToByteSlice(inter, key)
 	if err != nil {
@@ -1669,12 +5024,27 @@ func NewPublicKeyFromValue(
 	}, nil
 }
 
+// NewPublicKeyValue constructs a public key value from the given public key.
+//
+// If the hash algorithm that the key will be used with is known, it can be given as hashAlgo,
+// and the public key is rejected with a PublicKeyValidationError
+// if its signature algorithm cannot be used with the hash algorithm.
+// If hashAlgo is HashAlgorithmUnknown, no compatibility check is performed.
 func NewPublicKeyValue(
 	inter *interpreter.Interpreter,
 	getLocationRange func() interpreter.LocationRange,
 	publicKey *PublicKey,
+	hashAlgo sema.HashAlgorithm,
 	validatePublicKey interpreter.PublicKeyValidationHandlerFunc,
 ) *interpreter.CompositeValue {
+
+	if hashAlgo != sema.HashAlgorithmUnknown {
+		err := CheckSignatureHashAlgorithmCompatibility(publicKey.SignAlgo, hashAlgo)
+		if err != nil {
+			panic(NewPublicKeyValidationError(publicKey, err))
+		}
+	}
+
 	return interpreter.NewPublicKeyValue(
 		inter,
 		getLocationRange,
@@ -1683,7 +5053,13 @@ func NewPublicKeyValue(
 			publicKey.PublicKey,
 		),
 		NewSignatureAlgorithmCase(
-			interpreter.UInt8Value(publicKey.SignAlgo.RawValue()),
+			inter,
+			interpreter.NewUInt8Value(
+				inter,
+				func() uint8 {
+					return publicKey.SignAlgo.RawValue()
+				},
+			),
 		),
 		func(
 			inter *interpreter.Interpreter,
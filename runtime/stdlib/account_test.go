@@ -0,0 +1,631 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestClassifyContractCode(t *testing.T) {
+
+	t.Parallel()
+
+	parseAndCheck := func(t *testing.T, code string) *interpreter.Program {
+		program, err := parser.ParseProgram([]byte(code), nil)
+		require.NoError(t, err)
+
+		checker, err := sema.NewChecker(
+			program,
+			utils.TestLocation,
+			nil,
+			&sema.Config{
+				AccessCheckMode: sema.AccessCheckModeStrict,
+			},
+		)
+		require.NoError(t, err)
+
+		err = checker.Check()
+		require.NoError(t, err)
+
+		return interpreter.ProgramFromChecker(checker)
+	}
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parseAndCheck(t, `
+          pub contract Foo {}
+        `)
+
+		kind, name, err := ClassifyContractCode(program)
+		require.NoError(t, err)
+
+		assert.Equal(t, common.DeclarationKindContract, kind)
+		assert.Equal(t, "Foo", name)
+	})
+
+	t.Run("contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parseAndCheck(t, `
+          pub contract interface Foo {}
+        `)
+
+		kind, name, err := ClassifyContractCode(program)
+		require.NoError(t, err)
+
+		assert.Equal(t, common.DeclarationKindContractInterface, kind)
+		assert.Equal(t, "Foo", name)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parseAndCheck(t, ``)
+
+		_, _, err := ClassifyContractCode(program)
+		require.Error(t, err)
+	})
+
+	t.Run("multiple declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parseAndCheck(t, `
+          pub contract Foo {}
+
+          pub contract interface Bar {}
+        `)
+
+		_, _, err := ClassifyContractCode(program)
+		require.Error(t, err)
+	})
+
+	for _, testCase := range []struct {
+		name            string
+		code            string
+		declarationKind common.DeclarationKind
+	}{
+		{
+			name: "transaction",
+			code: `
+              pub contract Foo {}
+
+              transaction {}
+            `,
+			declarationKind: common.DeclarationKindTransaction,
+		},
+		{
+			name: "function",
+			code: `
+              pub fun foo() {}
+            `,
+			declarationKind: common.DeclarationKindFunction,
+		},
+		{
+			name: "struct",
+			code: `
+              pub struct Foo {}
+            `,
+			declarationKind: common.DeclarationKindStructure,
+		},
+	} {
+		testCase := testCase
+
+		t.Run(fmt.Sprintf("top-level %s", testCase.name), func(t *testing.T) {
+
+			t.Parallel()
+
+			program := parseAndCheck(t, testCase.code)
+
+			_, _, err := ClassifyContractCode(program)
+			require.Error(t, err)
+
+			var declarationErr *InvalidContractCodeTopLevelDeclarationError
+			require.ErrorAs(t, err, &declarationErr)
+
+			assert.Equal(t, testCase.declarationKind, declarationErr.DeclarationKind)
+			assert.ErrorContains(t, err, testCase.declarationKind.Name())
+		})
+	}
+}
+
+type testBalanceProvider func(address common.Address) (uint64, error)
+
+var _ BalanceProvider = testBalanceProvider(nil)
+
+func (f testBalanceProvider) GetAccountBalance(address common.Address) (uint64, error) {
+	return f(address)
+}
+
+type testPartialAuthAccountHandler struct {
+	BalanceProvider
+	AuthAccountKeysHandler
+	AvailableBalanceProvider
+	StorageUsedProvider
+	AccountEncodedKeyAdditionHandler
+	AccountEncodedKeyRevocationHandler
+	AuthAccountContractsHandler
+	AuthAccountCapabilitiesHandler
+}
+
+var _ AuthAccountHandler = &testPartialAuthAccountHandler{}
+
+func (*testPartialAuthAccountHandler) GetStorageCapacity(_ common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (*testPartialAuthAccountHandler) EmitEvent(
+	_ *interpreter.Interpreter,
+	_ *sema.CompositeType,
+	_ []interpreter.Value,
+	_ func() interpreter.LocationRange,
+) {
+	// NO-OP
+}
+
+// balanceProvider is an unexported alias, so embedding it results in an unexported field
+type balanceProvider = BalanceProvider
+
+type testUnexportedSubHandler struct {
+	balanceProvider
+}
+
+func TestNewGetAuthAccountFunctionMissingHandler(t *testing.T) {
+
+	t.Parallel()
+
+	assertPanicsWithMessage := func(t *testing.T, expected string, f func()) {
+		defer func() {
+			r := recover()
+			require.NotNil(t, r)
+
+			err, ok := r.(error)
+			require.True(t, ok)
+			require.IsType(t, errors.UnexpectedError{}, err)
+			assert.Contains(t, err.Error(), expected)
+		}()
+
+		f()
+	}
+
+	t.Run("nil handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		assertPanicsWithMessage(t,
+			"missing handler: AuthAccountHandler is nil",
+			func() {
+				NewGetAuthAccountFunction(nil)
+			},
+		)
+	})
+
+	t.Run("missing keys handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		handler := &testPartialAuthAccountHandler{
+			BalanceProvider: testBalanceProvider(func(_ common.Address) (uint64, error) {
+				return 0, nil
+			}),
+		}
+
+		assertPanicsWithMessage(t,
+			"missing handler: AuthAccountHandler.AuthAccountKeysHandler is nil",
+			func() {
+				NewGetAuthAccountFunction(handler)
+			},
+		)
+	})
+
+	t.Run("missing unexported handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		assertPanicsWithMessage(t,
+			"missing handler: Handler.balanceProvider is nil",
+			func() {
+				checkHandler(&testUnexportedSubHandler{}, "Handler")
+			},
+		)
+	})
+
+	t.Run("unexported handler", func(t *testing.T) {
+
+		t.Parallel()
+
+		handler := &testUnexportedSubHandler{
+			balanceProvider: testBalanceProvider(func(_ common.Address) (uint64, error) {
+				return 0, nil
+			}),
+		}
+
+		assert.NotPanics(t, func() {
+			checkHandler(handler, "Handler")
+		})
+	})
+}
+
+func TestDecodeEncodedAccountKey(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		// public key [1, 2, 3], signature algorithm ECDSA_secp256k1 (3),
+		// hash algorithm SHA3_256 (3), and weight 1000
+		publicKey, err := decodeEncodedAccountKey([]byte{
+			0xc9,
+			0x83, 0x1, 0x2, 0x3,
+			0x3,
+			0x3,
+			0x82, 0x3, 0xe8,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&PublicKey{
+				PublicKey: []byte{1, 2, 3},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_secp256k1,
+			},
+			publicKey,
+		)
+	})
+
+	t.Run("invalid field count", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := decodeEncodedAccountKey([]byte{
+			0xc5,
+			0x83, 0x1, 0x2, 0x3,
+			0x3,
+		})
+		require.EqualError(t, err, "invalid encoded account key: expected 4 fields, got 2")
+	})
+
+	t.Run("unsupported signature algorithm", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := decodeEncodedAccountKey([]byte{
+			0xc9,
+			0x83, 0x1, 0x2, 0x3,
+			0x4,
+			0x3,
+			0x82, 0x3, 0xe8,
+		})
+		require.EqualError(t, err, "invalid encoded account key: unsupported signature algorithm")
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := decodeEncodedAccountKey([]byte{
+			0xc9,
+			0x83, 0x1, 0x2, 0x3,
+			0x3,
+			0x3,
+			0x82, 0x3, 0xe8,
+			0x0,
+		})
+		require.EqualError(t, err, "invalid encoded account key: unexpected trailing bytes")
+	})
+
+	t.Run("not a list", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := decodeEncodedAccountKey([]byte{0x83, 0x1, 0x2, 0x3})
+		require.Error(t, err)
+	})
+}
+
+func TestPublicAccountValueConcurrentBalance(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	// Detect concurrent calls of the handler
+
+	var calls int32
+	var concurrentCalls int32
+
+	fixture := newPublicAccountFixture(address).
+		WithBalance(42, 0).
+		OnAccess(func(_ common.Address) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				atomic.AddInt32(&concurrentCalls, 1)
+			}
+			defer atomic.AddInt32(&calls, -1)
+
+			// Give other goroutines the chance to call concurrently
+			runtime.Gosched()
+		})
+
+	const goroutines = 16
+	const iterations = 100
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			// Each goroutine has its own account value,
+			// the calls into the handler are still serialized
+
+			accountValue := fixture.Value(nil).(*interpreter.SimpleCompositeValue)
+
+			for j := 0; j < iterations; j++ {
+				balance := accountValue.GetMember(nil, nil, sema.PublicAccountBalanceField)
+				assert.Equal(t, interpreter.UFix64Value(42), balance)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Zero(t, atomic.LoadInt32(&concurrentCalls))
+}
+
+func TestPublicAccountValueAddressAliasing(t *testing.T) {
+
+	t.Parallel()
+
+	var addresses []common.Address
+
+	fixture := newPublicAccountFixture(common.MustBytesToAddress([]byte{0x1})).
+		WithBalance(42, 0).
+		OnAccess(func(address common.Address) {
+			addresses = append(addresses, address)
+		})
+
+	addressValue := interpreter.NewUnmeteredAddressValueFromBytes([]byte{0x1})
+
+	accountValue := NewPublicAccountValue(
+		nil,
+		fixture,
+		addressValue,
+	).(*interpreter.SimpleCompositeValue)
+
+	// AddressValue is an array, so the account value and the host functions
+	// keep their own copies, and mutating the source value has no effect on them
+
+	addressValue[len(addressValue)-1] = 0x2
+
+	accountValue.GetMember(nil, nil, sema.PublicAccountBalanceField)
+
+	assert.Equal(t,
+		[]common.Address{
+			common.MustBytesToAddress([]byte{0x1}),
+		},
+		addresses,
+	)
+
+	assert.Equal(t,
+		interpreter.NewUnmeteredAddressValueFromBytes([]byte{0x1}),
+		accountValue.GetMember(nil, nil, sema.PublicAccountAddressField),
+	)
+}
+
+func TestNewPublicKeyValueHashAlgorithmCompatibility(t *testing.T) {
+
+	t.Parallel()
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		utils.TestLocation,
+		&interpreter.Config{
+			Storage: newUnmeteredInMemoryStorage(),
+		},
+	)
+	require.NoError(t, err)
+
+	validatePublicKey := func(
+		_ *interpreter.Interpreter,
+		_ func() interpreter.LocationRange,
+		_ *interpreter.CompositeValue,
+	) error {
+		return nil
+	}
+
+	newPublicKeyValue := func(hashAlgo sema.HashAlgorithm) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = r.(error)
+			}
+		}()
+
+		NewPublicKeyValue(
+			inter,
+			interpreter.ReturnEmptyLocationRange,
+			&PublicKey{
+				PublicKey: make([]byte, blsPublicKeyLength),
+				SignAlgo:  sema.SignatureAlgorithmBLS_BLS12_381,
+			},
+			hashAlgo,
+			validatePublicKey,
+		)
+
+		return nil
+	}
+
+	t.Run("incompatible", func(t *testing.T) {
+		err := newPublicKeyValue(sema.HashAlgorithmSHA3_256)
+		require.Error(t, err)
+
+		var validationErr *PublicKeyValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, sema.SignatureAlgorithmBLS_BLS12_381, validationErr.SignatureAlgorithm)
+		assert.Contains(t,
+			validationErr.Error(),
+			"signature algorithm BLS_BLS12_381 is not compatible with hash algorithm SHA3_256",
+		)
+	})
+
+	t.Run("compatible", func(t *testing.T) {
+		err := newPublicKeyValue(sema.HashAlgorithmKMAC128_BLS_BLS12_381)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		err := newPublicKeyValue(sema.HashAlgorithmUnknown)
+		require.NoError(t, err)
+	})
+}
+
+func TestPublicAccountFixture(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	fixture := newPublicAccountFixture(address).
+		WithBalance(10_00000000, 8_00000000).
+		WithStorage(100, 1000).
+		WithKey(AccountKey{
+			PublicKey: &PublicKey{
+				PublicKey: []byte{1, 2, 3},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+			},
+			HashAlgo:  sema.HashAlgorithmSHA3_256,
+			Weight:    1000,
+			IsRevoked: true,
+		}).
+		WithKey(AccountKey{
+			PublicKey: &PublicKey{
+				PublicKey: []byte{4, 5, 6},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_secp256k1,
+			},
+			HashAlgo: sema.HashAlgorithmSHA2_256,
+			Weight:   500,
+		}).
+		WithContract("Foo", []byte("pub contract Foo {}"))
+
+	t.Run("value", func(t *testing.T) {
+
+		t.Parallel()
+
+		accountValue := fixture.Value(nil).(*interpreter.SimpleCompositeValue)
+
+		assert.Equal(t,
+			interpreter.NewUnmeteredAddressValueFromBytes(address[:]),
+			accountValue.GetMember(nil, nil, sema.PublicAccountAddressField),
+		)
+		assert.Equal(t,
+			interpreter.UFix64Value(10_00000000),
+			accountValue.GetMember(nil, nil, sema.PublicAccountBalanceField),
+		)
+		assert.Equal(t,
+			interpreter.UFix64Value(8_00000000),
+			accountValue.GetMember(nil, nil, sema.PublicAccountAvailableBalanceField),
+		)
+	})
+
+	t.Run("program", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := testInterpreter(t,
+			`
+              pub let account = getAccount(0x1)
+              pub let storageUsed = account.storageUsed
+              pub let storageCapacity = account.storageCapacity
+              pub let key = account.keys.get(keyIndex: 1)!
+              pub let revoked = account.keys.get(keyIndex: 0)!.isRevoked
+              pub let missingKey = account.keys.get(keyIndex: 2)
+              pub let totalWeight = account.keys.totalWeight()
+              pub let contractNames = account.contracts.names
+              pub let codeLength = account.contracts.get(name: "Foo")!.code.length
+              pub let missingContract = account.contracts.get(name: "Bar")
+            `,
+			NewGetAccountFunction(fixture),
+		)
+
+		getGlobal := func(name string) interpreter.Value {
+			variable, ok := inter.Globals.Get(name)
+			require.True(t, ok)
+			return variable.GetValue()
+		}
+
+		assert.Equal(t, interpreter.NewUnmeteredUInt64Value(100), getGlobal("storageUsed"))
+		assert.Equal(t, interpreter.NewUnmeteredUInt64Value(1000), getGlobal("storageCapacity"))
+
+		key := getGlobal("key").(*interpreter.SimpleCompositeValue)
+		assert.Equal(t,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			key.GetMember(inter, nil, sema.AccountKeyKeyIndexField),
+		)
+		assert.Equal(t,
+			interpreter.NewUnmeteredUFix64ValueWithInteger(500),
+			key.GetMember(inter, nil, sema.AccountKeyWeightField),
+		)
+
+		assert.Equal(t, interpreter.BoolValue(true), getGlobal("revoked"))
+		assert.Equal(t, interpreter.NilValue{}, getGlobal("missingKey"))
+
+		// The revoked key is not counted
+		assert.Equal(t, interpreter.NewUnmeteredUFix64ValueWithInteger(500), getGlobal("totalWeight"))
+
+		contractNames := getGlobal("contractNames").(*interpreter.ArrayValue)
+		require.Equal(t, 1, contractNames.Count())
+		assert.Equal(t,
+			interpreter.NewUnmeteredStringValue("Foo"),
+			contractNames.Get(inter, nil, 0),
+		)
+
+		assert.Equal(t,
+			interpreter.NewUnmeteredIntValueFromInt64(int64(len("pub contract Foo {}"))),
+			getGlobal("codeLength"),
+		)
+		assert.Equal(t, interpreter.NilValue{}, getGlobal("missingContract"))
+	})
+
+	t.Run("other account", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := fixture.GetAccountBalance(common.MustBytesToAddress([]byte{0x2}))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// publicAccountFixture is a PublicAccountHandler which is backed by in-memory fixtures.
+//
+// It allows tests to construct PublicAccount values with a given balance, keys, and contracts,
+// without a host environment. The fixture only provides the account at its address,
+// queries for other accounts fail.
+// Contracts only have code, they are not instantiated and their types cannot be loaded.
+//
+type publicAccountFixture struct {
+	onAccess         func(address common.Address)
+	address          common.Address
+	balance          uint64
+	availableBalance uint64
+	storageUsed      uint64
+	storageCapacity  uint64
+	keys             []*AccountKey
+	contractNames    []string
+	contractCodes    map[string][]byte
+	hostCallLock     sync.Mutex
+}
+
+var _ PublicAccountHandler = &publicAccountFixture{}
+
+func newPublicAccountFixture(address common.Address) *publicAccountFixture {
+	return &publicAccountFixture{
+		address:       address,
+		contractCodes: map[string][]byte{},
+	}
+}
+
+// WithBalance sets the balance and the available balance of the account.
+func (f *publicAccountFixture) WithBalance(balance, availableBalance uint64) *publicAccountFixture {
+	f.balance = balance
+	f.availableBalance = availableBalance
+	return f
+}
+
+// WithStorage sets the storage used and the storage capacity of the account.
+func (f *publicAccountFixture) WithStorage(used, capacity uint64) *publicAccountFixture {
+	f.storageUsed = used
+	f.storageCapacity = capacity
+	return f
+}
+
+// WithKey adds the given key to the account.
+// The key index is assigned sequentially, the index of the given key is ignored.
+func (f *publicAccountFixture) WithKey(key AccountKey) *publicAccountFixture {
+	key.KeyIndex = len(f.keys)
+	f.keys = append(f.keys, &key)
+	return f
+}
+
+// WithContract adds a contract with the given name and code to the account,
+// or replaces the code of an existing contract with the given name.
+func (f *publicAccountFixture) WithContract(name string, code []byte) *publicAccountFixture {
+	if _, ok := f.contractCodes[name]; !ok {
+		f.contractNames = append(f.contractNames, name)
+	}
+	f.contractCodes[name] = code
+	return f
+}
+
+// OnAccess sets the function which is called with the queried address
+// whenever the handler is called.
+func (f *publicAccountFixture) OnAccess(onAccess func(address common.Address)) *publicAccountFixture {
+	f.onAccess = onAccess
+	return f
+}
+
+// Value returns a new PublicAccount value for the account of the fixture.
+func (f *publicAccountFixture) Value(gauge common.MemoryGauge) interpreter.Value {
+	return NewPublicAccountValue(
+		gauge,
+		f,
+		interpreter.NewAddressValue(gauge, f.address),
+	)
+}
+
+func (f *publicAccountFixture) checkAddress(address common.Address) error {
+	if f.onAccess != nil {
+		f.onAccess(address)
+	}
+	if address != f.address {
+		return errors.NewUnexpectedError("no fixture for account %s", address)
+	}
+	return nil
+}
+
+func (f *publicAccountFixture) HostCallLock() sync.Locker {
+	return &f.hostCallLock
+}
+
+func (f *publicAccountFixture) GetAccountBalance(address common.Address) (uint64, error) {
+	if err := f.checkAddress(address); err != nil {
+		return 0, err
+	}
+	return f.balance, nil
+}
+
+func (f *publicAccountFixture) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	if err := f.checkAddress(address); err != nil {
+		return 0, err
+	}
+	return f.availableBalance, nil
+}
+
+func (*publicAccountFixture) CommitStorageTemporarily(_ *interpreter.Interpreter, _ func() interpreter.LocationRange) error {
+	return nil
+}
+
+func (f *publicAccountFixture) GetStorageUsed(address common.Address) (uint64, error) {
+	if err := f.checkAddress(address); err != nil {
+		return 0, err
+	}
+	return f.storageUsed, nil
+}
+
+func (f *publicAccountFixture) GetStorageCapacity(address common.Address) (uint64, error) {
+	if err := f.checkAddress(address); err != nil {
+		return 0, err
+	}
+	return f.storageCapacity, nil
+}
+
+func (f *publicAccountFixture) GetAccountKey(address common.Address, index int) (*AccountKey, error) {
+	if err := f.checkAddress(address); err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(f.keys) {
+		return nil, nil
+	}
+	return f.keys[index], nil
+}
+
+func (f *publicAccountFixture) GetAccountKeysTotalWeight(address common.Address) (uint64, error) {
+	if err := f.checkAddress(address); err != nil {
+		return 0, err
+	}
+	var totalWeight uint64
+	for _, key := range f.keys {
+		if key.IsRevoked {
+			continue
+		}
+		totalWeight += uint64(key.Weight)
+	}
+	return totalWeight, nil
+}
+
+func (f *publicAccountFixture) GetAccountContractNames(address common.Address) ([]string, error) {
+	if err := f.checkAddress(address); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(f.contractNames))
+	copy(names, f.contractNames)
+	return names, nil
+}
+
+func (f *publicAccountFixture) GetAccountContractCode(address common.Address, name string) ([]byte, error) {
+	if err := f.checkAddress(address); err != nil {
+		return nil, err
+	}
+	return f.contractCodes[name], nil
+}
+
+func (f *publicAccountFixture) GetAccountContractValue(
+	_ *interpreter.Interpreter,
+	address common.Address,
+	_ string,
+) (*interpreter.CompositeValue, error) {
+	if err := f.checkAddress(address); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*publicAccountFixture) ParseAndCheckProgram(
+	_ []byte,
+	location common.Location,
+	_ bool,
+) (*interpreter.Program, error) {
+	return nil, errors.NewUnexpectedError("cannot load program of fixture contract %s", location)
+}
@@ -0,0 +1,93 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+const getAddressForPublicKeyFunctionDocString = `
+Returns the address of the account which is derived from the given public key.
+
+Returns nil if the chain does not derive account addresses from public keys.
+`
+
+var getAddressForPublicKeyFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "publicKey",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.PublicKeyType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: &sema.AddressType{},
+		},
+	),
+}
+
+type AddressFromPublicKeyProvider interface {
+	// GetAddressForPublicKey returns the address of the account derived from the given public key.
+	// The boolean result is false if the chain does not derive addresses from public keys.
+	GetAddressForPublicKey(publicKey *PublicKey) (common.Address, bool, error)
+}
+
+func NewGetAddressForPublicKeyFunction(provider AddressFromPublicKeyProvider) StandardLibraryValue {
+	return NewStandardLibraryFunction(
+		"getAddressForPublicKey",
+		getAddressForPublicKeyFunctionType,
+		getAddressForPublicKeyFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			publicKeyValue, ok := invocation.Arguments[0].(interpreter.MemberAccessibleValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			publicKey, err := NewPublicKeyFromValue(inter, invocation.GetLocationRange, publicKeyValue)
+			if err != nil {
+				panic(err)
+			}
+
+			var address common.Address
+			var supported bool
+			wrapPanic(func() {
+				address, supported, err = provider.GetAddressForPublicKey(publicKey)
+			})
+			if err != nil {
+				panic(mapHostError(provider, err))
+			}
+
+			if !supported {
+				return interpreter.NewNilValue(inter)
+			}
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				interpreter.NewAddressValue(inter, address),
+			)
+		},
+	)
+}
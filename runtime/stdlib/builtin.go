@@ -33,4 +33,5 @@ var BuiltinValues = []StandardLibraryValue{
 	//   instead of relying on callback on interpreter
 	//   (BLSVerifyPoPHandler, BLSAggregateSignaturesHandler, BLSAggregatePublicKeysHandler)
 	BLSContract,
+	DomainTagsContract,
 }
@@ -0,0 +1,64 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// RequireByteArrayLength returns an error if the length of the given byte array
+// is not in the inclusive range [min, max].
+// A negative max means that the length is not bounded above.
+// For an exact length, pass the same value for min and max.
+func RequireByteArrayLength(value *interpreter.ArrayValue, min, max int) error {
+	length := value.Count()
+
+	switch {
+	case min == max:
+		if length != min {
+			return errors.NewDefaultUserError(
+				"invalid byte array length: expected %d, got %d",
+				min,
+				length,
+			)
+		}
+
+	case max < 0:
+		if length < min {
+			return errors.NewDefaultUserError(
+				"invalid byte array length: expected at least %d, got %d",
+				min,
+				length,
+			)
+		}
+
+	default:
+		if length < min || length > max {
+			return errors.NewDefaultUserError(
+				"invalid byte array length: expected between %d and %d, got %d",
+				min,
+				max,
+				length,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRequireByteArrayLength(t *testing.T) {
+
+	t.Parallel()
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		utils.TestLocation,
+		&interpreter.Config{
+			Storage: newUnmeteredInMemoryStorage(),
+		},
+	)
+	require.NoError(t, err)
+
+	newByteArray := func(length int) *interpreter.ArrayValue {
+		return interpreter.ByteSliceToByteArrayValue(inter, make([]byte, length))
+	}
+
+	type testCase struct {
+		name   string
+		length int
+		min    int
+		max    int
+		err    string
+	}
+
+	for _, test := range []testCase{
+		{
+			name:   "exact, in range",
+			length: 32,
+			min:    32,
+			max:    32,
+		},
+		{
+			name:   "exact, under",
+			length: 31,
+			min:    32,
+			max:    32,
+			err:    "invalid byte array length: expected 32, got 31",
+		},
+		{
+			name:   "exact, over",
+			length: 33,
+			min:    32,
+			max:    32,
+			err:    "invalid byte array length: expected 32, got 33",
+		},
+		{
+			name:   "bounded, in range",
+			length: 2,
+			min:    1,
+			max:    3,
+		},
+		{
+			name:   "bounded, under",
+			length: 0,
+			min:    1,
+			max:    3,
+			err:    "invalid byte array length: expected between 1 and 3, got 0",
+		},
+		{
+			name:   "bounded, over",
+			length: 4,
+			min:    1,
+			max:    3,
+			err:    "invalid byte array length: expected between 1 and 3, got 4",
+		},
+		{
+			name:   "unbounded, in range",
+			length: 100,
+			min:    1,
+			max:    -1,
+		},
+		{
+			name:   "unbounded, under",
+			length: 0,
+			min:    1,
+			max:    -1,
+			err:    "invalid byte array length: expected at least 1, got 0",
+		},
+	} {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			err := RequireByteArrayLength(newByteArray(test.length), test.min, test.max)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+const getRemainingComputationFunctionDocString = `
+Returns the computation that can still be used until the computation limit is reached,
+accounting for the computation already used, including the invocation of this function.
+
+Returns zero if the limit is reached
+`
+
+var getRemainingComputationFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.UInt64Type,
+	),
+}
+
+type ComputationBudgetProvider interface {
+	// GetComputationLimit returns the computation limit of the current transaction or script.
+	GetComputationLimit() (uint64, error)
+	// GetComputationUsed returns the computation used so far by the current transaction or script.
+	GetComputationUsed() (uint64, error)
+}
+
+func NewGetRemainingComputationFunction(provider ComputationBudgetProvider) StandardLibraryValue {
+	return NewStandardLibraryFunction(
+		"getRemainingComputation",
+		getRemainingComputationFunctionType,
+		getRemainingComputationFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			return interpreter.NewUInt64Value(
+				invocation.Interpreter,
+				func() uint64 {
+					var limit, used uint64
+					var err error
+					wrapPanic(func() {
+						limit, err = provider.GetComputationLimit()
+						if err != nil {
+							return
+						}
+						used, err = provider.GetComputationUsed()
+					})
+					if err != nil {
+						panic(mapHostError(provider, err))
+					}
+
+					if used >= limit {
+						return 0
+					}
+					return limit - used
+				},
+			)
+		},
+	)
+}
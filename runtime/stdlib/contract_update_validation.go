@@ -392,6 +392,41 @@ func containsEnums(declaration ast.Declaration) bool {
 	return false
 }
 
+// enumQualifiedIdentifiersInProgram returns the qualified identifiers
+// of all enums declared in the contract or contract interface of the given program.
+func enumQualifiedIdentifiersInProgram(program *ast.Program) []string {
+	declaration, err := getRootDeclaration(program)
+
+	if err != nil {
+		return nil
+	}
+
+	return enumQualifiedIdentifiers(declaration, "")
+}
+
+func enumQualifiedIdentifiers(declaration ast.Declaration, prefix string) []string {
+	qualifiedIdentifier := declaration.DeclarationIdentifier().Identifier
+	if prefix != "" {
+		qualifiedIdentifier = prefix + "." + qualifiedIdentifier
+	}
+
+	var result []string
+
+	if declaration.DeclarationKind() == common.DeclarationKindEnum {
+		result = append(result, qualifiedIdentifier)
+	}
+
+	nestedCompositeDecls := declaration.DeclarationMembers().Composites()
+	for _, nestedDecl := range nestedCompositeDecls {
+		result = append(
+			result,
+			enumQualifiedIdentifiers(nestedDecl, qualifiedIdentifier)...,
+		)
+	}
+
+	return result
+}
+
 // Contract update related errors
 
 // ContractUpdateError is reported upon any invalid update to a contract or contract interface.
@@ -132,7 +132,7 @@ func cryptoAlgorithmEnumConstructorType(
 	return constructorType
 }
 
-type enumCaseConstructor func(rawValue interpreter.UInt8Value) interpreter.MemberAccessibleValue
+type enumCaseConstructor func(gauge common.MemoryGauge, rawValue interpreter.UInt8Value) interpreter.MemberAccessibleValue
 
 func cryptoAlgorithmEnumValueAndCaseValues(
 	enumType *sema.CompositeType,
@@ -150,7 +150,8 @@ func cryptoAlgorithmEnumValueAndCaseValues(
 
 	for i, enumCase := range enumCases {
 		rawValue := interpreter.UInt8Value(enumCase.RawValue())
-		caseValue := caseConstructor(rawValue)
+		// NOTE: the case values are shared, they are not metered
+		caseValue := caseConstructor(nil, rawValue)
 		cases[rawValue] = caseValue
 		caseValues[i] = interpreter.EnumCase{
 			Value:    caseValue,
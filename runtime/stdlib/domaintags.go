@@ -0,0 +1,97 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// UserDomainSeparationTag is the domain separation tag for signatures of user data,
+// e.g. signatures verified using `PublicKey.verify`.
+// The host environment pads the tag to the length it expects
+const UserDomainSeparationTag = "FLOW-V0.0-user"
+
+var domainTagsContractType = func() *sema.CompositeType {
+	ty := &sema.CompositeType{
+		Identifier: "DomainTags",
+		Kind:       common.CompositeKindContract,
+	}
+
+	ty.Members = sema.GetMembersAsMap([]*sema.Member{
+		sema.NewUnmeteredPublicConstantFieldMember(
+			ty,
+			domainTagsUserFieldName,
+			sema.StringType,
+			domainTagsUserFieldDocString,
+		),
+	})
+	return ty
+}()
+
+var domainTagsContractTypeID = domainTagsContractType.ID()
+var domainTagsContractStaticType interpreter.StaticType = interpreter.CompositeStaticType{
+	QualifiedIdentifier: domainTagsContractType.Identifier,
+	TypeID:              domainTagsContractTypeID,
+}
+
+const domainTagsUserFieldName = "user"
+
+const domainTagsUserFieldDocString = `
+The domain separation tag for signatures of user data.
+
+Use this tag when verifying signatures using ` + "`PublicKey.verify`" + `.
+`
+
+func newDomainTagStringValue(inter *interpreter.Interpreter, tag string) *interpreter.StringValue {
+	return interpreter.NewStringValue(
+		inter,
+		common.NewStringMemoryUsage(len(tag)),
+		func() string {
+			return tag
+		},
+	)
+}
+
+// The tags are computed fields, as the contract value is shared by all interpreters,
+// and string values are not safe for concurrent use
+var domainTagsContractValue = interpreter.NewSimpleCompositeValue(
+	nil,
+	domainTagsContractTypeID,
+	domainTagsContractStaticType,
+	nil,
+	nil,
+	func(name string, inter *interpreter.Interpreter, _ func() interpreter.LocationRange) interpreter.Value {
+		switch name {
+		case domainTagsUserFieldName:
+			return newDomainTagStringValue(inter, UserDomainSeparationTag)
+		}
+		return nil
+	},
+	nil,
+	nil,
+)
+
+var DomainTagsContract = StandardLibraryValue{
+	Name:  "DomainTags",
+	Type:  domainTagsContractType,
+	Value: domainTagsContractValue,
+	Kind:  common.DeclarationKindContract,
+}
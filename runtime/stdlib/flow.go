@@ -176,6 +176,11 @@ var AccountEventAddressParameter = &sema.Parameter{
 	TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
 }
 
+var AccountEventPayerParameter = &sema.Parameter{
+	Identifier:     "payer",
+	TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+}
+
 var AccountEventCodeHashParameter = &sema.Parameter{
 	Identifier:     "codeHash",
 	TypeAnnotation: sema.NewTypeAnnotation(HashType),
@@ -193,9 +198,20 @@ var AccountEventContractParameter = &sema.Parameter{
 	TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
 }
 
+var AccountEventOldCapacityParameter = &sema.Parameter{
+	Identifier:     "oldCapacity",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+}
+
+var AccountEventNewCapacityParameter = &sema.Parameter{
+	Identifier:     "newCapacity",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+}
+
 var AccountCreatedEventType = newFlowEventType(
 	"AccountCreated",
 	AccountEventAddressParameter,
+	AccountEventPayerParameter,
 )
 
 var AccountKeyAddedEventType = newFlowEventType(
@@ -210,6 +226,23 @@ var AccountKeyRemovedEventType = newFlowEventType(
 	AccountEventPublicKeyParameter,
 )
 
+var AccountEventOldKeyIndexParameter = &sema.Parameter{
+	Identifier:     "oldKeyIndex",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+}
+
+var AccountEventNewKeyIndexParameter = &sema.Parameter{
+	Identifier:     "newKeyIndex",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+}
+
+var AccountKeyRotatedEventType = newFlowEventType(
+	"AccountKeyRotated",
+	AccountEventAddressParameter,
+	AccountEventOldKeyIndexParameter,
+	AccountEventNewKeyIndexParameter,
+)
+
 var AccountContractAddedEventType = newFlowEventType(
 	"AccountContractAdded",
 	AccountEventAddressParameter,
@@ -230,3 +263,63 @@ var AccountContractRemovedEventType = newFlowEventType(
 	AccountEventCodeHashParameter,
 	AccountEventContractParameter,
 )
+
+var AccountEventFeeAmountParameter = &sema.Parameter{
+	Identifier:     "amount",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+}
+
+var ContractDeploymentFeeDeductedEventType = newFlowEventType(
+	"ContractDeploymentFeeDeducted",
+	AccountEventAddressParameter,
+	AccountEventContractParameter,
+	AccountEventFeeAmountParameter,
+)
+
+var AccountStorageCapacityChangedEventType = newFlowEventType(
+	"AccountStorageCapacityChanged",
+	AccountEventAddressParameter,
+	AccountEventOldCapacityParameter,
+	AccountEventNewCapacityParameter,
+)
+
+var InboxEventProviderParameter = &sema.Parameter{
+	Identifier:     "provider",
+	TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+}
+
+var InboxEventRecipientParameter = &sema.Parameter{
+	Identifier:     "recipient",
+	TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+}
+
+var InboxEventNameParameter = &sema.Parameter{
+	Identifier:     "name",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+}
+
+var InboxEventTypeParameter = &sema.Parameter{
+	Identifier:     "type",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.MetaType),
+}
+
+var InboxValuePublishedEventType = newFlowEventType(
+	"InboxValuePublished",
+	InboxEventProviderParameter,
+	InboxEventRecipientParameter,
+	InboxEventNameParameter,
+	InboxEventTypeParameter,
+)
+
+var InboxValueUnpublishedEventType = newFlowEventType(
+	"InboxValueUnpublished",
+	InboxEventProviderParameter,
+	InboxEventNameParameter,
+)
+
+var InboxValueClaimedEventType = newFlowEventType(
+	"InboxValueClaimed",
+	InboxEventProviderParameter,
+	InboxEventRecipientParameter,
+	InboxEventNameParameter,
+)
@@ -36,9 +36,14 @@ func TestFlowEventTypeIDs(t *testing.T) {
 		AccountCreatedEventType,
 		AccountKeyAddedEventType,
 		AccountKeyRemovedEventType,
+		AccountKeyRotatedEventType,
 		AccountContractAddedEventType,
 		AccountContractUpdatedEventType,
 		AccountContractRemovedEventType,
+		AccountStorageCapacityChangedEventType,
+		InboxValuePublishedEventType,
+		InboxValueUnpublishedEventType,
+		InboxValueClaimedEventType,
 	} {
 		assert.True(t, strings.HasPrefix(string(ty.ID()), "flow"))
 	}
@@ -0,0 +1,77 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// checkHandler ensures that the given handler provides all capabilities,
+// i.e. that it is not nil, and that none of the interfaces embedded in it are nil.
+//
+// Handlers are often composed of structs which embed sub-handlers.
+// A missing sub-handler would otherwise only result in a nil pointer dereference
+// when a function which uses it is called.
+//
+// The check uses reflection, so it is only performed when the standard library functions
+// are constructed, and not each time a value is constructed using the handler.
+//
+func checkHandler(handler any, name string) {
+	if handler == nil {
+		panic(errors.NewUnexpectedError("missing handler: %s is nil", name))
+	}
+
+	checkHandlerValue(reflect.ValueOf(handler), name)
+}
+
+// checkHandlerValue checks the given handler value, see checkHandler.
+// It only inspects the value, and never converts it back to an interface,
+// which is not allowed for values of unexported fields
+//
+func checkHandlerValue(value reflect.Value, name string) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			panic(errors.NewUnexpectedError("missing handler: %s is nil", name))
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	ty := value.Type()
+	for i := 0; i < ty.NumField(); i++ {
+		field := ty.Field(i)
+		if !field.Anonymous || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		fieldName := name + "." + field.Name
+
+		fieldValue := value.Field(i)
+		if fieldValue.IsNil() {
+			panic(errors.NewUnexpectedError("missing handler: %s is nil", fieldName))
+		}
+
+		checkHandlerValue(fieldValue.Elem(), fieldName)
+	}
+}
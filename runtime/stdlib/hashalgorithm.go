@@ -31,10 +31,13 @@ var hashAlgorithmStaticType interpreter.StaticType = interpreter.CompositeStatic
 	TypeID:              hashAlgorithmTypeID,
 }
 
-func NewHashAlgorithmCase(rawValue interpreter.UInt8Value) interpreter.MemberAccessibleValue {
+func NewHashAlgorithmCase(
+	gauge common.MemoryGauge,
+	rawValue interpreter.UInt8Value,
+) interpreter.MemberAccessibleValue {
 
 	value := interpreter.NewSimpleCompositeValue(
-		nil,
+		gauge,
 		sema.HashAlgorithmType.ID(),
 		hashAlgorithmStaticType,
 		[]string{sema.EnumRawValueFieldName},
@@ -44,15 +47,25 @@ func NewHashAlgorithmCase(rawValue interpreter.UInt8Value) interpreter.MemberAcc
 		nil,
 	)
 	value.Fields = map[string]interpreter.Value{
-		sema.EnumRawValueFieldName:                    rawValue,
-		sema.HashAlgorithmTypeHashFunctionName:        hashAlgorithmHashFunction(value),
-		sema.HashAlgorithmTypeHashWithTagFunctionName: hashAlgorithmHashWithTagFunction(value),
+		sema.EnumRawValueFieldName:                     rawValue,
+		sema.HashAlgorithmTypeHashFunctionName:         hashAlgorithmHashFunction(gauge, value),
+		sema.HashAlgorithmTypeHashWithTagFunctionName:  hashAlgorithmHashWithTagFunction(gauge, value),
+		sema.HashAlgorithmTypeHashWithSaltFunctionName: hashAlgorithmHashWithSaltFunction(gauge, value),
 	}
+
+	// The fields can only be set after the value was constructed,
+	// as the functions refer to the value, so meter them separately
+	common.UseMemory(gauge, common.NewSimpleCompositeMemoryUsage(len(value.Fields)))
+
 	return value
 }
 
-func hashAlgorithmHashFunction(hashAlgoValue interpreter.MemberAccessibleValue) *interpreter.HostFunctionValue {
-	return interpreter.NewUnmeteredHostFunctionValue(
+func hashAlgorithmHashFunction(
+	gauge common.MemoryGauge,
+	hashAlgoValue interpreter.MemberAccessibleValue,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
 			dataValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
 			if !ok {
@@ -75,8 +88,12 @@ func hashAlgorithmHashFunction(hashAlgoValue interpreter.MemberAccessibleValue)
 	)
 }
 
-func hashAlgorithmHashWithTagFunction(hashAlgoValue interpreter.MemberAccessibleValue) *interpreter.HostFunctionValue {
-	return interpreter.NewUnmeteredHostFunctionValue(
+func hashAlgorithmHashWithTagFunction(
+	gauge common.MemoryGauge,
+	hashAlgoValue interpreter.MemberAccessibleValue,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		gauge,
 		func(invocation interpreter.Invocation) interpreter.Value {
 			dataValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
 			if !ok {
@@ -104,6 +121,71 @@ func hashAlgorithmHashWithTagFunction(hashAlgoValue interpreter.MemberAccessible
 	)
 }
 
+// Salts must be long enough to make precomputation infeasible,
+// e.g. in commit-reveal schemes
+const hashSaltMinLength = 16
+const hashSaltMaxLength = 64
+
+// hashAlgorithmHashWithSaltFunction returns a function which hashes the given data,
+// prefixed with the given salt, i.e. the hashed message is `salt || data`.
+// The salted message is hashed without a tag, so the result is the same as for `hash(salt.concat(data))`
+func hashAlgorithmHashWithSaltFunction(
+	gauge common.MemoryGauge,
+	hashAlgoValue interpreter.MemberAccessibleValue,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		gauge,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			dataValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			saltValue, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			getLocationRange := invocation.GetLocationRange
+
+			err := RequireByteArrayLength(saltValue, hashSaltMinLength, hashSaltMaxLength)
+			if err != nil {
+				panic(err)
+			}
+
+			inter.ReportComputation(
+				common.ComputationKindSTDLIBHashWithSalt,
+				uint(saltValue.Count()+dataValue.Count()),
+			)
+
+			salt, err := interpreter.ByteArrayValueToByteSlice(inter, saltValue)
+			if err != nil {
+				panic(errors.NewUnexpectedError("failed to get salt. %w", err))
+			}
+
+			data, err := interpreter.ByteArrayValueToByteSlice(inter, dataValue)
+			if err != nil {
+				panic(errors.NewUnexpectedError("failed to get data. %w", err))
+			}
+
+			saltedData := make([]byte, 0, len(salt)+len(data))
+			saltedData = append(saltedData, salt...)
+			saltedData = append(saltedData, data...)
+
+			return inter.Config.HashHandler(
+				inter,
+				getLocationRange,
+				interpreter.ByteSliceToByteArrayValue(inter, saltedData),
+				nil,
+				hashAlgoValue,
+			)
+		},
+		sema.HashAlgorithmTypeHashWithSaltFunctionType,
+	)
+}
+
 var hashAlgorithmConstructorValue, HashAlgorithmCaseValues = cryptoAlgorithmEnumValueAndCaseValues(
 	sema.HashAlgorithmType,
 	sema.HashAlgorithms,
@@ -19,6 +19,12 @@
 package stdlib
 
 import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
@@ -40,14 +46,89 @@ var publicKeyConstructorFunctionType = &sema.FunctionType{
 		},
 	},
 	ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.PublicKeyType),
+	Members: sema.GetMembersAsMap([]*sema.Member{
+		sema.NewUnmeteredPublicFunctionMember(
+			sema.PublicKeyType,
+			publicKeyFromPEMFunctionName,
+			publicKeyFromPEMFunctionType,
+			publicKeyFromPEMFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			sema.PublicKeyType,
+			publicKeyFromDERFunctionName,
+			publicKeyFromDERFunctionType,
+			publicKeyFromDERFunctionDocString,
+		),
+	}),
+}
+
+var PublicKeyConstructor = func() StandardLibraryValue {
+	value := NewStandardLibraryFunction(
+		sema.PublicKeyTypeName,
+		publicKeyConstructorFunctionType,
+		publicKeyConstructorFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			publicKey, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			signAlgo, ok := invocation.Arguments[1].(*interpreter.SimpleCompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			return interpreter.NewPublicKeyValue(
+				inter,
+				invocation.GetLocationRange,
+				publicKey,
+				signAlgo,
+				inter.Config.PublicKeyValidationHandler,
+			)
+		},
+	)
+
+	constructor := value.Value.(*interpreter.HostFunctionValue)
+	constructor.NestedVariables = map[string]*interpreter.Variable{
+		publicKeyFromPEMFunctionName: interpreter.NewVariableWithValue(nil, publicKeyFromPEMFunction),
+		publicKeyFromDERFunctionName: interpreter.NewVariableWithValue(nil, publicKeyFromDERFunction),
+	}
+
+	return value
+}()
+
+const publicKeyFromPEMFunctionName = "fromPEM"
+
+const publicKeyFromPEMFunctionDocString = `
+Constructs a new public key from the given PEM encoded public key.
+
+Returns nil if the given string is not a valid PEM encoded public key for the given signature algorithm
+`
+
+var publicKeyFromPEMFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "pem",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+		{
+			Identifier:     "signatureAlgorithm",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.SignatureAlgorithmType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: sema.PublicKeyType,
+		},
+	),
 }
 
-var PublicKeyConstructor = NewStandardLibraryFunction(
-	sema.PublicKeyTypeName,
-	publicKeyConstructorFunctionType,
-	publicKeyConstructorFunctionDocString,
+var publicKeyFromPEMFunction = interpreter.NewUnmeteredHostFunctionValue(
 	func(invocation interpreter.Invocation) interpreter.Value {
-		publicKey, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		pemValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 		if !ok {
 			panic(errors.NewUnreachableError())
 		}
@@ -57,14 +138,231 @@ var PublicKeyConstructor = NewStandardLibraryFunction(
 			panic(errors.NewUnreachableError())
 		}
 
-		inter := invocation.Interpreter
+		block, rest := pem.Decode([]byte(pemValue.Str))
+		if block == nil || len(bytes.TrimSpace(rest)) > 0 {
+			return interpreter.NewNilValue(invocation.Interpreter)
+		}
+
+		return newPublicKeyValueFromDER(invocation, block.Bytes, signAlgo)
+	},
+	publicKeyFromPEMFunctionType,
+)
+
+const publicKeyFromDERFunctionName = "fromDER"
+
+const publicKeyFromDERFunctionDocString = `
+Constructs a new public key from the given DER encoded public key.
+
+Returns nil if the given bytes are not a valid DER encoded public key for the given signature algorithm
+`
+
+var publicKeyFromDERFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "der",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+		},
+		{
+			Identifier:     "signatureAlgorithm",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.SignatureAlgorithmType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: sema.PublicKeyType,
+		},
+	),
+}
+
+var publicKeyFromDERFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		derValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		signAlgo, ok := invocation.Arguments[1].(*interpreter.SimpleCompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
 
-		return interpreter.NewPublicKeyValue(
+		der, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, derValue)
+		if err != nil {
+			panic(errors.NewUnreachableError())
+		}
+
+		return newPublicKeyValueFromDER(invocation, der, signAlgo)
+	},
+	publicKeyFromDERFunctionType,
+)
+
+// newPublicKeyValueFromDER constructs a public key value from the given DER encoded public key,
+// or returns nil if the public key cannot be parsed for the given signature algorithm
+func newPublicKeyValueFromDER(
+	invocation interpreter.Invocation,
+	der []byte,
+	signAlgo *interpreter.SimpleCompositeValue,
+) interpreter.Value {
+
+	inter := invocation.Interpreter
+	getLocationRange := invocation.GetLocationRange
+
+	rawValue, ok := signAlgo.GetMember(inter, getLocationRange, sema.EnumRawValueFieldName).(interpreter.UInt8Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	publicKey, err := ParsePublicKeyDER(der, sema.SignatureAlgorithm(rawValue))
+	if err != nil {
+		return interpreter.NewNilValue(inter)
+	}
+
+	return interpreter.NewSomeValueNonCopying(
+		inter,
+		interpreter.NewPublicKeyValue(
 			inter,
-			invocation.GetLocationRange,
-			publicKey,
+			getLocationRange,
+			interpreter.ByteSliceToByteArrayValue(inter, publicKey),
 			signAlgo,
 			inter.Config.PublicKeyValidationHandler,
-		)
-	},
+		),
+	)
+}
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
 )
+
+const ecdsaPublicKeyLength = 64
+const blsPublicKeyLength = 96
+
+// subjectPublicKeyInfo is the ASN.1 structure of a DER encoded public key (RFC 5280, section 4.1)
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ParsePublicKeyDER parses the given DER encoded public key (a SubjectPublicKeyInfo),
+// and returns the raw public key in the encoding expected for the given signature algorithm.
+//
+// ECDSA keys must be uncompressed points on the curve of the signature algorithm,
+// and are returned as the concatenation of the X and Y coordinates.
+// BLS keys have no standardized algorithm identifier, so only the length of the key is validated.
+func ParsePublicKeyDER(der []byte, signatureAlgorithm sema.SignatureAlgorithm) ([]byte, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.NewDefaultUserError("trailing data after public key")
+	}
+
+	key := info.PublicKey.RightAlign()
+
+	switch signatureAlgorithm {
+	case sema.SignatureAlgorithmECDSA_P256,
+		sema.SignatureAlgorithmECDSA_secp256k1:
+
+		if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+			return nil, errors.NewDefaultUserError("not an ECDSA public key")
+		}
+
+		var curve asn1.ObjectIdentifier
+		_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve)
+		if err != nil {
+			return nil, err
+		}
+
+		expectedCurve := oidCurveP256
+		if signatureAlgorithm == sema.SignatureAlgorithmECDSA_secp256k1 {
+			expectedCurve = oidCurveSecp256k1
+		}
+		if !curve.Equal(expectedCurve) {
+			return nil, errors.NewDefaultUserError("public key curve does not match signature algorithm")
+		}
+
+		// Only uncompressed points are supported
+		if len(key) != ecdsaPublicKeyLength+1 || key[0] != 0x04 {
+			return nil, errors.NewDefaultUserError("invalid ECDSA public key length")
+		}
+
+		return key[1:], nil
+
+	case sema.SignatureAlgorithmBLS_BLS12_381:
+		if len(key) != blsPublicKeyLength {
+			return nil, errors.NewDefaultUserError("invalid BLS public key length")
+		}
+
+		return key, nil
+
+	default:
+		return nil, errors.NewDefaultUserError("unsupported signature algorithm: %s", signatureAlgorithm.Name())
+	}
+}
+
+// CheckSignatureHashAlgorithmCompatibility returns an error
+// if keys of the given signature algorithm cannot be used with the given hash algorithm.
+//
+// BLS signatures require the KMAC128_BLS_BLS12_381 hash algorithm,
+// which in turn cannot be used with ECDSA signatures.
+func CheckSignatureHashAlgorithmCompatibility(
+	signatureAlgorithm sema.SignatureAlgorithm,
+	hashAlgorithm sema.HashAlgorithm,
+) error {
+	isBLS := signatureAlgorithm == sema.SignatureAlgorithmBLS_BLS12_381
+	isKMAC := hashAlgorithm == sema.HashAlgorithmKMAC128_BLS_BLS12_381
+
+	if isBLS != isKMAC {
+		return errors.NewDefaultUserError(
+			"signature algorithm %s is not compatible with hash algorithm %s",
+			signatureAlgorithm.Name(),
+			hashAlgorithm.Name(),
+		)
+	}
+
+	return nil
+}
+
+// PublicKeyValidationError is reported when the host environment
+// rejects a public key, e.g. because it is not a valid point on the curve.
+//
+// The key itself is not retained, only a fingerprint of it,
+// so the error can be logged safely.
+type PublicKeyValidationError struct {
+	Fingerprint        string
+	SignatureAlgorithm sema.SignatureAlgorithm
+	Err                error
+}
+
+var _ errors.UserError = &PublicKeyValidationError{}
+
+func NewPublicKeyValidationError(publicKey *PublicKey, err error) *PublicKeyValidationError {
+	return &PublicKeyValidationError{
+		Fingerprint:        interpreter.PublicKeyFingerprint(publicKey.PublicKey),
+		SignatureAlgorithm: publicKey.SignAlgo,
+		Err:                err,
+	}
+}
+
+var _ interpreter.RedactedPublicKeyError = &PublicKeyValidationError{}
+
+func (*PublicKeyValidationError) IsUserError() {}
+
+func (*PublicKeyValidationError) IsRedactedPublicKeyError() {}
+
+func (e *PublicKeyValidationError) Error() string {
+	return fmt.Sprintf(
+		"public key validation failed (fingerprint %s, signature algorithm %s): %s",
+		e.Fingerprint,
+		e.SignatureAlgorithm.Name(),
+		e.Err.Error(),
+	)
+}
+
+func (e *PublicKeyValidationError) Unwrap() error {
+	return e.Err
+}
@@ -47,6 +47,18 @@ var rlpContractType = func() *sema.CompositeType {
 			rlpDecodeStringFunctionType,
 			rlpDecodeStringFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			rlpEncodeStringFunctionName,
+			rlpEncodeStringFunctionType,
+			rlpEncodeStringFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			rlpEncodeListFunctionName,
+			rlpEncodeListFunctionType,
+			rlpEncodeListFunctionDocString,
+		),
 	})
 	return ty
 }()
@@ -224,9 +236,151 @@ var rlpDecodeListFunction = interpreter.NewUnmeteredHostFunctionValue(
 	rlpDecodeListFunctionType,
 )
 
+const rlpEncodeStringFunctionDocString = `
+Encodes a byte array (called string in the context of RLP) into RLP.
+The result is the inverse of decodeString.
+`
+
+const rlpEncodeStringFunctionName = "encodeString"
+
+var rlpEncodeStringFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "input",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.ByteArrayType,
+	),
+}
+
+type RLPEncodeStringError struct {
+	Msg string
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = RLPEncodeStringError{}
+
+func (RLPEncodeStringError) IsUserError() {}
+
+func (e RLPEncodeStringError) Error() string {
+	return fmt.Sprintf("failed to RLP-encode string: %s", e.Msg)
+}
+
+var rlpEncodeStringFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		invocation.Interpreter.ReportComputation(common.ComputationKindSTDLIBRLPEncodeString, uint(input.Count()))
+
+		convertedInput, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, input)
+		if err != nil {
+			panic(RLPEncodeStringError{
+				Msg:           err.Error(),
+				LocationRange: invocation.GetLocationRange(),
+			})
+		}
+
+		output := rlp.EncodeString(convertedInput)
+
+		return interpreter.ByteSliceToByteArrayValue(invocation.Interpreter, output)
+	},
+	rlpEncodeStringFunctionType,
+)
+
+const rlpEncodeListFunctionDocString = `
+Encodes an array of RLP-encoded items into an RLP-encoded list.
+Note that this function does not recursively encode, so each element of the given array must already be RLP-encoded data,
+e.g. the result of encodeString or encodeList. This allows encoding nested lists.
+The result is the inverse of decodeList.
+If any element is not a single RLP-encoded item, the program aborts.
+`
+
+const rlpEncodeListFunctionName = "encodeList"
+
+var rlpEncodeListFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "items",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.ByteArrayArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.ByteArrayType,
+	),
+}
+
+type RLPEncodeListError struct {
+	Msg string
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = RLPEncodeListError{}
+
+func (RLPEncodeListError) IsUserError() {}
+
+func (e RLPEncodeListError) Error() string {
+	return fmt.Sprintf("failed to RLP-encode list: %s", e.Msg)
+}
+
+var rlpEncodeListFunction = interpreter.NewUnmeteredHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		getLocationRange := invocation.GetLocationRange
+
+		count := input.Count()
+		items := make([][]byte, count)
+		for i := 0; i < count; i++ {
+			itemValue := input.Get(inter, getLocationRange, i)
+
+			item, err := interpreter.ByteArrayValueToByteSlice(inter, itemValue)
+			if err != nil {
+				panic(RLPEncodeListError{
+					Msg:           err.Error(),
+					LocationRange: getLocationRange(),
+				})
+			}
+
+			// The computation is proportional to the total size of the items
+
+			inter.ReportComputation(common.ComputationKindSTDLIBRLPEncodeList, uint(len(item)))
+
+			items[i] = item
+		}
+
+		output, err := rlp.EncodeList(items)
+		if err != nil {
+			panic(RLPEncodeListError{
+				Msg:           err.Error(),
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		return interpreter.ByteSliceToByteArrayValue(inter, output)
+	},
+	rlpEncodeListFunctionType,
+)
+
 var rlpContractFields = map[string]interpreter.Value{
 	rlpDecodeListFunctionName:   rlpDecodeListFunction,
 	rlpDecodeStringFunctionName: rlpDecodeStringFunction,
+	rlpEncodeListFunctionName:   rlpEncodeListFunction,
+	rlpEncodeStringFunctionName: rlpEncodeStringFunction,
 }
 
 var rlpContractValue = interpreter.NewSimpleCompositeValue(
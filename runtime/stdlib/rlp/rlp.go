@@ -47,6 +47,7 @@ var (
 	ErrDataSizeTooLarge  = errors.New("data size is larger than what is supported")
 	ErrListSizeMismatch  = errors.New("list size doesn't match the size of items")
 	ErrTypeMismatch      = errors.New("type extracted from input doesn't match the function")
+	ErrInvalidListItem   = errors.New("list item is not a single RLP-encoded item")
 )
 
 // ReadSize looks at the first byte at startIndex to decode the type and reads as many bytes as needed
@@ -242,3 +243,65 @@ func DecodeList(inp []byte, startIndex int) (encodedItems [][]byte, bytesRead in
 
 	return retList, itemEndIndex - startIndex, nil
 }
+
+// EncodeString encodes the given string (byte array) in RLP canonical form
+func EncodeString(str []byte) []byte {
+	// single character special case
+	if len(str) == 1 && str[0] <= ByteRangeEnd {
+		return []byte{str[0]}
+	}
+
+	header := encodeHeader(len(str), ShortStringRangeStart, LongStringRangeStart)
+
+	output := make([]byte, 0, len(header)+len(str))
+	output = append(output, header...)
+	return append(output, str...)
+}
+
+// EncodeList encodes a list of RLP-encoded items in RLP canonical form.
+// Each item must be a single RLP-encoded string or list, e.g. as returned by EncodeString,
+// EncodeList, or DecodeList. Items are not re-encoded, which allows encoding nested lists
+func EncodeList(encodedItems [][]byte) ([]byte, error) {
+	var payloadSize int
+	for _, item := range encodedItems {
+		_, dataStartIndex, dataSize, err := ReadSize(item, 0)
+		if err != nil {
+			return nil, err
+		}
+		if dataStartIndex+dataSize != len(item) {
+			return nil, ErrInvalidListItem
+		}
+		payloadSize += len(item)
+	}
+
+	header := encodeHeader(payloadSize, ShortListRangeStart, LongListRangeStart)
+
+	output := make([]byte, 0, len(header)+payloadSize)
+	output = append(output, header...)
+	for _, item := range encodedItems {
+		output = append(output, item...)
+	}
+	return output, nil
+}
+
+// encodeHeader encodes the header of a string or list with a data size of the given size.
+// Short data has a single byte header, long data is prefixed with the big-endian encoded size,
+// without leading zeros
+func encodeHeader(size int, shortRangeStart, longRangeStart byte) []byte {
+	if size <= MaxShortLengthAllowed {
+		return []byte{shortRangeStart + byte(size)}
+	}
+
+	var sizeBytes [8]byte
+	binary.BigEndian.PutUint64(sizeBytes[:], uint64(size))
+
+	leadingZeros := 0
+	for leadingZeros < len(sizeBytes)-1 && sizeBytes[leadingZeros] == 0 {
+		leadingZeros++
+	}
+	trimmedSizeBytes := sizeBytes[leadingZeros:]
+
+	header := make([]byte, 0, 1+len(trimmedSizeBytes))
+	header = append(header, longRangeStart+byte(len(trimmedSizeBytes))-1)
+	return append(header, trimmedSizeBytes...)
+}
@@ -452,3 +452,90 @@ func TestDecodeList(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodeString(t *testing.T) {
+	tests := []struct {
+		str      []byte
+		expected []byte
+	}{
+		{[]byte{}, []byte{0x80}},                                                      // empty string
+		{[]byte{0x00}, []byte{0x00}},                                                  // single byte
+		{[]byte{0x7f}, []byte{0x7f}},                                                  // single byte, upper bound
+		{[]byte{0x80}, []byte{0x81, 0x80}},                                            // single byte, out of range
+		{[]byte("dog"), []byte{0x83, 'd', 'o', 'g'}},                                  // short string
+		{make([]byte, 55), append([]byte{0xb7}, make([]byte, 55)...)},                 // longest short string
+		{make([]byte, 56), append([]byte{0xb8, 56}, make([]byte, 56)...)},             // shortest long string
+		{make([]byte, 1024), append([]byte{0xb9, 0x04, 0x00}, make([]byte, 1024)...)}, // long string, two size bytes
+	}
+
+	for _, test := range tests {
+		encoded := rlp.EncodeString(test.str)
+		require.Equal(t, test.expected, encoded)
+
+		decoded, bytesRead, err := rlp.DecodeString(encoded, 0)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), bytesRead)
+		require.Equal(t, test.str, decoded)
+	}
+}
+
+func TestEncodeList(t *testing.T) {
+
+	t.Run("empty", func(t *testing.T) {
+		encoded, err := rlp.EncodeList(nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xc0}, encoded)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		// [ "cat", [ "dog", [ [] ] ] ]
+
+		innerList, err := rlp.EncodeList([][]byte{{0xc0}})
+		require.NoError(t, err)
+
+		middleList, err := rlp.EncodeList([][]byte{
+			rlp.EncodeString([]byte("dog")),
+			innerList,
+		})
+		require.NoError(t, err)
+
+		encoded, err := rlp.EncodeList([][]byte{
+			rlp.EncodeString([]byte("cat")),
+			middleList,
+		})
+		require.NoError(t, err)
+		require.Equal(t,
+			[]byte{0xcb, 0x83, 'c', 'a', 't', 0xc6, 0x83, 'd', 'o', 'g', 0xc1, 0xc0},
+			encoded,
+		)
+
+		items, bytesRead, err := rlp.DecodeList(encoded, 0)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), bytesRead)
+		require.Equal(t, [][]byte{{0x83, 'c', 'a', 't'}, middleList}, items)
+	})
+
+	t.Run("long", func(t *testing.T) {
+		items := make([][]byte, 60)
+		for i := range items {
+			items[i] = rlp.EncodeString([]byte{byte(i)})
+		}
+
+		encoded, err := rlp.EncodeList(items)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf8, 60}, encoded[:2])
+
+		decodedItems, bytesRead, err := rlp.DecodeList(encoded, 0)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), bytesRead)
+		require.Equal(t, items, decodedItems)
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		_, err := rlp.EncodeList([][]byte{{0x83, 'd', 'o', 'g', 0x01}})
+		require.Equal(t, rlp.ErrInvalidListItem, err)
+
+		_, err = rlp.EncodeList([][]byte{{}})
+		require.Equal(t, rlp.ErrEmptyInput, err)
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type ServiceAccountProvider interface {
+	// GetServiceAccountAddress returns the address of the service account of the chain.
+	GetServiceAccountAddress() common.Address
+}
+
+// CheckServiceAccount ensures that the given address is the address of the service account,
+// and panics with a NotServiceAccountError otherwise.
+//
+// Functions which may only be called by the service account, e.g. freezing accounts,
+// should use this function to check the calling account.
+//
+func CheckServiceAccount(
+	provider ServiceAccountProvider,
+	address common.Address,
+	getLocationRange func() interpreter.LocationRange,
+) {
+	var serviceAddress common.Address
+	wrapPanic(func() {
+		serviceAddress = provider.GetServiceAccountAddress()
+	})
+
+	if address != serviceAddress {
+		panic(&NotServiceAccountError{
+			Address:       address,
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
+// NotServiceAccountError is reported when an account other than the service account
+// calls a function which may only be called by the service account
+//
+type NotServiceAccountError struct {
+	Address common.Address
+	interpreter.LocationRange
+}
+
+var _ errors.UserError = &NotServiceAccountError{}
+
+func (*NotServiceAccountError) IsUserError() {}
+
+func (e *NotServiceAccountError) Error() string {
+	return fmt.Sprintf(
+		"account %s is not the service account",
+		e.Address.ShortHexWithPrefix(),
+	)
+}
@@ -0,0 +1,80 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type testServiceAccountProvider struct {
+	address common.Address
+}
+
+var _ ServiceAccountProvider = testServiceAccountProvider{}
+
+func (p testServiceAccountProvider) GetServiceAccountAddress() common.Address {
+	return p.address
+}
+
+func TestCheckServiceAccount(t *testing.T) {
+
+	t.Parallel()
+
+	serviceAddress := common.MustBytesToAddress([]byte{0x1})
+
+	provider := testServiceAccountProvider{
+		address: serviceAddress,
+	}
+
+	t.Run("service account", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.NotPanics(t, func() {
+			CheckServiceAccount(provider, serviceAddress, interpreter.ReturnEmptyLocationRange)
+		})
+	})
+
+	t.Run("other account", func(t *testing.T) {
+
+		t.Parallel()
+
+		otherAddress := common.MustBytesToAddress([]byte{0x2})
+
+		defer func() {
+			r := recover()
+			require.IsType(t, &NotServiceAccountError{}, r)
+
+			err := r.(*NotServiceAccountError)
+			assert.Equal(t, otherAddress, err.Address)
+			assert.Equal(t, "account 0x2 is not the service account", err.Error())
+		}()
+
+		CheckServiceAccount(provider, otherAddress, interpreter.ReturnEmptyLocationRange)
+
+		t.Fatal("expected panic")
+	})
+}
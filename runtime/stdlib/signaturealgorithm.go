@@ -30,14 +30,17 @@ var signatureAlgorithmStaticType interpreter.StaticType = interpreter.CompositeS
 	TypeID:              signatureAlgorithmTypeID,
 }
 
-func NewSignatureAlgorithmCase(rawValue interpreter.UInt8Value) interpreter.MemberAccessibleValue {
+func NewSignatureAlgorithmCase(
+	gauge common.MemoryGauge,
+	rawValue interpreter.UInt8Value,
+) interpreter.MemberAccessibleValue {
 
 	fields := map[string]interpreter.Value{
 		sema.EnumRawValueFieldName: rawValue,
 	}
 
 	return interpreter.NewSimpleCompositeValue(
-		nil,
+		gauge,
 		sema.SignatureAlgorithmType.ID(),
 		signatureAlgorithmStaticType,
 		[]string{sema.EnumRawValueFieldName},
@@ -0,0 +1,225 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+const verifySignaturesFunctionDocString = `
+Verifies each of the given signatures against the public key at the same index,
+and returns the total weight of the valid signatures.
+
+All signatures are verified against the same signed data, domain separation tag, and hash algorithm.
+If weights are given, there must be one weight per public key, otherwise each valid signature has a weight of 1.0.
+`
+
+const verifySignaturesFunctionName = "verifySignatures"
+
+var verifySignaturesFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "signatures",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayArrayType),
+		},
+		{
+			Identifier:     "publicKeys",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.PublicKeyArrayType),
+		},
+		{
+			Identifier: "weights",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: &sema.VariableSizedType{
+						Type: sema.UFix64Type,
+					},
+				},
+			),
+		},
+		{
+			Identifier:     "signedData",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+		},
+		{
+			Identifier:     "domainSeparationTag",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+		{
+			Identifier:     "hashAlgorithm",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.HashAlgorithmType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.UFix64Type,
+	),
+}
+
+type SignatureVerifier interface {
+	// VerifySignature returns true if the given signature was produced by signing the given tag + data
+	// using the given public key, signature algorithm, and hash algorithm.
+	VerifySignature(
+		signature []byte,
+		tag string,
+		signedData []byte,
+		publicKey []byte,
+		signatureAlgorithm sema.SignatureAlgorithm,
+		hashAlgorithm sema.HashAlgorithm,
+	) (bool, error)
+}
+
+func NewVerifySignaturesFunction(verifier SignatureVerifier) StandardLibraryValue {
+	return NewStandardLibraryFunction(
+		verifySignaturesFunctionName,
+		verifySignaturesFunctionType,
+		verifySignaturesFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			getLocationRange := invocation.GetLocationRange
+
+			signaturesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			publicKeysValue, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var weightsValue *interpreter.ArrayValue
+			switch weights := invocation.Arguments[2].(type) {
+			case interpreter.NilValue:
+				// no weights, each valid signature has a weight of 1.0
+			case *interpreter.SomeValue:
+				weightsValue, ok = weights.InnerValue(inter, getLocationRange).(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
+			signedDataValue, ok := invocation.Arguments[3].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			domainSeparationTagValue, ok := invocation.Arguments[4].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			count := signaturesValue.Count()
+
+			if publicKeysValue.Count() != count {
+				panic(errors.NewDefaultUserError(
+					"invalid number of public keys: expected %d, got %d",
+					count,
+					publicKeysValue.Count(),
+				))
+			}
+
+			if weightsValue != nil && weightsValue.Count() != count {
+				panic(errors.NewDefaultUserError(
+					"invalid number of weights: expected %d, got %d",
+					count,
+					weightsValue.Count(),
+				))
+			}
+
+			signedData, err := interpreter.ByteArrayValueToByteSlice(inter, signedDataValue)
+			if err != nil {
+				panic(errors.NewUnexpectedError("failed to get signed data. %w", err))
+			}
+
+			domainSeparationTag := domainSeparationTagValue.Str
+
+			hashAlgorithm := NewHashAlgorithmFromValue(inter, getLocationRange, invocation.Arguments[5])
+
+			var totalWeight uint64
+
+			for i := 0; i < count; i++ {
+				inter.ReportComputation(common.ComputationKindSTDLIBVerifySignatures, 1)
+
+				signature, err := interpreter.ByteArrayValueToByteSlice(
+					inter,
+					signaturesValue.Get(inter, getLocationRange, i),
+				)
+				if err != nil {
+					panic(errors.NewUnexpectedError("failed to get signature. %w", err))
+				}
+
+				publicKeyValue, ok := publicKeysValue.Get(inter, getLocationRange, i).(interpreter.MemberAccessibleValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				publicKey, err := NewPublicKeyFromValue(inter, getLocationRange, publicKeyValue)
+				if err != nil {
+					// An invalid public key never produces a valid signature
+					continue
+				}
+
+				var valid bool
+				wrapPanic(func() {
+					valid, err = verifier.VerifySignature(
+						signature,
+						domainSeparationTag,
+						signedData,
+						publicKey.PublicKey,
+						publicKey.SignAlgo,
+						hashAlgorithm,
+					)
+				})
+				if err != nil {
+					panic(err)
+				}
+
+				if !valid {
+					continue
+				}
+
+				weight := uint64(sema.Fix64Factor)
+				if weightsValue != nil {
+					weightValue, ok := weightsValue.Get(inter, getLocationRange, i).(interpreter.UFix64Value)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					weight = uint64(weightValue)
+				}
+
+				if totalWeight+weight < totalWeight {
+					panic(interpreter.OverflowError{})
+				}
+				totalWeight += weight
+			}
+
+			return interpreter.NewUFix64Value(
+				inter,
+				func() uint64 {
+					return totalWeight
+				},
+			)
+		},
+	)
+}
@@ -970,6 +970,7 @@ func newAccountValue(
 			account.PublicKey.PublicKey,
 		),
 		NewSignatureAlgorithmCase(
+			inter,
 			interpreter.UInt8Value(account.PublicKey.SignAlgo.RawValue()),
 		),
 		inter.Config.PublicKeyValidationHandler,
@@ -20,6 +20,7 @@ package stdlib
 
 import (
 	goRuntime "runtime"
+	"runtime/debug"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -31,9 +32,17 @@ func wrapPanic(f func()) {
 			switch r := r.(type) {
 			case goRuntime.Error, errors.InternalError:
 				panic(r)
+			case errors.UserError:
+				panic(errors.ExternalError{
+					Recovered: r,
+				})
 			default:
+				// Unexpected failures of the host environment capture the stack,
+				// while it still includes the frames of the failed host call.
+				// User errors are expected and stay lightweight
 				panic(errors.ExternalError{
 					Recovered: r,
+					Stack:     debug.Stack(),
 				})
 			}
 
@@ -41,3 +50,42 @@ func wrapPanic(f func()) {
 	}()
 	f()
 }
+
+// HostErrorMapper is an optional interface a handler can implement,
+// to map the errors returned by host functions to Cadence errors.
+//
+// For example, a host may map an error indicating that the payer has an insufficient balance
+// to a HostUserError with a clean message, while keeping storage backend failures external errors.
+type HostErrorMapper interface {
+	// MapHostError returns the Cadence error for the given error returned by a host function.
+	MapHostError(err error) error
+}
+
+// HostUserError is a user error reported for an error returned by a host function,
+// e.g. by a HostErrorMapper.
+type HostUserError struct {
+	Message string
+	Err     error
+}
+
+var _ errors.UserError = HostUserError{}
+
+func (HostUserError) IsUserError() {}
+
+func (e HostUserError) Error() string {
+	return e.Message
+}
+
+func (e HostUserError) Unwrap() error {
+	return e.Err
+}
+
+// mapHostError maps the given error returned by a host function,
+// if the handler implements HostErrorMapper
+func mapHostError(handler any, err error) error {
+	mapper, ok := handler.(HostErrorMapper)
+	if !ok {
+		return err
+	}
+	return mapper.MapHostError(err)
+}
@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	"bytes"
 	"runtime"
 	"sort"
 
@@ -39,6 +40,13 @@ type Storage struct {
 	contractUpdates map[interpreter.StorageKey]*interpreter.CompositeValue
 	Ledger          atree.Ledger
 	memoryGauge     common.MemoryGauge
+	// onAccountAccessed is called when the storage of an account is accessed
+	// for the first time, if set
+	onAccountAccessed func(address common.Address)
+	accessedAccounts  map[common.Address]struct{}
+	// writtenAccounts are the accounts whose registers were written,
+	// see takeWrittenAccounts
+	writtenAccounts map[common.Address]struct{}
 }
 
 var _ atree.SlabStorage = &Storage{}
@@ -63,6 +71,13 @@ func NewStorage(ledger atree.Ledger, memoryGauge common.MemoryGauge) *Storage {
 		return interpreter.DecodeTypeInfo(decoder, memoryGauge)
 	}
 
+	writtenAccounts := map[common.Address]struct{}{}
+
+	ledger = writeRecordingLedger{
+		Ledger:          ledger,
+		writtenAccounts: writtenAccounts,
+	}
+
 	ledgerStorage := atree.NewLedgerBaseStorage(ledger)
 	persistentSlabStorage := atree.NewPersistentSlabStorage(
 		ledgerStorage,
@@ -77,10 +92,54 @@ func NewStorage(ledger atree.Ledger, memoryGauge common.MemoryGauge) *Storage {
 		writes:                map[interpreter.StorageKey]atree.StorageIndex{},
 		storageMaps:           map[interpreter.StorageKey]*interpreter.StorageMap{},
 		contractUpdates:       map[interpreter.StorageKey]*interpreter.CompositeValue{},
+		accessedAccounts:      map[common.Address]struct{}{},
+		writtenAccounts:       writtenAccounts,
 		memoryGauge:           memoryGauge,
 	}
 }
 
+// writeRecordingLedger is a ledger which records the accounts whose registers are written
+type writeRecordingLedger struct {
+	atree.Ledger
+	writtenAccounts map[common.Address]struct{}
+}
+
+var _ atree.Ledger = writeRecordingLedger{}
+
+func (l writeRecordingLedger) SetValue(owner, key, value []byte) error {
+	// Temporary slabs are not stored in accounts
+	address, err := common.BytesToAddress(owner)
+	if err == nil && address != (common.Address{}) {
+		l.writtenAccounts[address] = struct{}{}
+	}
+
+	return l.Ledger.SetValue(owner, key, value)
+}
+
+// takeWrittenAccounts returns the accounts whose registers were written
+// since the last call of this function, in sorted order
+func (s *Storage) takeWrittenAccounts() []common.Address {
+	if len(s.writtenAccounts) == 0 {
+		return nil
+	}
+
+	addresses := make([]common.Address, 0, len(s.writtenAccounts))
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for address := range s.writtenAccounts { //nolint:maprangecheck
+		addresses = append(addresses, address)
+		delete(s.writtenAccounts, address)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	return addresses
+}
+
 const storageIndexLength = 8
 
 func (s *Storage) GetStorageMap(
@@ -95,6 +154,8 @@ func (s *Storage) GetStorageMap(
 	storageMap = s.storageMaps[key]
 	if storageMap == nil {
 
+		s.recordAccountAccess(address)
+
 		// Load data through the runtime interface
 
 		var data []byte
@@ -136,6 +197,19 @@ func (s *Storage) GetStorageMap(
 	return storageMap
 }
 
+func (s *Storage) recordAccountAccess(address common.Address) {
+	if s.onAccountAccessed == nil {
+		return
+	}
+
+	if _, ok := s.accessedAccounts[address]; ok {
+		return
+	}
+	s.accessedAccounts[address] = struct{}{}
+
+	s.onAccountAccessed(address)
+}
+
 func (s *Storage) loadExistingStorageMap(address atree.Address, storageIndex atree.StorageIndex) *interpreter.StorageMap {
 
 	storageID := atree.StorageID{
@@ -255,6 +329,13 @@ func sortWrites(writes []write) {
 	})
 }
 
+// hasPendingWrites returns true if there are storage writes or slab changes
+// which have not been committed yet. Contract updates are not considered
+func (s *Storage) hasPendingWrites() bool {
+	return len(s.writes) > 0 ||
+		s.PersistentSlabStorage.Deltas() > 0
+}
+
 // Commit serializes/saves all values in the readCache in storage (through the runtime interface).
 //
 func (s *Storage) Commit(inter *interpreter.Interpreter, commitContractUpdates bool) error {
@@ -0,0 +1,88 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/onflow/atree"
+)
+
+// StorageIndexAllocator allocates the storage indices of the slabs of accounts.
+//
+// By default, storage indices are allocated by the host environment
+// (Interface.AllocateStorageIndex).
+// An allocator can be provided in the Context to use a different allocation strategy,
+// e.g. deterministic indices in tests.
+type StorageIndexAllocator interface {
+	// AllocateStorageIndex allocates a new storage index under the given account.
+	AllocateStorageIndex(owner []byte) (atree.StorageIndex, error)
+}
+
+// SequentialStorageIndexAllocator is a StorageIndexAllocator
+// which allocates the storage indices of each account sequentially, starting at 1.
+type SequentialStorageIndexAllocator struct {
+	mutex   sync.Mutex
+	indices map[string]uint64
+}
+
+var _ StorageIndexAllocator = &SequentialStorageIndexAllocator{}
+
+func NewSequentialStorageIndexAllocator() *SequentialStorageIndexAllocator {
+	return &SequentialStorageIndexAllocator{
+		indices: map[string]uint64{},
+	}
+}
+
+func (a *SequentialStorageIndexAllocator) AllocateStorageIndex(owner []byte) (result atree.StorageIndex, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	index := a.indices[string(owner)] + 1
+	a.indices[string(owner)] = index
+
+	binary.BigEndian.PutUint64(result[:], index)
+	return result, nil
+}
+
+// allocatorLedger is a ledger which allocates storage indices using an allocator,
+// instead of the wrapped ledger
+type allocatorLedger struct {
+	atree.Ledger
+	allocator StorageIndexAllocator
+}
+
+var _ atree.Ledger = allocatorLedger{}
+
+func (l allocatorLedger) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	return l.allocator.AllocateStorageIndex(owner)
+}
+
+// storageLedger returns the ledger used for the storage of the execution of the context.
+func (c Context) storageLedger() atree.Ledger {
+	if c.StorageIndexAllocator == nil {
+		return c.Interface
+	}
+
+	return allocatorLedger{
+		Ledger:    c.Interface,
+		allocator: c.StorageIndexAllocator,
+	}
+}
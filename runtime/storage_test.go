@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/onflow/atree"
@@ -35,6 +36,8 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
 
@@ -2068,6 +2071,296 @@ func TestRuntimeStorageUsed(t *testing.T) {
 
 }
 
+func TestRuntimeStorageGrowthLimit(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		ledger := newTestLedger(nil, nil)
+
+		// The storage used is the total size of the account's registers
+
+		prefix := string(address[:]) + "|"
+
+		return &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getStorageUsed: func(_ Address) (uint64, error) {
+				var used uint64
+				for key, value := range ledger.storedValues {
+					if strings.HasPrefix(key, prefix) {
+						used += uint64(len(value))
+					}
+				}
+				return used, nil
+			},
+		}
+	}
+
+	newTransaction := func(length int) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save("%s", to: /storage/data)
+                      let used = signer.storageUsed
+                  }
+              }
+            `,
+			strings.Repeat("x", length),
+		))
+	}
+
+	// newWriteOnlyTransaction returns a transaction which grows the storage,
+	// but never reads the storage used
+	newWriteOnlyTransaction := func(length int) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save("%s", to: /storage/data)
+                  }
+              }
+            `,
+			strings.Repeat("x", length),
+		))
+	}
+
+	executeTransaction := func(config Config, script []byte) error {
+		runtime := NewInterpreterRuntime(config)
+
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	t.Run("below limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeTransaction(
+			Config{
+				AtreeValidationEnabled: true,
+				StorageGrowthLimit:     1000,
+			},
+			newTransaction(10),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("above limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeTransaction(
+			Config{
+				AtreeValidationEnabled: true,
+				StorageGrowthLimit:     1000,
+			},
+			newTransaction(2000),
+		)
+		require.Error(t, err)
+
+		var storageGrowthLimitErr *stdlib.StorageGrowthLimitError
+		require.ErrorAs(t, err, &storageGrowthLimitErr)
+
+		assert.Equal(t, address, storageGrowthLimitErr.Address)
+		assert.Equal(t, uint64(1000), storageGrowthLimitErr.Limit)
+		assert.Greater(t, storageGrowthLimitErr.Used, uint64(2000))
+
+		// The error is reported at the read of the storage used
+		assert.Equal(t, common.TransactionLocation{}, storageGrowthLimitErr.Location)
+	})
+
+	t.Run("above limit, without reading storage used", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeTransaction(
+			Config{
+				AtreeValidationEnabled: true,
+				StorageGrowthLimit:     1000,
+			},
+			newWriteOnlyTransaction(2000),
+		)
+		require.Error(t, err)
+
+		var storageGrowthLimitErr *stdlib.StorageGrowthLimitError
+		require.ErrorAs(t, err, &storageGrowthLimitErr)
+
+		assert.Equal(t, address, storageGrowthLimitErr.Address)
+		assert.Equal(t, uint64(1000), storageGrowthLimitErr.Limit)
+		assert.Greater(t, storageGrowthLimitErr.Used, uint64(2000))
+	})
+
+	t.Run("below limit, without reading storage used", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeTransaction(
+			Config{
+				AtreeValidationEnabled: true,
+				StorageGrowthLimit:     1000,
+			},
+			newWriteOnlyTransaction(10),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeTransaction(
+			Config{
+				AtreeValidationEnabled: true,
+			},
+			newTransaction(2000),
+		)
+		require.NoError(t, err)
+	})
+}
+
+func TestRuntimeStorageFlush(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	// executeTransaction executes the given transaction,
+	// which may flush the storage explicitly using the function `flushStorage`,
+	// and returns the number of storage commits
+	executeTransaction := func(t *testing.T, code string) int {
+		runtime := newTestInterpreterRuntime()
+
+		environment := NewBaseInterpreterEnvironment(Config{})
+		environment.Declare(
+			stdlib.NewStandardLibraryFunction(
+				"flushStorage",
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+				},
+				"",
+				func(invocation interpreter.Invocation) interpreter.Value {
+					err := environment.FlushStorage(invocation.Interpreter, invocation.GetLocationRange)
+					if err != nil {
+						panic(err)
+					}
+					return interpreter.VoidValue{}
+				},
+			),
+		)
+
+		var commits int
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getStorageUsed: func(_ Address) (uint64, error) {
+				return 1, nil
+			},
+			getStorageCapacity: func(_ Address) (uint64, error) {
+				return 2, nil
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				// Each storage commit reports the encoded slabs
+				if usage.Kind == common.MemoryKindAtreeEncodedSlab {
+					commits++
+				}
+				return nil
+			},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface:   runtimeInterface,
+				Location:    common.TransactionLocation{},
+				Environment: environment,
+			},
+		)
+		require.NoError(t, err)
+
+		return commits
+	}
+
+	t.Run("without flush", func(t *testing.T) {
+
+		t.Parallel()
+
+		commits := executeTransaction(t, `
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save(1, to: /storage/one)
+                  let used1 = signer.storageUsed
+                  let used2 = signer.storageUsed
+                  let capacity = signer.storageCapacity
+              }
+          }
+        `)
+
+		// The first read commits and serves the following reads,
+		// as nothing was written since, and the transaction commits at the end
+		assert.Equal(t, 2, commits)
+	})
+
+	t.Run("with flush", func(t *testing.T) {
+
+		t.Parallel()
+
+		commits := executeTransaction(t, `
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save(1, to: /storage/one)
+                  flushStorage()
+                  let used1 = signer.storageUsed
+                  let used2 = signer.storageUsed
+                  let capacity = signer.storageCapacity
+              }
+          }
+        `)
+
+		// The flush serves all reads, and the transaction commits at the end
+		assert.Equal(t, 2, commits)
+	})
+
+	t.Run("write after flush", func(t *testing.T) {
+
+		t.Parallel()
+
+		commits := executeTransaction(t, `
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save(1, to: /storage/one)
+                  flushStorage()
+                  let used1 = signer.storageUsed
+                  signer.save(2, to: /storage/two)
+                  let used2 = signer.storageUsed
+                  let used3 = signer.storageUsed
+              }
+          }
+        `)
+
+		// The write after the flush requires a commit,
+		// which serves the following read
+		assert.Equal(t, 3, commits)
+	})
+}
+
 func TestSortContractUpdates(t *testing.T) {
 
 	t.Parallel()
@@ -3377,3 +3670,287 @@ func TestRuntimeStorageInternalAccess(t *testing.T) {
 	_, err = ExportValue(rValue, inter, interpreter.ReturnEmptyLocationRange)
 	require.NoError(t, err)
 }
+
+type testStorageBreakdownRuntimeInterface struct {
+	*testRuntimeInterface
+	getStorageUsedByDomain func(address Address) (map[string]uint64, error)
+}
+
+var _ StorageBreakdownProvider = &testStorageBreakdownRuntimeInterface{}
+
+func (i *testStorageBreakdownRuntimeInterface) GetStorageUsedByDomain(address Address) (map[string]uint64, error) {
+	return i.getStorageUsedByDomain(address)
+}
+
+func TestRuntimeStorageUsedByDomain(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x2})
+
+	t.Run("provided", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+          pub fun main(): {String: UInt64} {
+              let acc = getAuthAccount(0x02)
+              let usedByDomain = acc.storageUsedByDomain!
+
+              var sum: UInt64 = 0
+              for domain in usedByDomain.keys {
+                  sum = sum + usedByDomain[domain]!
+              }
+              assert(sum == acc.storageUsed)
+
+              return usedByDomain
+          }
+        `)
+
+		usedByDomain := map[string]uint64{
+			common.PathDomainStorage.Identifier(): 20,
+			common.PathDomainPublic.Identifier():  7,
+			common.PathDomainPrivate.Identifier(): 3,
+		}
+
+		runtimeInterface := &testStorageBreakdownRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getStorageUsed: func(_ Address) (uint64, error) {
+					return 30, nil
+				},
+			},
+			getStorageUsedByDomain: func(a Address) (map[string]uint64, error) {
+				assert.Equal(t, address, a)
+				return usedByDomain, nil
+			},
+		}
+
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		dictionary := result.(cadence.Dictionary)
+		require.Len(t, dictionary.Pairs, len(usedByDomain))
+
+		var total uint64
+		for _, pair := range dictionary.Pairs {
+			domain := string(pair.Key.(cadence.String))
+			used := uint64(pair.Value.(cadence.UInt64))
+			assert.Equal(t, usedByDomain[domain], used)
+			total += used
+		}
+		assert.Equal(t, uint64(30), total)
+	})
+
+	t.Run("not provided", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save(1, to: /storage/one)
+                  assert(signer.storageUsedByDomain == nil)
+              }
+          }
+        `)
+
+		var commits int
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				// Each storage commit reports the encoded slabs
+				if usage.Kind == common.MemoryKindAtreeEncodedSlab {
+					commits++
+				}
+				return nil
+			},
+		}
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		// Reading the unavailable breakdown does not commit the storage,
+		// the storage is only committed at the end of the transaction
+		assert.Equal(t, 1, commits)
+	})
+}
+
+func TestRuntimeStorageIndexAllocator(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	tx := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save([1, 2, 3], to: /storage/numbers)
+          }
+      }
+    `)
+
+	execute := func(t *testing.T) map[string][]byte {
+
+		var writtenKeys []string
+		ledger := newTestLedger(nil, func(owner, key, value []byte) {
+			writtenKeys = append(writtenKeys, string(key))
+		})
+		ledger.allocateStorageIndex = func(_ []byte) (atree.StorageIndex, error) {
+			assert.FailNow(t, "unexpected allocation of storage index by host environment")
+			return atree.StorageIndex{}, nil
+		}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		}
+
+		allocator := NewSequentialStorageIndexAllocator()
+
+		runtime := newTestInterpreterRuntime()
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface:             runtimeInterface,
+				Location:              nextTransactionLocation(),
+				StorageIndexAllocator: allocator,
+			},
+		)
+		require.NoError(t, err)
+
+		// The first storage index was allocated by the allocator
+
+		firstIndex := atree.StorageIndex{0, 0, 0, 0, 0, 0, 0, 1}
+		assert.Contains(t, writtenKeys, string(atree.SlabIndexToLedgerKey(firstIndex)))
+
+		return ledger.storedValues
+	}
+
+	// Executions with deterministic storage indices produce the same storage
+
+	assert.Equal(t, execute(t), execute(t))
+}
+
+func TestRuntimeStorageCapacityChangedEvent(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	tx := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save([1, 2, 3], to: /storage/numbers)
+          }
+      }
+    `)
+
+	execute := func(t *testing.T, enabled bool) []cadence.Event {
+
+		// Simulate a capacity increase, e.g. due to a deposit,
+		// which is only observable by the host once the storage is committed
+
+		var capacity uint64 = 100
+
+		ledger := newTestLedger(nil, func(_, _, _ []byte) {
+			capacity = 200
+		})
+
+		var events []cadence.Event
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getStorageCapacity: func(a Address) (uint64, error) {
+				assert.Equal(t, address, a)
+				return capacity, nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+		}
+
+		runtime := NewInterpreterRuntime(Config{
+			AtreeValidationEnabled:              true,
+			StorageCapacityChangedEventsEnabled: enabled,
+		})
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		return events
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		events := execute(t, true)
+		require.Len(t, events, 1)
+
+		event := events[0]
+
+		assert.Equal(t,
+			string(stdlib.AccountStorageCapacityChangedEventType.ID()),
+			event.EventType.ID(),
+		)
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.Address(address),
+				cadence.UInt64(100),
+				cadence.UInt64(200),
+			},
+			event.Fields,
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		events := execute(t, false)
+		assert.Empty(t, events)
+	})
+}
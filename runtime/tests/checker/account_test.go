@@ -1200,6 +1200,59 @@ func TestCheckAccount_getLinkTarget(t *testing.T) {
 	}
 }
 
+func TestCheckAccount_getLinkTargetType(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(domain common.PathDomain, accountType, accountVariable string) {
+
+		testName := fmt.Sprintf(
+			"%s.getLinkTargetType: %s",
+			accountType,
+			domain.Identifier(),
+		)
+
+		t.Run(testName, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheckAccount(t,
+				fmt.Sprintf(
+					`
+                      let type: Type? = %s.getLinkTargetType(/%s/r)
+                    `,
+					accountVariable,
+					domain.Identifier(),
+				),
+			)
+
+			// Public accounts only provide the borrow types of public links
+
+			switch {
+			case domain == common.PathDomainPublic,
+				domain == common.PathDomainPrivate && accountType == "AuthAccount":
+
+				require.NoError(t, err)
+
+			default:
+				errs := ExpectCheckerErrors(t, err, 1)
+
+				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+			}
+		})
+	}
+
+	for _, domain := range common.AllPathDomainsByIdentifier {
+
+		for accountType, accountVariable := range map[string]string{
+			"AuthAccount":   "authAccount",
+			"PublicAccount": "publicAccount",
+		} {
+			test(domain, accountType, accountVariable)
+		}
+	}
+}
+
 func TestCheckAccount_getCapability(t *testing.T) {
 
 	t.Parallel()
@@ -85,6 +85,26 @@ func TestCheckToBytes(t *testing.T) {
 	})
 }
 
+func TestCheckAddressFromString(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let res = Address.fromString("0x1")
+    `)
+
+	require.NoError(t, err)
+
+	resType := RequireGlobalValue(t, checker.Elaboration, "res")
+
+	assert.Equal(t,
+		&sema.OptionalType{
+			Type: &sema.AddressType{},
+		},
+		resType,
+	)
+}
+
 func TestCheckToBigEndianBytes(t *testing.T) {
 
 	for _, ty := range sema.AllNumberTypes {
@@ -94,6 +94,7 @@ func TestCheckHashAlgorithmHashFunctions(t *testing.T) {
            let data: [UInt8] = [1, 2, 3]
            let result: [UInt8] = HashAlgorithm.SHA2_256.hash(data)
            let result2: [UInt8] = HashAlgorithm.SHA2_256.hashWithTag(data, tag: "tag")
+           let result3: [UInt8] = HashAlgorithm.SHA2_256.hashWithSalt(data, salt: data)
         `,
 		ParseAndCheckOptions{
 			Config: &sema.Config{
@@ -114,3 +114,70 @@ func TestCheckInvalidRLPDecodeList(t *testing.T) {
 	require.IsType(t, mismatch, errs[0])
 	require.IsType(t, mismatch, errs[1])
 }
+
+func TestCheckRLPEncodeString(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.RLPContract)
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+           let l: [UInt8] = RLP.encodeString([0, 1, 2])
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				BaseValueActivation: baseValueActivation,
+			},
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestCheckRLPEncodeList(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.RLPContract)
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+           let l: [UInt8] = RLP.encodeList([[0x80], [0x01]])
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				BaseValueActivation: baseValueActivation,
+			},
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidRLPEncodeList(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.RLPContract)
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+           let l: [UInt8] = RLP.encodeList([0, 1, 2])
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				BaseValueActivation: baseValueActivation,
+			},
+		},
+	)
+
+	// Each element is not a byte array
+
+	errs := ExpectCheckerErrors(t, err, 3)
+	var mismatch *sema.TypeMismatchError
+	for _, err := range errs {
+		require.IsType(t, mismatch, err)
+	}
+}
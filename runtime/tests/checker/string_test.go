@@ -292,6 +292,59 @@ func TestCheckStringEncodeHex(t *testing.T) {
 	)
 }
 
+func TestCheckArrayToHexString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let bytes: [UInt8] = [1, 2, 3, 0xCA, 0xDE]
+            let x = bytes.toHexString()
+	    `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let bytes: [UInt8; 2] = [0xCA, 0xDE]
+            let x = bytes.toHexString()
+	    `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("invalid element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            let ints: [Int] = [1, 2, 3]
+            let x = ints.toHexString()
+	    `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
+
 func TestCheckStringUtf8Field(t *testing.T) {
 
 	t.Parallel()
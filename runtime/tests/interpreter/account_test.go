@@ -45,7 +45,7 @@ type storageKey struct {
 }
 
 func testAccount(
-	t *testing.T,
+	t testing.TB,
 	address interpreter.AddressValue,
 	auth bool,
 	code string,
@@ -137,7 +137,7 @@ func testAccount(
 	return inter, getAccountValues
 }
 
-func returnZeroUInt64(_ *interpreter.Interpreter) interpreter.UInt64Value {
+func returnZeroUInt64(_ *interpreter.Interpreter, _ func() interpreter.LocationRange) interpreter.UInt64Value {
 	return interpreter.NewUnmeteredUInt64Value(0)
 }
 
@@ -332,6 +332,129 @@ func TestInterpretAuthAccount_type(t *testing.T) {
 	})
 }
 
+func TestInterpretAuthAccount_storagePathsExist(t *testing.T) {
+
+	t.Parallel()
+
+	address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+	inter, _ := testAccount(
+		t,
+		address,
+		true,
+		`
+          resource R {}
+
+          fun save() {
+              account.save(<-create R(), to: /storage/a)
+              account.save(1, to: /storage/c)
+          }
+
+          fun test(): [Bool] {
+              return account.storagePathsExist([/storage/a, /storage/b, /storage/c, /storage/a])
+          }
+
+          fun testEmpty(): [Bool] {
+              return account.storagePathsExist([])
+          }
+        `,
+	)
+
+	newBoolArray := func(values ...bool) *interpreter.ArrayValue {
+		elements := make([]interpreter.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, interpreter.BoolValue(value))
+		}
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.ReturnEmptyLocationRange,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+			},
+			common.Address{},
+			elements...,
+		)
+	}
+
+	// Nothing is stored yet
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+	RequireValuesEqual(t, inter, newBoolArray(false, false, false, false), value)
+
+	// Store values at some of the paths
+
+	_, err = inter.Invoke("save")
+	require.NoError(t, err)
+
+	value, err = inter.Invoke("test")
+	require.NoError(t, err)
+	RequireValuesEqual(t, inter, newBoolArray(true, false, true, true), value)
+
+	value, err = inter.Invoke("testEmpty")
+	require.NoError(t, err)
+	RequireValuesEqual(t, inter, newBoolArray(), value)
+}
+
+func BenchmarkInterpretAuthAccount_storagePathsExist(b *testing.B) {
+
+	address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+	inter, _ := testAccount(
+		b,
+		address,
+		true,
+		`
+          let paths: [StoragePath] = [
+              /storage/a, /storage/b, /storage/c, /storage/d,
+              /storage/e, /storage/f, /storage/g, /storage/h
+          ]
+
+          fun save() {
+              account.save(1, to: /storage/a)
+              account.save(2, to: /storage/e)
+          }
+
+          fun batch(): Int {
+              var count = 0
+              for exists in account.storagePathsExist(paths) {
+                  if exists {
+                      count = count + 1
+                  }
+              }
+              return count
+          }
+
+          fun perPath(): Int {
+              var count = 0
+              for path in paths {
+                  if account.type(at: path) != nil {
+                      count = count + 1
+                  }
+              }
+              return count
+          }
+        `,
+	)
+
+	_, err := inter.Invoke("save")
+	require.NoError(b, err)
+
+	for _, name := range []string{"batch", "perPath"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, err := inter.Invoke(name)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestInterpretAuthAccount_load(t *testing.T) {
 
 	t.Parallel()
@@ -1727,6 +1850,84 @@ func TestInterpretAccount_getLinkTarget(t *testing.T) {
 	}
 }
 
+func TestInterpretAccount_getLinkTargetType(t *testing.T) {
+
+	t.Parallel()
+
+	tests := map[bool][]common.PathDomain{
+		true: {
+			common.PathDomainPublic,
+			common.PathDomainPrivate,
+		},
+		false: {
+			common.PathDomainPublic,
+		},
+	}
+
+	for auth, domains := range tests {
+
+		for _, domain := range domains {
+
+			auth := auth
+			domain := domain
+
+			t.Run(fmt.Sprintf("auth: %v, %s", auth, domain.Identifier()), func(t *testing.T) {
+
+				t.Parallel()
+
+				address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+				inter, _ := testAccount(
+					t,
+					address,
+					auth,
+					fmt.Sprintf(
+						`
+                          resource R {}
+
+                          fun link() {
+                              authAccount.link<&R>(/%[1]s/r, target: /storage/r)
+                          }
+
+                          fun existing(): Bool {
+                              return account.getLinkTargetType(/%[1]s/r) == Type<&R>()
+                          }
+
+                          fun nonExisting(): Type? {
+                              return account.getLinkTargetType(/%[1]s/r2)
+                          }
+                        `,
+						domain.Identifier(),
+					),
+				)
+
+				_, err := inter.Invoke("link")
+				require.NoError(t, err)
+
+				value, err := inter.Invoke("existing")
+				require.NoError(t, err)
+
+				AssertValuesEqual(
+					t,
+					inter,
+					interpreter.BoolValue(true),
+					value,
+				)
+
+				value, err = inter.Invoke("nonExisting")
+				require.NoError(t, err)
+
+				AssertValuesEqual(
+					t,
+					inter,
+					interpreter.NilValue{},
+					value,
+				)
+			})
+		}
+	}
+}
+
 func TestInterpretAccount_getCapability(t *testing.T) {
 
 	t.Parallel()
@@ -2590,6 +2791,45 @@ func TestInterpretAccount_iteration(t *testing.T) {
 		)
 
 	})
+
+	t.Run("forEachStored in sorted order", func(t *testing.T) {
+		address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+		inter, _ := testAccount(
+			t,
+			address,
+			true,
+			`
+			fun test(): String {
+				account.save(1, to: /storage/foo2)
+				account.save(2, to: /storage/bar)
+				account.save(3, to: /storage/foo10)
+				account.save(4, to: /storage/baz)
+				account.save(5, to: /storage/foo1)
+
+				var paths = ""
+				account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+					paths = paths.concat(path.toString()).concat(" ")
+					return true
+				})
+
+				return paths
+			}
+            `,
+		)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredStringValue(
+				"/storage/bar /storage/baz /storage/foo1 /storage/foo10 /storage/foo2 ",
+			),
+			value,
+		)
+	})
 }
 
 func TestInterpretAccountIterationMutation(t *testing.T) {
@@ -20,6 +20,7 @@ package interpreter_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/onflow/cadence/runtime/common"
@@ -147,6 +148,80 @@ func TestInterpretToBytes(t *testing.T) {
 	})
 }
 
+func TestInterpretAddressFromString(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		input    string
+		expected interpreter.Value
+	}
+
+	someAddress := func(address uint64) interpreter.Value {
+		return interpreter.NewUnmeteredSomeValueNonCopying(
+			interpreter.NewUnmeteredAddressValueFromBytes(
+				new(big.Int).SetUint64(address).Bytes(),
+			),
+		)
+	}
+
+	for _, testCase := range []testCase{
+		{"0x0000000000000042", someAddress(0x42)},
+		{"0x42", someAddress(0x42)},
+		{"0x042", someAddress(0x42)},
+		{"42", someAddress(0x42)},
+		{"0xf8d6e0586b0a20c7", someAddress(0xf8d6e0586b0a20c7)},
+		{"F8D6E0586B0A20C7", someAddress(0xf8d6e0586b0a20c7)},
+		{"", interpreter.NilValue{}},
+		{"0x", interpreter.NilValue{}},
+		{"0x0x42", interpreter.NilValue{}},
+		{"0xg", interpreter.NilValue{}},
+		{" 0x42", interpreter.NilValue{}},
+		{"0x10000000000000000", interpreter.NilValue{}},
+	} {
+		// NOTE: declare in loop, as captured in closure below
+		testCase := testCase
+
+		t.Run(testCase.input, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x = Address.fromString(%q)
+                    `,
+					testCase.input,
+				),
+			)
+
+			AssertValuesEqual(
+				t,
+				inter,
+				testCase.expected,
+				inter.Globals["x"].GetValue(),
+			)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let x: Address = 0x42
+          let y = Address.fromString(x.toString())
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			someAddress(0x42),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+}
+
 func TestInterpretToBigEndianBytes(t *testing.T) {
 
 	t.Parallel()
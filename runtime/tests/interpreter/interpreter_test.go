@@ -8887,10 +8887,13 @@ func newTestAuthAccountValue(gauge common.MemoryGauge, addressValue interpreter.
 		addressValue,
 		returnZeroUFix64,
 		returnZeroUFix64,
+		panicFunction,
 		returnZeroUInt64,
+		nil,
 		returnZeroUInt64,
 		panicFunction,
 		panicFunction,
+		panicFunction,
 		func() interpreter.Value {
 			return interpreter.NewAuthAccountContractsValue(
 				gauge,
@@ -8899,6 +8902,17 @@ func newTestAuthAccountValue(gauge common.MemoryGauge, addressValue interpreter.
 				panicFunction,
 				panicFunction,
 				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
 				func(
 					inter *interpreter.Interpreter,
 					getLocationRange func() interpreter.LocationRange,
@@ -8921,6 +8935,29 @@ func newTestAuthAccountValue(gauge common.MemoryGauge, addressValue interpreter.
 				panicFunction,
 				panicFunction,
 				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+			)
+		},
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountCapabilitiesValue(
+				gauge,
+				addressValue,
+				panicFunction,
+				panicFunction,
+			)
+		},
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountInboxValue(
+				gauge,
+				addressValue,
+				panicFunction,
+				panicFunction,
+				panicFunction,
 			)
 		},
 	)
@@ -8941,6 +8978,7 @@ func newTestPublicAccountValue(gauge common.MemoryGauge, addressValue interprete
 		addressValue,
 		returnZeroUFix64,
 		returnZeroUFix64,
+		panicFunction,
 		returnZeroUInt64,
 		returnZeroUInt64,
 		func() interpreter.Value {
@@ -8948,6 +8986,10 @@ func newTestPublicAccountValue(gauge common.MemoryGauge, addressValue interprete
 				gauge,
 				addressValue,
 				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
 			)
 		},
 		func() interpreter.Value {
@@ -8955,6 +8997,11 @@ func newTestPublicAccountValue(gauge common.MemoryGauge, addressValue interprete
 				gauge,
 				addressValue,
 				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
+				panicFunction,
 				func(
 					inter *interpreter.Interpreter,
 					getLocationRange func() interpreter.LocationRange,
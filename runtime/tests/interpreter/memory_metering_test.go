@@ -705,7 +705,7 @@ func TestInterpretSimpleCompositeMetering(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindSimpleCompositeValueBase))
-		assert.Equal(t, uint64(4), meter.getMemory(common.MemoryKindSimpleCompositeValue))
+		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindSimpleCompositeValue))
 	})
 
 	t.Run("public account", func(t *testing.T) {
@@ -1192,6 +1192,65 @@ func TestInterpretHostFunctionMetering(t *testing.T) {
 	})
 }
 
+func TestInterpretAccountKeyMetering(t *testing.T) {
+	t.Parallel()
+
+	meter := newTestMemoryGauge()
+	inter := parseCheckAndInterpretWithMemoryMetering(t, "", meter)
+
+	before := make(map[common.MemoryKind]uint64, len(meter.meter))
+	for kind, amount := range meter.meter {
+		before[kind] = amount
+	}
+
+	_ = stdlib.NewAccountKeyValue(
+		inter,
+		interpreter.ReturnEmptyLocationRange,
+		&stdlib.AccountKey{
+			KeyIndex: 1,
+			PublicKey: &stdlib.PublicKey{
+				PublicKey: []byte{1, 2, 3},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+			},
+			HashAlgo:  sema.HashAlgorithmSHA3_256,
+			Weight:    100,
+			IsRevoked: true,
+		},
+		func(
+			_ *interpreter.Interpreter,
+			_ func() interpreter.LocationRange,
+			_ *interpreter.CompositeValue,
+		) error {
+			return nil
+		},
+	)
+
+	usage := func(kind common.MemoryKind) uint64 {
+		return meter.getMemory(kind) - before[kind]
+	}
+
+	// is-revoked and is-active flags
+	assert.Equal(t, uint64(2), usage(common.MemoryKindBoolValue))
+
+	// 3 = account key, hash algorithm case, and signature algorithm case
+	assert.Equal(t, uint64(3), usage(common.MemoryKindSimpleCompositeValueBase))
+
+	// 11 = 6 account key fields, 4 hash algorithm case fields, and 1 signature algorithm case field
+	assert.Equal(t, uint64(11), usage(common.MemoryKindSimpleCompositeValue))
+
+	// 3 = 'hash', 'hashWithTag', and 'hashWithSalt' functions of the hash algorithm case
+	assert.Equal(t, uint64(3), usage(common.MemoryKindHostFunctionValue))
+
+	// public key
+	assert.Equal(t, uint64(1), usage(common.MemoryKindCompositeValueBase))
+
+	// key index
+	assert.Equal(t, uint64(8), usage(common.MemoryKindBigInt))
+
+	// 10 = raw values of the algorithm cases (1 each), and weight (8)
+	assert.Equal(t, uint64(10), usage(common.MemoryKindNumberValue))
+}
+
 func TestInterpretBoundFunctionMetering(t *testing.T) {
 	t.Parallel()
 
@@ -9697,6 +9756,8 @@ func TestInterpretStaticTypeStringConversion(t *testing.T) {
 				interpreter.PrimitiveStaticTypeAuthAccountKeys,
 				interpreter.PrimitiveStaticTypePublicAccountKeys,
 				interpreter.PrimitiveStaticTypeAccountKey,
+				interpreter.PrimitiveStaticTypeAuthAccountCapabilities,
+				interpreter.PrimitiveStaticTypeAuthAccountInbox,
 				interpreter.PrimitiveStaticType_Count:
 				continue
 			case interpreter.PrimitiveStaticTypeAnyResource:
@@ -173,6 +173,66 @@ func TestInterpretStringEncodeHex(t *testing.T) {
 	)
 }
 
+func TestInterpretArrayToHexString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("encode", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): String {
+              let bytes: [UInt8] = [1, 2, 3, 0xCA, 0xDE]
+              return bytes.toHexString()
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredStringValue("010203cade"),
+			result,
+		)
+	})
+
+	t.Run("round-trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [String] {
+              return [
+                  "01CaDe".decodeHex().toHexString(),
+                  "".decodeHex().toHexString()
+              ]
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.ReturnEmptyLocationRange,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewUnmeteredStringValue("01cade"),
+				interpreter.NewUnmeteredStringValue(""),
+			),
+			result,
+		)
+	})
+}
+
 func TestInterpretStringFromUtf8(t *testing.T) {
 	t.Parallel()
 
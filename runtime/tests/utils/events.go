@@ -0,0 +1,107 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// RecordedEvent is an event recorded by a RecordingEventEmitter.
+type RecordedEvent struct {
+	EventType *sema.CompositeType
+	Values    []interpreter.Value
+}
+
+// RecordingEventEmitter records all emitted events, in order.
+//
+// It implements the `EventEmitter` interface of the standard library,
+// so it can be used to capture the events emitted by the account functions in tests.
+type RecordingEventEmitter struct {
+	Events []RecordedEvent
+}
+
+func (e *RecordingEventEmitter) EmitEvent(
+	_ *interpreter.Interpreter,
+	eventType *sema.CompositeType,
+	values []interpreter.Value,
+	_ func() interpreter.LocationRange,
+) {
+	e.Events = append(
+		e.Events,
+		RecordedEvent{
+			EventType: eventType,
+			Values:    values,
+		},
+	)
+}
+
+// EventsOfType returns the recorded events of the given type, in order.
+func (e *RecordingEventEmitter) EventsOfType(eventType *sema.CompositeType) []RecordedEvent {
+	var events []RecordedEvent
+	for _, event := range e.Events {
+		if event.EventType.Equal(eventType) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// AssertEmitted asserts that an event of the given type was emitted with the given field values.
+//
+// Values are compared using their equality, so the given interpreter
+// must be able to access the recorded values.
+func (e *RecordingEventEmitter) AssertEmitted(
+	t assert.TestingT,
+	inter *interpreter.Interpreter,
+	eventType *sema.CompositeType,
+	values ...interpreter.Value,
+) bool {
+	for _, event := range e.EventsOfType(eventType) {
+		if eventValuesEqual(inter, event.Values, values) {
+			return true
+		}
+	}
+
+	return assert.Fail(
+		t,
+		"event not emitted",
+		"expected event %s with values %s, recorded events: %s",
+		eventType.QualifiedIdentifier(),
+		values,
+		e.Events,
+	)
+}
+
+func eventValuesEqual(inter *interpreter.Interpreter, values, expected []interpreter.Value) bool {
+	if len(values) != len(expected) {
+		return false
+	}
+
+	for i, value := range values {
+		equatableValue, ok := value.(interpreter.EquatableValue)
+		if !ok || !equatableValue.Equal(inter, interpreter.ReturnEmptyLocationRange, expected[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+var _ stdlib.EventEmitter = &utils.RecordingEventEmitter{}
+
+type testErrorRecorder struct {
+	errors []string
+}
+
+func (r *testErrorRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecordingEventEmitter(t *testing.T) {
+
+	t.Parallel()
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		utils.TestLocation,
+		&interpreter.Config{
+			Storage: interpreter.NewInMemoryStorage(nil),
+		},
+	)
+	require.NoError(t, err)
+
+	address := interpreter.AddressValue(common.MustBytesToAddress([]byte{0x1}))
+	payer := interpreter.AddressValue(common.MustBytesToAddress([]byte{0x2}))
+
+	newPublicKeyValue := func() *interpreter.ArrayValue {
+		return interpreter.ByteSliceToByteArrayValue(inter, []byte{1, 2, 3})
+	}
+
+	var emitter stdlib.EventEmitter
+	recorder := &utils.RecordingEventEmitter{}
+	emitter = recorder
+
+	emitter.EmitEvent(
+		inter,
+		stdlib.AccountCreatedEventType,
+		[]interpreter.Value{address, payer},
+		interpreter.ReturnEmptyLocationRange,
+	)
+
+	emitter.EmitEvent(
+		inter,
+		stdlib.AccountKeyAddedEventType,
+		[]interpreter.Value{address, newPublicKeyValue()},
+		interpreter.ReturnEmptyLocationRange,
+	)
+
+	// Events are recorded in order
+
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, stdlib.AccountCreatedEventType, recorder.Events[0].EventType)
+	assert.Equal(t, stdlib.AccountKeyAddedEventType, recorder.Events[1].EventType)
+
+	assert.Len(t, recorder.EventsOfType(stdlib.AccountCreatedEventType), 1)
+	assert.Len(t, recorder.EventsOfType(stdlib.AccountKeyAddedEventType), 1)
+	assert.Empty(t, recorder.EventsOfType(stdlib.AccountKeyRemovedEventType))
+
+	recorder.AssertEmitted(
+		t,
+		inter,
+		stdlib.AccountCreatedEventType,
+		address,
+		payer,
+	)
+
+	recorder.AssertEmitted(
+		t,
+		inter,
+		stdlib.AccountKeyAddedEventType,
+		address,
+		newPublicKeyValue(),
+	)
+
+	// Events with different values are not matched
+
+	errorRecorder := &testErrorRecorder{}
+	assert.False(t,
+		recorder.AssertEmitted(
+			errorRecorder,
+			inter,
+			stdlib.AccountCreatedEventType,
+			payer,
+			address,
+		),
+	)
+	assert.Len(t, errorRecorder.errors, 1)
+}
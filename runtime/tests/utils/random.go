@@ -0,0 +1,61 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// TestRandomSource is a seedable, deterministic source for the random numbers and UUIDs
+// the runtime requests from the host environment.
+//
+// Its methods have the same signatures as the runtime interface functions
+// `UnsafeRandom` and `GenerateUUID`, so they can be used to implement them in tests.
+// Two sources created with the same seed produce identical sequences.
+type TestRandomSource struct {
+	mutex  sync.Mutex
+	random *rand.Rand
+	uuids  *rand.Rand
+}
+
+func NewTestRandomSource(seed int64) *TestRandomSource {
+	return &TestRandomSource{
+		random: rand.New(rand.NewSource(seed)),
+		// UUIDs are generated from a separate source,
+		// so the sequences do not depend on each other
+		uuids: rand.New(rand.NewSource(^seed)),
+	}
+}
+
+// UnsafeRandom returns the next random number of the sequence.
+func (s *TestRandomSource) UnsafeRandom() (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.random.Uint64(), nil
+}
+
+// GenerateUUID returns the next UUID of the sequence.
+func (s *TestRandomSource) GenerateUUID() (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.uuids.Uint64(), nil
+}
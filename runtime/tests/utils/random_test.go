@@ -0,0 +1,68 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRandomSource(t *testing.T) {
+
+	t.Parallel()
+
+	const count = 10
+
+	generate := func(source *TestRandomSource) (randoms, uuids []uint64) {
+		for i := 0; i < count; i++ {
+			random, err := source.UnsafeRandom()
+			require.NoError(t, err)
+			randoms = append(randoms, random)
+
+			uuid, err := source.GenerateUUID()
+			require.NoError(t, err)
+			uuids = append(uuids, uuid)
+		}
+		return
+	}
+
+	t.Run("same seed", func(t *testing.T) {
+
+		t.Parallel()
+
+		randoms1, uuids1 := generate(NewTestRandomSource(42))
+		randoms2, uuids2 := generate(NewTestRandomSource(42))
+
+		assert.Equal(t, randoms1, randoms2)
+		assert.Equal(t, uuids1, uuids2)
+	})
+
+	t.Run("different seeds", func(t *testing.T) {
+
+		t.Parallel()
+
+		randoms1, uuids1 := generate(NewTestRandomSource(1))
+		randoms2, uuids2 := generate(NewTestRandomSource(2))
+
+		assert.NotEqual(t, randoms1, randoms2)
+		assert.NotEqual(t, uuids1, uuids2)
+	})
+}
@@ -102,7 +102,7 @@ func (executor *interpreterTransactionExecutor) preprocess() (err error) {
 
 	runtimeInterface := context.Interface
 
-	storage := NewStorage(runtimeInterface, runtimeInterface)
+	storage := NewStorage(context.storageLedger(), runtimeInterface)
 	executor.storage = storage
 
 	environment := context.Environment
@@ -212,6 +212,8 @@ func (executor *interpreterTransactionExecutor) execute() (err error) {
 	codesAndPrograms := executor.codesAndPrograms
 	interpreterRuntime := executor.runtime
 
+	defer environment.ReportMemoryUsage()
+
 	defer interpreterRuntime.Recover(
 		func(internalErr Error) {
 			err = internalErr
@@ -61,3 +61,13 @@ const (
 type PublicKey = stdlib.PublicKey
 type AccountKey = stdlib.AccountKey
 type Block = stdlib.Block
+type ContractVersion = stdlib.ContractVersion
+
+type AccountAccessKind = stdlib.AccountAccessKind
+
+const (
+	AccountAccessKindUnknown      = stdlib.AccountAccessKindUnknown
+	AccountAccessKindAccount      = stdlib.AccountAccessKindAccount
+	AccountAccessKindStorageRead  = stdlib.AccountAccessKindStorageRead
+	AccountAccessKindStorageWrite = stdlib.AccountAccessKindStorageWrite
+)
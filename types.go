@@ -1815,6 +1815,46 @@ func (AuthAccountContractsType) ID() string {
 	return "AuthAccount.Contracts"
 }
 
+// AuthAccountCapabilitiesType
+type AuthAccountCapabilitiesType struct{}
+
+func NewAuthAccountCapabilitiesType() AuthAccountCapabilitiesType {
+	return AuthAccountCapabilitiesType{}
+}
+
+func NewMeteredAuthAccountCapabilitiesType(
+	gauge common.MemoryGauge,
+) AuthAccountCapabilitiesType {
+	common.UseMemory(gauge, common.CadenceSimpleTypeMemoryUsage)
+	return NewAuthAccountCapabilitiesType()
+}
+
+func (AuthAccountCapabilitiesType) isType() {}
+
+func (AuthAccountCapabilitiesType) ID() string {
+	return "AuthAccount.Capabilities"
+}
+
+// AuthAccountInboxType
+type AuthAccountInboxType struct{}
+
+func NewAuthAccountInboxType() AuthAccountInboxType {
+	return AuthAccountInboxType{}
+}
+
+func NewMeteredAuthAccountInboxType(
+	gauge common.MemoryGauge,
+) AuthAccountInboxType {
+	common.UseMemory(gauge, common.CadenceSimpleTypeMemoryUsage)
+	return NewAuthAccountInboxType()
+}
+
+func (AuthAccountInboxType) isType() {}
+
+func (AuthAccountInboxType) ID() string {
+	return "AuthAccount.Inbox"
+}
+
 // PublicAccountContractsType
 type PublicAccountContractsType struct{}
 